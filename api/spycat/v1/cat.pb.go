@@ -0,0 +1,868 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: spycat/v1/cat.proto
+
+package spycatv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Cat struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	YearsExperience int32                  `protobuf:"varint,3,opt,name=years_experience,json=yearsExperience,proto3" json:"years_experience,omitempty"`
+	Breed           string                 `protobuf:"bytes,4,opt,name=breed,proto3" json:"breed,omitempty"`
+	BreedVerified   bool                   `protobuf:"varint,5,opt,name=breed_verified,json=breedVerified,proto3" json:"breed_verified,omitempty"`
+	Salary          float64                `protobuf:"fixed64,6,opt,name=salary,proto3" json:"salary,omitempty"`
+	Version         int32                  `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Set only on soft-deleted cats
+	DeletedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Cat) Reset() {
+	*x = Cat{}
+	mi := &file_spycat_v1_cat_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Cat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cat) ProtoMessage() {}
+
+func (x *Cat) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_cat_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cat.ProtoReflect.Descriptor instead.
+func (*Cat) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_cat_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Cat) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Cat) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Cat) GetYearsExperience() int32 {
+	if x != nil {
+		return x.YearsExperience
+	}
+	return 0
+}
+
+func (x *Cat) GetBreed() string {
+	if x != nil {
+		return x.Breed
+	}
+	return ""
+}
+
+func (x *Cat) GetBreedVerified() bool {
+	if x != nil {
+		return x.BreedVerified
+	}
+	return false
+}
+
+func (x *Cat) GetSalary() float64 {
+	if x != nil {
+		return x.Salary
+	}
+	return 0
+}
+
+func (x *Cat) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Cat) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Cat) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Cat) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+// ListParams are the pagination and sorting parameters of list calls
+type ListParams struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Page size, 1-100; 20 when unset
+	Limit int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// next_cursor of the previous page
+	Cursor string `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// Sort column, prefixed with - for descending order
+	Sort          string `protobuf:"bytes,3,opt,name=sort,proto3" json:"sort,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListParams) Reset() {
+	*x = ListParams{}
+	mi := &file_spycat_v1_cat_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListParams) ProtoMessage() {}
+
+func (x *ListParams) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_cat_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListParams.ProtoReflect.Descriptor instead.
+func (*ListParams) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_cat_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListParams) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListParams) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListParams) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+type PageInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Total int32                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	// Empty on the last page
+	NextCursor    string `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PageInfo) Reset() {
+	*x = PageInfo{}
+	mi := &file_spycat_v1_cat_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PageInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageInfo) ProtoMessage() {}
+
+func (x *PageInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_cat_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PageInfo.ProtoReflect.Descriptor instead.
+func (*PageInfo) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_cat_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PageInfo) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *PageInfo) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type CreateCatRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Name            string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	YearsExperience int32                  `protobuf:"varint,2,opt,name=years_experience,json=yearsExperience,proto3" json:"years_experience,omitempty"`
+	Breed           string                 `protobuf:"bytes,3,opt,name=breed,proto3" json:"breed,omitempty"`
+	Salary          float64                `protobuf:"fixed64,4,opt,name=salary,proto3" json:"salary,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreateCatRequest) Reset() {
+	*x = CreateCatRequest{}
+	mi := &file_spycat_v1_cat_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCatRequest) ProtoMessage() {}
+
+func (x *CreateCatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_cat_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCatRequest.ProtoReflect.Descriptor instead.
+func (*CreateCatRequest) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_cat_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateCatRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateCatRequest) GetYearsExperience() int32 {
+	if x != nil {
+		return x.YearsExperience
+	}
+	return 0
+}
+
+func (x *CreateCatRequest) GetBreed() string {
+	if x != nil {
+		return x.Breed
+	}
+	return ""
+}
+
+func (x *CreateCatRequest) GetSalary() float64 {
+	if x != nil {
+		return x.Salary
+	}
+	return 0
+}
+
+type GetCatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCatRequest) Reset() {
+	*x = GetCatRequest{}
+	mi := &file_spycat_v1_cat_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCatRequest) ProtoMessage() {}
+
+func (x *GetCatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_cat_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCatRequest.ProtoReflect.Descriptor instead.
+func (*GetCatRequest) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_cat_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetCatRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListCatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          *ListParams            `protobuf:"bytes,1,opt,name=page,proto3" json:"page,omitempty"`
+	Breed         string                 `protobuf:"bytes,2,opt,name=breed,proto3" json:"breed,omitempty"`
+	MinExperience *int32                 `protobuf:"varint,3,opt,name=min_experience,json=minExperience,proto3,oneof" json:"min_experience,omitempty"`
+	MaxExperience *int32                 `protobuf:"varint,4,opt,name=max_experience,json=maxExperience,proto3,oneof" json:"max_experience,omitempty"`
+	MinSalary     *float64               `protobuf:"fixed64,5,opt,name=min_salary,json=minSalary,proto3,oneof" json:"min_salary,omitempty"`
+	MaxSalary     *float64               `protobuf:"fixed64,6,opt,name=max_salary,json=maxSalary,proto3,oneof" json:"max_salary,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCatsRequest) Reset() {
+	*x = ListCatsRequest{}
+	mi := &file_spycat_v1_cat_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCatsRequest) ProtoMessage() {}
+
+func (x *ListCatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_cat_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCatsRequest.ProtoReflect.Descriptor instead.
+func (*ListCatsRequest) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_cat_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListCatsRequest) GetPage() *ListParams {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+func (x *ListCatsRequest) GetBreed() string {
+	if x != nil {
+		return x.Breed
+	}
+	return ""
+}
+
+func (x *ListCatsRequest) GetMinExperience() int32 {
+	if x != nil && x.MinExperience != nil {
+		return *x.MinExperience
+	}
+	return 0
+}
+
+func (x *ListCatsRequest) GetMaxExperience() int32 {
+	if x != nil && x.MaxExperience != nil {
+		return *x.MaxExperience
+	}
+	return 0
+}
+
+func (x *ListCatsRequest) GetMinSalary() float64 {
+	if x != nil && x.MinSalary != nil {
+		return *x.MinSalary
+	}
+	return 0
+}
+
+func (x *ListCatsRequest) GetMaxSalary() float64 {
+	if x != nil && x.MaxSalary != nil {
+		return *x.MaxSalary
+	}
+	return 0
+}
+
+type ListCatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*Cat                 `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	Page          *PageInfo              `protobuf:"bytes,2,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCatsResponse) Reset() {
+	*x = ListCatsResponse{}
+	mi := &file_spycat_v1_cat_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCatsResponse) ProtoMessage() {}
+
+func (x *ListCatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_cat_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCatsResponse.ProtoReflect.Descriptor instead.
+func (*ListCatsResponse) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_cat_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListCatsResponse) GetItems() []*Cat {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ListCatsResponse) GetPage() *PageInfo {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+type UpdateCatSalaryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Apply the change only if this is still the current version; 0 skips the check
+	Version int32   `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Salary  float64 `protobuf:"fixed64,3,opt,name=salary,proto3" json:"salary,omitempty"`
+	// First day of the new salary as YYYY-MM-DD
+	EffectiveFrom string `protobuf:"bytes,4,opt,name=effective_from,json=effectiveFrom,proto3" json:"effective_from,omitempty"`
+	Reason        string `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCatSalaryRequest) Reset() {
+	*x = UpdateCatSalaryRequest{}
+	mi := &file_spycat_v1_cat_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCatSalaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCatSalaryRequest) ProtoMessage() {}
+
+func (x *UpdateCatSalaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_cat_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCatSalaryRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCatSalaryRequest) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_cat_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpdateCatSalaryRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateCatSalaryRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *UpdateCatSalaryRequest) GetSalary() float64 {
+	if x != nil {
+		return x.Salary
+	}
+	return 0
+}
+
+func (x *UpdateCatSalaryRequest) GetEffectiveFrom() string {
+	if x != nil {
+		return x.EffectiveFrom
+	}
+	return ""
+}
+
+func (x *UpdateCatSalaryRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type DeleteCatRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Delete only if this is still the current version; 0 skips the check
+	Version       int32 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCatRequest) Reset() {
+	*x = DeleteCatRequest{}
+	mi := &file_spycat_v1_cat_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCatRequest) ProtoMessage() {}
+
+func (x *DeleteCatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_cat_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCatRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCatRequest) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_cat_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteCatRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeleteCatRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type DeleteCatResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCatResponse) Reset() {
+	*x = DeleteCatResponse{}
+	mi := &file_spycat_v1_cat_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCatResponse) ProtoMessage() {}
+
+func (x *DeleteCatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_cat_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCatResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCatResponse) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_cat_proto_rawDescGZIP(), []int{9}
+}
+
+type RestoreCatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreCatRequest) Reset() {
+	*x = RestoreCatRequest{}
+	mi := &file_spycat_v1_cat_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreCatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreCatRequest) ProtoMessage() {}
+
+func (x *RestoreCatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_cat_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreCatRequest.ProtoReflect.Descriptor instead.
+func (*RestoreCatRequest) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_cat_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RestoreCatRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+var File_spycat_v1_cat_proto protoreflect.FileDescriptor
+
+const file_spycat_v1_cat_proto_rawDesc = "" +
+	"\n" +
+	"\x13spycat/v1/cat.proto\x12\tspycat.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf4\x02\n" +
+	"\x03Cat\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12)\n" +
+	"\x10years_experience\x18\x03 \x01(\x05R\x0fyearsExperience\x12\x14\n" +
+	"\x05breed\x18\x04 \x01(\tR\x05breed\x12%\n" +
+	"\x0ebreed_verified\x18\x05 \x01(\bR\rbreedVerified\x12\x16\n" +
+	"\x06salary\x18\x06 \x01(\x01R\x06salary\x12\x18\n" +
+	"\aversion\x18\a \x01(\x05R\aversion\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x129\n" +
+	"\n" +
+	"deleted_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\"N\n" +
+	"\n" +
+	"ListParams\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\tR\x06cursor\x12\x12\n" +
+	"\x04sort\x18\x03 \x01(\tR\x04sort\"A\n" +
+	"\bPageInfo\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x05R\x05total\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"\x7f\n" +
+	"\x10CreateCatRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12)\n" +
+	"\x10years_experience\x18\x02 \x01(\x05R\x0fyearsExperience\x12\x14\n" +
+	"\x05breed\x18\x03 \x01(\tR\x05breed\x12\x16\n" +
+	"\x06salary\x18\x04 \x01(\x01R\x06salary\"\x1f\n" +
+	"\rGetCatRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"\xb6\x02\n" +
+	"\x0fListCatsRequest\x12)\n" +
+	"\x04page\x18\x01 \x01(\v2\x15.spycat.v1.ListParamsR\x04page\x12\x14\n" +
+	"\x05breed\x18\x02 \x01(\tR\x05breed\x12*\n" +
+	"\x0emin_experience\x18\x03 \x01(\x05H\x00R\rminExperience\x88\x01\x01\x12*\n" +
+	"\x0emax_experience\x18\x04 \x01(\x05H\x01R\rmaxExperience\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"min_salary\x18\x05 \x01(\x01H\x02R\tminSalary\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"max_salary\x18\x06 \x01(\x01H\x03R\tmaxSalary\x88\x01\x01B\x11\n" +
+	"\x0f_min_experienceB\x11\n" +
+	"\x0f_max_experienceB\r\n" +
+	"\v_min_salaryB\r\n" +
+	"\v_max_salary\"a\n" +
+	"\x10ListCatsResponse\x12$\n" +
+	"\x05items\x18\x01 \x03(\v2\x0e.spycat.v1.CatR\x05items\x12'\n" +
+	"\x04page\x18\x02 \x01(\v2\x13.spycat.v1.PageInfoR\x04page\"\x99\x01\n" +
+	"\x16UpdateCatSalaryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\x12\x16\n" +
+	"\x06salary\x18\x03 \x01(\x01R\x06salary\x12%\n" +
+	"\x0eeffective_from\x18\x04 \x01(\tR\reffectiveFrom\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\"<\n" +
+	"\x10DeleteCatRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\"\x13\n" +
+	"\x11DeleteCatResponse\"#\n" +
+	"\x11RestoreCatRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id2\x89\x03\n" +
+	"\n" +
+	"CatService\x128\n" +
+	"\tCreateCat\x12\x1b.spycat.v1.CreateCatRequest\x1a\x0e.spycat.v1.Cat\x122\n" +
+	"\x06GetCat\x12\x18.spycat.v1.GetCatRequest\x1a\x0e.spycat.v1.Cat\x12C\n" +
+	"\bListCats\x12\x1a.spycat.v1.ListCatsRequest\x1a\x1b.spycat.v1.ListCatsResponse\x12D\n" +
+	"\x0fUpdateCatSalary\x12!.spycat.v1.UpdateCatSalaryRequest\x1a\x0e.spycat.v1.Cat\x12F\n" +
+	"\tDeleteCat\x12\x1b.spycat.v1.DeleteCatRequest\x1a\x1c.spycat.v1.DeleteCatResponse\x12:\n" +
+	"\n" +
+	"RestoreCat\x12\x1c.spycat.v1.RestoreCatRequest\x1a\x0e.spycat.v1.CatB%Z#SpyCatAgency/api/spycat/v1;spycatv1b\x06proto3"
+
+var (
+	file_spycat_v1_cat_proto_rawDescOnce sync.Once
+	file_spycat_v1_cat_proto_rawDescData []byte
+)
+
+func file_spycat_v1_cat_proto_rawDescGZIP() []byte {
+	file_spycat_v1_cat_proto_rawDescOnce.Do(func() {
+		file_spycat_v1_cat_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_spycat_v1_cat_proto_rawDesc), len(file_spycat_v1_cat_proto_rawDesc)))
+	})
+	return file_spycat_v1_cat_proto_rawDescData
+}
+
+var file_spycat_v1_cat_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_spycat_v1_cat_proto_goTypes = []any{
+	(*Cat)(nil),                    // 0: spycat.v1.Cat
+	(*ListParams)(nil),             // 1: spycat.v1.ListParams
+	(*PageInfo)(nil),               // 2: spycat.v1.PageInfo
+	(*CreateCatRequest)(nil),       // 3: spycat.v1.CreateCatRequest
+	(*GetCatRequest)(nil),          // 4: spycat.v1.GetCatRequest
+	(*ListCatsRequest)(nil),        // 5: spycat.v1.ListCatsRequest
+	(*ListCatsResponse)(nil),       // 6: spycat.v1.ListCatsResponse
+	(*UpdateCatSalaryRequest)(nil), // 7: spycat.v1.UpdateCatSalaryRequest
+	(*DeleteCatRequest)(nil),       // 8: spycat.v1.DeleteCatRequest
+	(*DeleteCatResponse)(nil),      // 9: spycat.v1.DeleteCatResponse
+	(*RestoreCatRequest)(nil),      // 10: spycat.v1.RestoreCatRequest
+	(*timestamppb.Timestamp)(nil),  // 11: google.protobuf.Timestamp
+}
+var file_spycat_v1_cat_proto_depIdxs = []int32{
+	11, // 0: spycat.v1.Cat.created_at:type_name -> google.protobuf.Timestamp
+	11, // 1: spycat.v1.Cat.updated_at:type_name -> google.protobuf.Timestamp
+	11, // 2: spycat.v1.Cat.deleted_at:type_name -> google.protobuf.Timestamp
+	1,  // 3: spycat.v1.ListCatsRequest.page:type_name -> spycat.v1.ListParams
+	0,  // 4: spycat.v1.ListCatsResponse.items:type_name -> spycat.v1.Cat
+	2,  // 5: spycat.v1.ListCatsResponse.page:type_name -> spycat.v1.PageInfo
+	3,  // 6: spycat.v1.CatService.CreateCat:input_type -> spycat.v1.CreateCatRequest
+	4,  // 7: spycat.v1.CatService.GetCat:input_type -> spycat.v1.GetCatRequest
+	5,  // 8: spycat.v1.CatService.ListCats:input_type -> spycat.v1.ListCatsRequest
+	7,  // 9: spycat.v1.CatService.UpdateCatSalary:input_type -> spycat.v1.UpdateCatSalaryRequest
+	8,  // 10: spycat.v1.CatService.DeleteCat:input_type -> spycat.v1.DeleteCatRequest
+	10, // 11: spycat.v1.CatService.RestoreCat:input_type -> spycat.v1.RestoreCatRequest
+	0,  // 12: spycat.v1.CatService.CreateCat:output_type -> spycat.v1.Cat
+	0,  // 13: spycat.v1.CatService.GetCat:output_type -> spycat.v1.Cat
+	6,  // 14: spycat.v1.CatService.ListCats:output_type -> spycat.v1.ListCatsResponse
+	0,  // 15: spycat.v1.CatService.UpdateCatSalary:output_type -> spycat.v1.Cat
+	9,  // 16: spycat.v1.CatService.DeleteCat:output_type -> spycat.v1.DeleteCatResponse
+	0,  // 17: spycat.v1.CatService.RestoreCat:output_type -> spycat.v1.Cat
+	12, // [12:18] is the sub-list for method output_type
+	6,  // [6:12] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_spycat_v1_cat_proto_init() }
+func file_spycat_v1_cat_proto_init() {
+	if File_spycat_v1_cat_proto != nil {
+		return
+	}
+	file_spycat_v1_cat_proto_msgTypes[5].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_spycat_v1_cat_proto_rawDesc), len(file_spycat_v1_cat_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_spycat_v1_cat_proto_goTypes,
+		DependencyIndexes: file_spycat_v1_cat_proto_depIdxs,
+		MessageInfos:      file_spycat_v1_cat_proto_msgTypes,
+	}.Build()
+	File_spycat_v1_cat_proto = out.File
+	file_spycat_v1_cat_proto_goTypes = nil
+	file_spycat_v1_cat_proto_depIdxs = nil
+}
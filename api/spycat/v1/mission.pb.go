@@ -0,0 +1,1420 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: spycat/v1/mission.proto
+
+package spycatv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type MissionStatus int32
+
+const (
+	MissionStatus_MISSION_STATUS_UNSPECIFIED MissionStatus = 0
+	MissionStatus_MISSION_STATUS_DRAFT       MissionStatus = 1
+	MissionStatus_MISSION_STATUS_ASSIGNED    MissionStatus = 2
+	MissionStatus_MISSION_STATUS_IN_PROGRESS MissionStatus = 3
+	MissionStatus_MISSION_STATUS_COMPLETED   MissionStatus = 4
+	MissionStatus_MISSION_STATUS_ABORTED     MissionStatus = 5
+)
+
+// Enum value maps for MissionStatus.
+var (
+	MissionStatus_name = map[int32]string{
+		0: "MISSION_STATUS_UNSPECIFIED",
+		1: "MISSION_STATUS_DRAFT",
+		2: "MISSION_STATUS_ASSIGNED",
+		3: "MISSION_STATUS_IN_PROGRESS",
+		4: "MISSION_STATUS_COMPLETED",
+		5: "MISSION_STATUS_ABORTED",
+	}
+	MissionStatus_value = map[string]int32{
+		"MISSION_STATUS_UNSPECIFIED": 0,
+		"MISSION_STATUS_DRAFT":       1,
+		"MISSION_STATUS_ASSIGNED":    2,
+		"MISSION_STATUS_IN_PROGRESS": 3,
+		"MISSION_STATUS_COMPLETED":   4,
+		"MISSION_STATUS_ABORTED":     5,
+	}
+)
+
+func (x MissionStatus) Enum() *MissionStatus {
+	p := new(MissionStatus)
+	*p = x
+	return p
+}
+
+func (x MissionStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MissionStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_spycat_v1_mission_proto_enumTypes[0].Descriptor()
+}
+
+func (MissionStatus) Type() protoreflect.EnumType {
+	return &file_spycat_v1_mission_proto_enumTypes[0]
+}
+
+func (x MissionStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MissionStatus.Descriptor instead.
+func (MissionStatus) EnumDescriptor() ([]byte, []int) {
+	return file_spycat_v1_mission_proto_rawDescGZIP(), []int{0}
+}
+
+type Mission struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name      string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	CatId     *uint32                `protobuf:"varint,3,opt,name=cat_id,json=catId,proto3,oneof" json:"cat_id,omitempty"`
+	Status    MissionStatus          `protobuf:"varint,4,opt,name=status,proto3,enum=spycat.v1.MissionStatus" json:"status,omitempty"`
+	Version   int32                  `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Set only on soft-deleted missions
+	DeletedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	// Set only when requested with include_cat
+	Cat *Cat `protobuf:"bytes,9,opt,name=cat,proto3" json:"cat,omitempty"`
+	// Set only when requested with include_targets
+	Targets       []*Target `protobuf:"bytes,10,rep,name=targets,proto3" json:"targets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Mission) Reset() {
+	*x = Mission{}
+	mi := &file_spycat_v1_mission_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Mission) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Mission) ProtoMessage() {}
+
+func (x *Mission) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_mission_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Mission.ProtoReflect.Descriptor instead.
+func (*Mission) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_mission_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Mission) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Mission) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Mission) GetCatId() uint32 {
+	if x != nil && x.CatId != nil {
+		return *x.CatId
+	}
+	return 0
+}
+
+func (x *Mission) GetStatus() MissionStatus {
+	if x != nil {
+		return x.Status
+	}
+	return MissionStatus_MISSION_STATUS_UNSPECIFIED
+}
+
+func (x *Mission) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Mission) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Mission) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Mission) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *Mission) GetCat() *Cat {
+	if x != nil {
+		return x.Cat
+	}
+	return nil
+}
+
+func (x *Mission) GetTargets() []*Target {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+type Target struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	MissionId uint32                 `protobuf:"varint,2,opt,name=mission_id,json=missionId,proto3" json:"mission_id,omitempty"`
+	Name      string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Country   string                 `protobuf:"bytes,4,opt,name=country,proto3" json:"country,omitempty"`
+	Notes     string                 `protobuf:"bytes,5,opt,name=notes,proto3" json:"notes,omitempty"`
+	Completed bool                   `protobuf:"varint,6,opt,name=completed,proto3" json:"completed,omitempty"`
+	Version   int32                  `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Set only on soft-deleted targets
+	DeletedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Target) Reset() {
+	*x = Target{}
+	mi := &file_spycat_v1_mission_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Target) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Target) ProtoMessage() {}
+
+func (x *Target) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_mission_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Target.ProtoReflect.Descriptor instead.
+func (*Target) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_mission_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Target) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Target) GetMissionId() uint32 {
+	if x != nil {
+		return x.MissionId
+	}
+	return 0
+}
+
+func (x *Target) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Target) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *Target) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *Target) GetCompleted() bool {
+	if x != nil {
+		return x.Completed
+	}
+	return false
+}
+
+func (x *Target) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Target) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Target) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Target) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+// MissionInclude selects the relations returned with missions
+type MissionInclude struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cat           bool                   `protobuf:"varint,1,opt,name=cat,proto3" json:"cat,omitempty"`
+	Targets       bool                   `protobuf:"varint,2,opt,name=targets,proto3" json:"targets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MissionInclude) Reset() {
+	*x = MissionInclude{}
+	mi := &file_spycat_v1_mission_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MissionInclude) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MissionInclude) ProtoMessage() {}
+
+func (x *MissionInclude) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_mission_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MissionInclude.ProtoReflect.Descriptor instead.
+func (*MissionInclude) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_mission_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MissionInclude) GetCat() bool {
+	if x != nil {
+		return x.Cat
+	}
+	return false
+}
+
+func (x *MissionInclude) GetTargets() bool {
+	if x != nil {
+		return x.Targets
+	}
+	return false
+}
+
+type CreateTarget struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Country       string                 `protobuf:"bytes,2,opt,name=country,proto3" json:"country,omitempty"`
+	Notes         string                 `protobuf:"bytes,3,opt,name=notes,proto3" json:"notes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTarget) Reset() {
+	*x = CreateTarget{}
+	mi := &file_spycat_v1_mission_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTarget) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTarget) ProtoMessage() {}
+
+func (x *CreateTarget) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_mission_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTarget.ProtoReflect.Descriptor instead.
+func (*CreateTarget) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_mission_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateTarget) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateTarget) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *CreateTarget) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+type CreateMissionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	CatId *uint32                `protobuf:"varint,2,opt,name=cat_id,json=catId,proto3,oneof" json:"cat_id,omitempty"`
+	// 1 to 3 targets
+	Targets       []*CreateTarget `protobuf:"bytes,3,rep,name=targets,proto3" json:"targets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateMissionRequest) Reset() {
+	*x = CreateMissionRequest{}
+	mi := &file_spycat_v1_mission_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMissionRequest) ProtoMessage() {}
+
+func (x *CreateMissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_mission_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMissionRequest.ProtoReflect.Descriptor instead.
+func (*CreateMissionRequest) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_mission_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateMissionRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateMissionRequest) GetCatId() uint32 {
+	if x != nil && x.CatId != nil {
+		return *x.CatId
+	}
+	return 0
+}
+
+func (x *CreateMissionRequest) GetTargets() []*CreateTarget {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+type GetMissionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Include       *MissionInclude        `protobuf:"bytes,2,opt,name=include,proto3" json:"include,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMissionRequest) Reset() {
+	*x = GetMissionRequest{}
+	mi := &file_spycat_v1_mission_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMissionRequest) ProtoMessage() {}
+
+func (x *GetMissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_mission_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMissionRequest.ProtoReflect.Descriptor instead.
+func (*GetMissionRequest) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_mission_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetMissionRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GetMissionRequest) GetInclude() *MissionInclude {
+	if x != nil {
+		return x.Include
+	}
+	return nil
+}
+
+type ListMissionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          *ListParams            `protobuf:"bytes,1,opt,name=page,proto3" json:"page,omitempty"`
+	Status        MissionStatus          `protobuf:"varint,2,opt,name=status,proto3,enum=spycat.v1.MissionStatus" json:"status,omitempty"`
+	Completed     *bool                  `protobuf:"varint,3,opt,name=completed,proto3,oneof" json:"completed,omitempty"`
+	CatId         *uint32                `protobuf:"varint,4,opt,name=cat_id,json=catId,proto3,oneof" json:"cat_id,omitempty"`
+	Country       string                 `protobuf:"bytes,5,opt,name=country,proto3" json:"country,omitempty"`
+	Include       *MissionInclude        `protobuf:"bytes,6,opt,name=include,proto3" json:"include,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMissionsRequest) Reset() {
+	*x = ListMissionsRequest{}
+	mi := &file_spycat_v1_mission_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMissionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMissionsRequest) ProtoMessage() {}
+
+func (x *ListMissionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_mission_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMissionsRequest.ProtoReflect.Descriptor instead.
+func (*ListMissionsRequest) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_mission_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListMissionsRequest) GetPage() *ListParams {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+func (x *ListMissionsRequest) GetStatus() MissionStatus {
+	if x != nil {
+		return x.Status
+	}
+	return MissionStatus_MISSION_STATUS_UNSPECIFIED
+}
+
+func (x *ListMissionsRequest) GetCompleted() bool {
+	if x != nil && x.Completed != nil {
+		return *x.Completed
+	}
+	return false
+}
+
+func (x *ListMissionsRequest) GetCatId() uint32 {
+	if x != nil && x.CatId != nil {
+		return *x.CatId
+	}
+	return 0
+}
+
+func (x *ListMissionsRequest) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *ListMissionsRequest) GetInclude() *MissionInclude {
+	if x != nil {
+		return x.Include
+	}
+	return nil
+}
+
+type ListMissionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*Mission             `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	Page          *PageInfo              `protobuf:"bytes,2,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMissionsResponse) Reset() {
+	*x = ListMissionsResponse{}
+	mi := &file_spycat_v1_mission_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMissionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMissionsResponse) ProtoMessage() {}
+
+func (x *ListMissionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_mission_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMissionsResponse.ProtoReflect.Descriptor instead.
+func (*ListMissionsResponse) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_mission_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListMissionsResponse) GetItems() []*Mission {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ListMissionsResponse) GetPage() *PageInfo {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+type UpdateMissionStatusRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Apply the change only if this is still the current version; 0 skips the check
+	Version       int32         `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Status        MissionStatus `protobuf:"varint,3,opt,name=status,proto3,enum=spycat.v1.MissionStatus" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateMissionStatusRequest) Reset() {
+	*x = UpdateMissionStatusRequest{}
+	mi := &file_spycat_v1_mission_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMissionStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMissionStatusRequest) ProtoMessage() {}
+
+func (x *UpdateMissionStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_mission_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMissionStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateMissionStatusRequest) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_mission_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateMissionStatusRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateMissionStatusRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *UpdateMissionStatusRequest) GetStatus() MissionStatus {
+	if x != nil {
+		return x.Status
+	}
+	return MissionStatus_MISSION_STATUS_UNSPECIFIED
+}
+
+type DeleteMissionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Delete only if this is still the current version; 0 skips the check
+	Version       int32 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMissionRequest) Reset() {
+	*x = DeleteMissionRequest{}
+	mi := &file_spycat_v1_mission_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMissionRequest) ProtoMessage() {}
+
+func (x *DeleteMissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_mission_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMissionRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMissionRequest) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_mission_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeleteMissionRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeleteMissionRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type DeleteMissionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMissionResponse) Reset() {
+	*x = DeleteMissionResponse{}
+	mi := &file_spycat_v1_mission_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMissionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMissionResponse) ProtoMessage() {}
+
+func (x *DeleteMissionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_mission_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMissionResponse.ProtoReflect.Descriptor instead.
+func (*DeleteMissionResponse) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_mission_proto_rawDescGZIP(), []int{10}
+}
+
+type RestoreMissionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreMissionRequest) Reset() {
+	*x = RestoreMissionRequest{}
+	mi := &file_spycat_v1_mission_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreMissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreMissionRequest) ProtoMessage() {}
+
+func (x *RestoreMissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_mission_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreMissionRequest.ProtoReflect.Descriptor instead.
+func (*RestoreMissionRequest) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_mission_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RestoreMissionRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type AssignCatRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	MissionId uint32                 `protobuf:"varint,1,opt,name=mission_id,json=missionId,proto3" json:"mission_id,omitempty"`
+	CatId     uint32                 `protobuf:"varint,2,opt,name=cat_id,json=catId,proto3" json:"cat_id,omitempty"`
+	// Recorded on the assignment of the cat being replaced
+	Reason        string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignCatRequest) Reset() {
+	*x = AssignCatRequest{}
+	mi := &file_spycat_v1_mission_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignCatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignCatRequest) ProtoMessage() {}
+
+func (x *AssignCatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_mission_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignCatRequest.ProtoReflect.Descriptor instead.
+func (*AssignCatRequest) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_mission_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AssignCatRequest) GetMissionId() uint32 {
+	if x != nil {
+		return x.MissionId
+	}
+	return 0
+}
+
+func (x *AssignCatRequest) GetCatId() uint32 {
+	if x != nil {
+		return x.CatId
+	}
+	return 0
+}
+
+func (x *AssignCatRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type AddTargetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MissionId     uint32                 `protobuf:"varint,1,opt,name=mission_id,json=missionId,proto3" json:"mission_id,omitempty"`
+	Target        *CreateTarget          `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddTargetRequest) Reset() {
+	*x = AddTargetRequest{}
+	mi := &file_spycat_v1_mission_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTargetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTargetRequest) ProtoMessage() {}
+
+func (x *AddTargetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_mission_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTargetRequest.ProtoReflect.Descriptor instead.
+func (*AddTargetRequest) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_mission_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *AddTargetRequest) GetMissionId() uint32 {
+	if x != nil {
+		return x.MissionId
+	}
+	return 0
+}
+
+func (x *AddTargetRequest) GetTarget() *CreateTarget {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+type UpdateTargetRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Apply the change only if this is still the current version; 0 skips the check
+	Version       int32  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Notes         string `protobuf:"bytes,3,opt,name=notes,proto3" json:"notes,omitempty"`
+	Completed     bool   `protobuf:"varint,4,opt,name=completed,proto3" json:"completed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTargetRequest) Reset() {
+	*x = UpdateTargetRequest{}
+	mi := &file_spycat_v1_mission_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTargetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTargetRequest) ProtoMessage() {}
+
+func (x *UpdateTargetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_mission_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTargetRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTargetRequest) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_mission_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UpdateTargetRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateTargetRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *UpdateTargetRequest) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *UpdateTargetRequest) GetCompleted() bool {
+	if x != nil {
+		return x.Completed
+	}
+	return false
+}
+
+type DeleteTargetRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Delete only if this is still the current version; 0 skips the check
+	Version       int32 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTargetRequest) Reset() {
+	*x = DeleteTargetRequest{}
+	mi := &file_spycat_v1_mission_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTargetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTargetRequest) ProtoMessage() {}
+
+func (x *DeleteTargetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_mission_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTargetRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTargetRequest) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_mission_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DeleteTargetRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeleteTargetRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type DeleteTargetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTargetResponse) Reset() {
+	*x = DeleteTargetResponse{}
+	mi := &file_spycat_v1_mission_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTargetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTargetResponse) ProtoMessage() {}
+
+func (x *DeleteTargetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_mission_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTargetResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTargetResponse) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_mission_proto_rawDescGZIP(), []int{16}
+}
+
+type RestoreTargetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreTargetRequest) Reset() {
+	*x = RestoreTargetRequest{}
+	mi := &file_spycat_v1_mission_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreTargetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreTargetRequest) ProtoMessage() {}
+
+func (x *RestoreTargetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_mission_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreTargetRequest.ProtoReflect.Descriptor instead.
+func (*RestoreTargetRequest) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_mission_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RestoreTargetRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+var File_spycat_v1_mission_proto protoreflect.FileDescriptor
+
+const file_spycat_v1_mission_proto_rawDesc = "" +
+	"\n" +
+	"\x17spycat/v1/mission.proto\x12\tspycat.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13spycat/v1/cat.proto\"\xa0\x03\n" +
+	"\aMission\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\x06cat_id\x18\x03 \x01(\rH\x00R\x05catId\x88\x01\x01\x120\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x18.spycat.v1.MissionStatusR\x06status\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\x05R\aversion\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x129\n" +
+	"\n" +
+	"deleted_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x12 \n" +
+	"\x03cat\x18\t \x01(\v2\x0e.spycat.v1.CatR\x03cat\x12+\n" +
+	"\atargets\x18\n" +
+	" \x03(\v2\x11.spycat.v1.TargetR\atargetsB\t\n" +
+	"\a_cat_id\"\xe4\x02\n" +
+	"\x06Target\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x1d\n" +
+	"\n" +
+	"mission_id\x18\x02 \x01(\rR\tmissionId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x18\n" +
+	"\acountry\x18\x04 \x01(\tR\acountry\x12\x14\n" +
+	"\x05notes\x18\x05 \x01(\tR\x05notes\x12\x1c\n" +
+	"\tcompleted\x18\x06 \x01(\bR\tcompleted\x12\x18\n" +
+	"\aversion\x18\a \x01(\x05R\aversion\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x129\n" +
+	"\n" +
+	"deleted_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\"<\n" +
+	"\x0eMissionInclude\x12\x10\n" +
+	"\x03cat\x18\x01 \x01(\bR\x03cat\x12\x18\n" +
+	"\atargets\x18\x02 \x01(\bR\atargets\"R\n" +
+	"\fCreateTarget\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\acountry\x18\x02 \x01(\tR\acountry\x12\x14\n" +
+	"\x05notes\x18\x03 \x01(\tR\x05notes\"\x84\x01\n" +
+	"\x14CreateMissionRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\x06cat_id\x18\x02 \x01(\rH\x00R\x05catId\x88\x01\x01\x121\n" +
+	"\atargets\x18\x03 \x03(\v2\x17.spycat.v1.CreateTargetR\atargetsB\t\n" +
+	"\a_cat_id\"X\n" +
+	"\x11GetMissionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x123\n" +
+	"\ainclude\x18\x02 \x01(\v2\x19.spycat.v1.MissionIncludeR\ainclude\"\x99\x02\n" +
+	"\x13ListMissionsRequest\x12)\n" +
+	"\x04page\x18\x01 \x01(\v2\x15.spycat.v1.ListParamsR\x04page\x120\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x18.spycat.v1.MissionStatusR\x06status\x12!\n" +
+	"\tcompleted\x18\x03 \x01(\bH\x00R\tcompleted\x88\x01\x01\x12\x1a\n" +
+	"\x06cat_id\x18\x04 \x01(\rH\x01R\x05catId\x88\x01\x01\x12\x18\n" +
+	"\acountry\x18\x05 \x01(\tR\acountry\x123\n" +
+	"\ainclude\x18\x06 \x01(\v2\x19.spycat.v1.MissionIncludeR\aincludeB\f\n" +
+	"\n" +
+	"_completedB\t\n" +
+	"\a_cat_id\"i\n" +
+	"\x14ListMissionsResponse\x12(\n" +
+	"\x05items\x18\x01 \x03(\v2\x12.spycat.v1.MissionR\x05items\x12'\n" +
+	"\x04page\x18\x02 \x01(\v2\x13.spycat.v1.PageInfoR\x04page\"x\n" +
+	"\x1aUpdateMissionStatusRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\x120\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x18.spycat.v1.MissionStatusR\x06status\"@\n" +
+	"\x14DeleteMissionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\"\x17\n" +
+	"\x15DeleteMissionResponse\"'\n" +
+	"\x15RestoreMissionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"`\n" +
+	"\x10AssignCatRequest\x12\x1d\n" +
+	"\n" +
+	"mission_id\x18\x01 \x01(\rR\tmissionId\x12\x15\n" +
+	"\x06cat_id\x18\x02 \x01(\rR\x05catId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"b\n" +
+	"\x10AddTargetRequest\x12\x1d\n" +
+	"\n" +
+	"mission_id\x18\x01 \x01(\rR\tmissionId\x12/\n" +
+	"\x06target\x18\x02 \x01(\v2\x17.spycat.v1.CreateTargetR\x06target\"s\n" +
+	"\x13UpdateTargetRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\x12\x14\n" +
+	"\x05notes\x18\x03 \x01(\tR\x05notes\x12\x1c\n" +
+	"\tcompleted\x18\x04 \x01(\bR\tcompleted\"?\n" +
+	"\x13DeleteTargetRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\"\x16\n" +
+	"\x14DeleteTargetResponse\"&\n" +
+	"\x14RestoreTargetRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id*\xc0\x01\n" +
+	"\rMissionStatus\x12\x1e\n" +
+	"\x1aMISSION_STATUS_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14MISSION_STATUS_DRAFT\x10\x01\x12\x1b\n" +
+	"\x17MISSION_STATUS_ASSIGNED\x10\x02\x12\x1e\n" +
+	"\x1aMISSION_STATUS_IN_PROGRESS\x10\x03\x12\x1c\n" +
+	"\x18MISSION_STATUS_COMPLETED\x10\x04\x12\x1a\n" +
+	"\x16MISSION_STATUS_ABORTED\x10\x052\xa9\x06\n" +
+	"\x0eMissionService\x12D\n" +
+	"\rCreateMission\x12\x1f.spycat.v1.CreateMissionRequest\x1a\x12.spycat.v1.Mission\x12>\n" +
+	"\n" +
+	"GetMission\x12\x1c.spycat.v1.GetMissionRequest\x1a\x12.spycat.v1.Mission\x12O\n" +
+	"\fListMissions\x12\x1e.spycat.v1.ListMissionsRequest\x1a\x1f.spycat.v1.ListMissionsResponse\x12P\n" +
+	"\x13UpdateMissionStatus\x12%.spycat.v1.UpdateMissionStatusRequest\x1a\x12.spycat.v1.Mission\x12R\n" +
+	"\rDeleteMission\x12\x1f.spycat.v1.DeleteMissionRequest\x1a .spycat.v1.DeleteMissionResponse\x12F\n" +
+	"\x0eRestoreMission\x12 .spycat.v1.RestoreMissionRequest\x1a\x12.spycat.v1.Mission\x12<\n" +
+	"\tAssignCat\x12\x1b.spycat.v1.AssignCatRequest\x1a\x12.spycat.v1.Mission\x12;\n" +
+	"\tAddTarget\x12\x1b.spycat.v1.AddTargetRequest\x1a\x11.spycat.v1.Target\x12A\n" +
+	"\fUpdateTarget\x12\x1e.spycat.v1.UpdateTargetRequest\x1a\x11.spycat.v1.Target\x12O\n" +
+	"\fDeleteTarget\x12\x1e.spycat.v1.DeleteTargetRequest\x1a\x1f.spycat.v1.DeleteTargetResponse\x12C\n" +
+	"\rRestoreTarget\x12\x1f.spycat.v1.RestoreTargetRequest\x1a\x11.spycat.v1.TargetB%Z#SpyCatAgency/api/spycat/v1;spycatv1b\x06proto3"
+
+var (
+	file_spycat_v1_mission_proto_rawDescOnce sync.Once
+	file_spycat_v1_mission_proto_rawDescData []byte
+)
+
+func file_spycat_v1_mission_proto_rawDescGZIP() []byte {
+	file_spycat_v1_mission_proto_rawDescOnce.Do(func() {
+		file_spycat_v1_mission_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_spycat_v1_mission_proto_rawDesc), len(file_spycat_v1_mission_proto_rawDesc)))
+	})
+	return file_spycat_v1_mission_proto_rawDescData
+}
+
+var file_spycat_v1_mission_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_spycat_v1_mission_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_spycat_v1_mission_proto_goTypes = []any{
+	(MissionStatus)(0),                 // 0: spycat.v1.MissionStatus
+	(*Mission)(nil),                    // 1: spycat.v1.Mission
+	(*Target)(nil),                     // 2: spycat.v1.Target
+	(*MissionInclude)(nil),             // 3: spycat.v1.MissionInclude
+	(*CreateTarget)(nil),               // 4: spycat.v1.CreateTarget
+	(*CreateMissionRequest)(nil),       // 5: spycat.v1.CreateMissionRequest
+	(*GetMissionRequest)(nil),          // 6: spycat.v1.GetMissionRequest
+	(*ListMissionsRequest)(nil),        // 7: spycat.v1.ListMissionsRequest
+	(*ListMissionsResponse)(nil),       // 8: spycat.v1.ListMissionsResponse
+	(*UpdateMissionStatusRequest)(nil), // 9: spycat.v1.UpdateMissionStatusRequest
+	(*DeleteMissionRequest)(nil),       // 10: spycat.v1.DeleteMissionRequest
+	(*DeleteMissionResponse)(nil),      // 11: spycat.v1.DeleteMissionResponse
+	(*RestoreMissionRequest)(nil),      // 12: spycat.v1.RestoreMissionRequest
+	(*AssignCatRequest)(nil),           // 13: spycat.v1.AssignCatRequest
+	(*AddTargetRequest)(nil),           // 14: spycat.v1.AddTargetRequest
+	(*UpdateTargetRequest)(nil),        // 15: spycat.v1.UpdateTargetRequest
+	(*DeleteTargetRequest)(nil),        // 16: spycat.v1.DeleteTargetRequest
+	(*DeleteTargetResponse)(nil),       // 17: spycat.v1.DeleteTargetResponse
+	(*RestoreTargetRequest)(nil),       // 18: spycat.v1.RestoreTargetRequest
+	(*timestamppb.Timestamp)(nil),      // 19: google.protobuf.Timestamp
+	(*Cat)(nil),                        // 20: spycat.v1.Cat
+	(*ListParams)(nil),                 // 21: spycat.v1.ListParams
+	(*PageInfo)(nil),                   // 22: spycat.v1.PageInfo
+}
+var file_spycat_v1_mission_proto_depIdxs = []int32{
+	0,  // 0: spycat.v1.Mission.status:type_name -> spycat.v1.MissionStatus
+	19, // 1: spycat.v1.Mission.created_at:type_name -> google.protobuf.Timestamp
+	19, // 2: spycat.v1.Mission.updated_at:type_name -> google.protobuf.Timestamp
+	19, // 3: spycat.v1.Mission.deleted_at:type_name -> google.protobuf.Timestamp
+	20, // 4: spycat.v1.Mission.cat:type_name -> spycat.v1.Cat
+	2,  // 5: spycat.v1.Mission.targets:type_name -> spycat.v1.Target
+	19, // 6: spycat.v1.Target.created_at:type_name -> google.protobuf.Timestamp
+	19, // 7: spycat.v1.Target.updated_at:type_name -> google.protobuf.Timestamp
+	19, // 8: spycat.v1.Target.deleted_at:type_name -> google.protobuf.Timestamp
+	4,  // 9: spycat.v1.CreateMissionRequest.targets:type_name -> spycat.v1.CreateTarget
+	3,  // 10: spycat.v1.GetMissionRequest.include:type_name -> spycat.v1.MissionInclude
+	21, // 11: spycat.v1.ListMissionsRequest.page:type_name -> spycat.v1.ListParams
+	0,  // 12: spycat.v1.ListMissionsRequest.status:type_name -> spycat.v1.MissionStatus
+	3,  // 13: spycat.v1.ListMissionsRequest.include:type_name -> spycat.v1.MissionInclude
+	1,  // 14: spycat.v1.ListMissionsResponse.items:type_name -> spycat.v1.Mission
+	22, // 15: spycat.v1.ListMissionsResponse.page:type_name -> spycat.v1.PageInfo
+	0,  // 16: spycat.v1.UpdateMissionStatusRequest.status:type_name -> spycat.v1.MissionStatus
+	4,  // 17: spycat.v1.AddTargetRequest.target:type_name -> spycat.v1.CreateTarget
+	5,  // 18: spycat.v1.MissionService.CreateMission:input_type -> spycat.v1.CreateMissionRequest
+	6,  // 19: spycat.v1.MissionService.GetMission:input_type -> spycat.v1.GetMissionRequest
+	7,  // 20: spycat.v1.MissionService.ListMissions:input_type -> spycat.v1.ListMissionsRequest
+	9,  // 21: spycat.v1.MissionService.UpdateMissionStatus:input_type -> spycat.v1.UpdateMissionStatusRequest
+	10, // 22: spycat.v1.MissionService.DeleteMission:input_type -> spycat.v1.DeleteMissionRequest
+	12, // 23: spycat.v1.MissionService.RestoreMission:input_type -> spycat.v1.RestoreMissionRequest
+	13, // 24: spycat.v1.MissionService.AssignCat:input_type -> spycat.v1.AssignCatRequest
+	14, // 25: spycat.v1.MissionService.AddTarget:input_type -> spycat.v1.AddTargetRequest
+	15, // 26: spycat.v1.MissionService.UpdateTarget:input_type -> spycat.v1.UpdateTargetRequest
+	16, // 27: spycat.v1.MissionService.DeleteTarget:input_type -> spycat.v1.DeleteTargetRequest
+	18, // 28: spycat.v1.MissionService.RestoreTarget:input_type -> spycat.v1.RestoreTargetRequest
+	1,  // 29: spycat.v1.MissionService.CreateMission:output_type -> spycat.v1.Mission
+	1,  // 30: spycat.v1.MissionService.GetMission:output_type -> spycat.v1.Mission
+	8,  // 31: spycat.v1.MissionService.ListMissions:output_type -> spycat.v1.ListMissionsResponse
+	1,  // 32: spycat.v1.MissionService.UpdateMissionStatus:output_type -> spycat.v1.Mission
+	11, // 33: spycat.v1.MissionService.DeleteMission:output_type -> spycat.v1.DeleteMissionResponse
+	1,  // 34: spycat.v1.MissionService.RestoreMission:output_type -> spycat.v1.Mission
+	1,  // 35: spycat.v1.MissionService.AssignCat:output_type -> spycat.v1.Mission
+	2,  // 36: spycat.v1.MissionService.AddTarget:output_type -> spycat.v1.Target
+	2,  // 37: spycat.v1.MissionService.UpdateTarget:output_type -> spycat.v1.Target
+	17, // 38: spycat.v1.MissionService.DeleteTarget:output_type -> spycat.v1.DeleteTargetResponse
+	2,  // 39: spycat.v1.MissionService.RestoreTarget:output_type -> spycat.v1.Target
+	29, // [29:40] is the sub-list for method output_type
+	18, // [18:29] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
+}
+
+func init() { file_spycat_v1_mission_proto_init() }
+func file_spycat_v1_mission_proto_init() {
+	if File_spycat_v1_mission_proto != nil {
+		return
+	}
+	file_spycat_v1_cat_proto_init()
+	file_spycat_v1_mission_proto_msgTypes[0].OneofWrappers = []any{}
+	file_spycat_v1_mission_proto_msgTypes[4].OneofWrappers = []any{}
+	file_spycat_v1_mission_proto_msgTypes[6].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_spycat_v1_mission_proto_rawDesc), len(file_spycat_v1_mission_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   18,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_spycat_v1_mission_proto_goTypes,
+		DependencyIndexes: file_spycat_v1_mission_proto_depIdxs,
+		EnumInfos:         file_spycat_v1_mission_proto_enumTypes,
+		MessageInfos:      file_spycat_v1_mission_proto_msgTypes,
+	}.Build()
+	File_spycat_v1_mission_proto = out.File
+	file_spycat_v1_mission_proto_goTypes = nil
+	file_spycat_v1_mission_proto_depIdxs = nil
+}
@@ -0,0 +1,507 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: spycat/v1/mission.proto
+
+package spycatv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	MissionService_CreateMission_FullMethodName       = "/spycat.v1.MissionService/CreateMission"
+	MissionService_GetMission_FullMethodName          = "/spycat.v1.MissionService/GetMission"
+	MissionService_ListMissions_FullMethodName        = "/spycat.v1.MissionService/ListMissions"
+	MissionService_UpdateMissionStatus_FullMethodName = "/spycat.v1.MissionService/UpdateMissionStatus"
+	MissionService_DeleteMission_FullMethodName       = "/spycat.v1.MissionService/DeleteMission"
+	MissionService_RestoreMission_FullMethodName      = "/spycat.v1.MissionService/RestoreMission"
+	MissionService_AssignCat_FullMethodName           = "/spycat.v1.MissionService/AssignCat"
+	MissionService_AddTarget_FullMethodName           = "/spycat.v1.MissionService/AddTarget"
+	MissionService_UpdateTarget_FullMethodName        = "/spycat.v1.MissionService/UpdateTarget"
+	MissionService_DeleteTarget_FullMethodName        = "/spycat.v1.MissionService/DeleteTarget"
+	MissionService_RestoreTarget_FullMethodName       = "/spycat.v1.MissionService/RestoreTarget"
+)
+
+// MissionServiceClient is the client API for MissionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// MissionService manages missions and their targets. It offers the operations of the /api/missions REST routes
+// with the same permissions and lifecycle rules.
+type MissionServiceClient interface {
+	CreateMission(ctx context.Context, in *CreateMissionRequest, opts ...grpc.CallOption) (*Mission, error)
+	GetMission(ctx context.Context, in *GetMissionRequest, opts ...grpc.CallOption) (*Mission, error)
+	ListMissions(ctx context.Context, in *ListMissionsRequest, opts ...grpc.CallOption) (*ListMissionsResponse, error)
+	UpdateMissionStatus(ctx context.Context, in *UpdateMissionStatusRequest, opts ...grpc.CallOption) (*Mission, error)
+	DeleteMission(ctx context.Context, in *DeleteMissionRequest, opts ...grpc.CallOption) (*DeleteMissionResponse, error)
+	RestoreMission(ctx context.Context, in *RestoreMissionRequest, opts ...grpc.CallOption) (*Mission, error)
+	AssignCat(ctx context.Context, in *AssignCatRequest, opts ...grpc.CallOption) (*Mission, error)
+	AddTarget(ctx context.Context, in *AddTargetRequest, opts ...grpc.CallOption) (*Target, error)
+	UpdateTarget(ctx context.Context, in *UpdateTargetRequest, opts ...grpc.CallOption) (*Target, error)
+	DeleteTarget(ctx context.Context, in *DeleteTargetRequest, opts ...grpc.CallOption) (*DeleteTargetResponse, error)
+	RestoreTarget(ctx context.Context, in *RestoreTargetRequest, opts ...grpc.CallOption) (*Target, error)
+}
+
+type missionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMissionServiceClient(cc grpc.ClientConnInterface) MissionServiceClient {
+	return &missionServiceClient{cc}
+}
+
+func (c *missionServiceClient) CreateMission(ctx context.Context, in *CreateMissionRequest, opts ...grpc.CallOption) (*Mission, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Mission)
+	err := c.cc.Invoke(ctx, MissionService_CreateMission_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *missionServiceClient) GetMission(ctx context.Context, in *GetMissionRequest, opts ...grpc.CallOption) (*Mission, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Mission)
+	err := c.cc.Invoke(ctx, MissionService_GetMission_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *missionServiceClient) ListMissions(ctx context.Context, in *ListMissionsRequest, opts ...grpc.CallOption) (*ListMissionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMissionsResponse)
+	err := c.cc.Invoke(ctx, MissionService_ListMissions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *missionServiceClient) UpdateMissionStatus(ctx context.Context, in *UpdateMissionStatusRequest, opts ...grpc.CallOption) (*Mission, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Mission)
+	err := c.cc.Invoke(ctx, MissionService_UpdateMissionStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *missionServiceClient) DeleteMission(ctx context.Context, in *DeleteMissionRequest, opts ...grpc.CallOption) (*DeleteMissionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteMissionResponse)
+	err := c.cc.Invoke(ctx, MissionService_DeleteMission_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *missionServiceClient) RestoreMission(ctx context.Context, in *RestoreMissionRequest, opts ...grpc.CallOption) (*Mission, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Mission)
+	err := c.cc.Invoke(ctx, MissionService_RestoreMission_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *missionServiceClient) AssignCat(ctx context.Context, in *AssignCatRequest, opts ...grpc.CallOption) (*Mission, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Mission)
+	err := c.cc.Invoke(ctx, MissionService_AssignCat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *missionServiceClient) AddTarget(ctx context.Context, in *AddTargetRequest, opts ...grpc.CallOption) (*Target, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Target)
+	err := c.cc.Invoke(ctx, MissionService_AddTarget_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *missionServiceClient) UpdateTarget(ctx context.Context, in *UpdateTargetRequest, opts ...grpc.CallOption) (*Target, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Target)
+	err := c.cc.Invoke(ctx, MissionService_UpdateTarget_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *missionServiceClient) DeleteTarget(ctx context.Context, in *DeleteTargetRequest, opts ...grpc.CallOption) (*DeleteTargetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteTargetResponse)
+	err := c.cc.Invoke(ctx, MissionService_DeleteTarget_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *missionServiceClient) RestoreTarget(ctx context.Context, in *RestoreTargetRequest, opts ...grpc.CallOption) (*Target, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Target)
+	err := c.cc.Invoke(ctx, MissionService_RestoreTarget_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MissionServiceServer is the server API for MissionService service.
+// All implementations must embed UnimplementedMissionServiceServer
+// for forward compatibility.
+//
+// MissionService manages missions and their targets. It offers the operations of the /api/missions REST routes
+// with the same permissions and lifecycle rules.
+type MissionServiceServer interface {
+	CreateMission(context.Context, *CreateMissionRequest) (*Mission, error)
+	GetMission(context.Context, *GetMissionRequest) (*Mission, error)
+	ListMissions(context.Context, *ListMissionsRequest) (*ListMissionsResponse, error)
+	UpdateMissionStatus(context.Context, *UpdateMissionStatusRequest) (*Mission, error)
+	DeleteMission(context.Context, *DeleteMissionRequest) (*DeleteMissionResponse, error)
+	RestoreMission(context.Context, *RestoreMissionRequest) (*Mission, error)
+	AssignCat(context.Context, *AssignCatRequest) (*Mission, error)
+	AddTarget(context.Context, *AddTargetRequest) (*Target, error)
+	UpdateTarget(context.Context, *UpdateTargetRequest) (*Target, error)
+	DeleteTarget(context.Context, *DeleteTargetRequest) (*DeleteTargetResponse, error)
+	RestoreTarget(context.Context, *RestoreTargetRequest) (*Target, error)
+	mustEmbedUnimplementedMissionServiceServer()
+}
+
+// UnimplementedMissionServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMissionServiceServer struct{}
+
+func (UnimplementedMissionServiceServer) CreateMission(context.Context, *CreateMissionRequest) (*Mission, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateMission not implemented")
+}
+func (UnimplementedMissionServiceServer) GetMission(context.Context, *GetMissionRequest) (*Mission, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetMission not implemented")
+}
+func (UnimplementedMissionServiceServer) ListMissions(context.Context, *ListMissionsRequest) (*ListMissionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListMissions not implemented")
+}
+func (UnimplementedMissionServiceServer) UpdateMissionStatus(context.Context, *UpdateMissionStatusRequest) (*Mission, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateMissionStatus not implemented")
+}
+func (UnimplementedMissionServiceServer) DeleteMission(context.Context, *DeleteMissionRequest) (*DeleteMissionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteMission not implemented")
+}
+func (UnimplementedMissionServiceServer) RestoreMission(context.Context, *RestoreMissionRequest) (*Mission, error) {
+	return nil, status.Error(codes.Unimplemented, "method RestoreMission not implemented")
+}
+func (UnimplementedMissionServiceServer) AssignCat(context.Context, *AssignCatRequest) (*Mission, error) {
+	return nil, status.Error(codes.Unimplemented, "method AssignCat not implemented")
+}
+func (UnimplementedMissionServiceServer) AddTarget(context.Context, *AddTargetRequest) (*Target, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddTarget not implemented")
+}
+func (UnimplementedMissionServiceServer) UpdateTarget(context.Context, *UpdateTargetRequest) (*Target, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateTarget not implemented")
+}
+func (UnimplementedMissionServiceServer) DeleteTarget(context.Context, *DeleteTargetRequest) (*DeleteTargetResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteTarget not implemented")
+}
+func (UnimplementedMissionServiceServer) RestoreTarget(context.Context, *RestoreTargetRequest) (*Target, error) {
+	return nil, status.Error(codes.Unimplemented, "method RestoreTarget not implemented")
+}
+func (UnimplementedMissionServiceServer) mustEmbedUnimplementedMissionServiceServer() {}
+func (UnimplementedMissionServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeMissionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MissionServiceServer will
+// result in compilation errors.
+type UnsafeMissionServiceServer interface {
+	mustEmbedUnimplementedMissionServiceServer()
+}
+
+func RegisterMissionServiceServer(s grpc.ServiceRegistrar, srv MissionServiceServer) {
+	// If the following call panics, it indicates UnimplementedMissionServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&MissionService_ServiceDesc, srv)
+}
+
+func _MissionService_CreateMission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateMissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MissionServiceServer).CreateMission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MissionService_CreateMission_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MissionServiceServer).CreateMission(ctx, req.(*CreateMissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MissionService_GetMission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MissionServiceServer).GetMission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MissionService_GetMission_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MissionServiceServer).GetMission(ctx, req.(*GetMissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MissionService_ListMissions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMissionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MissionServiceServer).ListMissions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MissionService_ListMissions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MissionServiceServer).ListMissions(ctx, req.(*ListMissionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MissionService_UpdateMissionStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateMissionStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MissionServiceServer).UpdateMissionStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MissionService_UpdateMissionStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MissionServiceServer).UpdateMissionStatus(ctx, req.(*UpdateMissionStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MissionService_DeleteMission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MissionServiceServer).DeleteMission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MissionService_DeleteMission_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MissionServiceServer).DeleteMission(ctx, req.(*DeleteMissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MissionService_RestoreMission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreMissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MissionServiceServer).RestoreMission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MissionService_RestoreMission_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MissionServiceServer).RestoreMission(ctx, req.(*RestoreMissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MissionService_AssignCat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssignCatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MissionServiceServer).AssignCat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MissionService_AssignCat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MissionServiceServer).AssignCat(ctx, req.(*AssignCatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MissionService_AddTarget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddTargetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MissionServiceServer).AddTarget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MissionService_AddTarget_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MissionServiceServer).AddTarget(ctx, req.(*AddTargetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MissionService_UpdateTarget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTargetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MissionServiceServer).UpdateTarget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MissionService_UpdateTarget_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MissionServiceServer).UpdateTarget(ctx, req.(*UpdateTargetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MissionService_DeleteTarget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTargetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MissionServiceServer).DeleteTarget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MissionService_DeleteTarget_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MissionServiceServer).DeleteTarget(ctx, req.(*DeleteTargetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MissionService_RestoreTarget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreTargetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MissionServiceServer).RestoreTarget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MissionService_RestoreTarget_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MissionServiceServer).RestoreTarget(ctx, req.(*RestoreTargetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MissionService_ServiceDesc is the grpc.ServiceDesc for MissionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MissionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "spycat.v1.MissionService",
+	HandlerType: (*MissionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateMission",
+			Handler:    _MissionService_CreateMission_Handler,
+		},
+		{
+			MethodName: "GetMission",
+			Handler:    _MissionService_GetMission_Handler,
+		},
+		{
+			MethodName: "ListMissions",
+			Handler:    _MissionService_ListMissions_Handler,
+		},
+		{
+			MethodName: "UpdateMissionStatus",
+			Handler:    _MissionService_UpdateMissionStatus_Handler,
+		},
+		{
+			MethodName: "DeleteMission",
+			Handler:    _MissionService_DeleteMission_Handler,
+		},
+		{
+			MethodName: "RestoreMission",
+			Handler:    _MissionService_RestoreMission_Handler,
+		},
+		{
+			MethodName: "AssignCat",
+			Handler:    _MissionService_AssignCat_Handler,
+		},
+		{
+			MethodName: "AddTarget",
+			Handler:    _MissionService_AddTarget_Handler,
+		},
+		{
+			MethodName: "UpdateTarget",
+			Handler:    _MissionService_UpdateTarget_Handler,
+		},
+		{
+			MethodName: "DeleteTarget",
+			Handler:    _MissionService_DeleteTarget_Handler,
+		},
+		{
+			MethodName: "RestoreTarget",
+			Handler:    _MissionService_RestoreTarget_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "spycat/v1/mission.proto",
+}
@@ -2,29 +2,49 @@
 // @version         1.0
 // @description     A spy cat management system API.
 
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description Bearer token from POST /api/auth/token, e.g. "Bearer eyJhbGciOi..."
+
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+// @description API key created with POST /api/apikeys
+
 package main
 
 import (
 	_ "SpyCatAgency/cmd/api/docs"
+	"SpyCatAgency/internal/auth"
 	"SpyCatAgency/internal/client"
 	"SpyCatAgency/internal/config"
+	"SpyCatAgency/internal/graph"
+	"SpyCatAgency/internal/grpcserver"
 	"SpyCatAgency/internal/handler"
 	"SpyCatAgency/internal/infrastructure/database"
 	"SpyCatAgency/internal/infrastructure/repository"
 	"SpyCatAgency/internal/logger"
+	"SpyCatAgency/internal/middleware"
+	"SpyCatAgency/internal/ratelimit"
 	"SpyCatAgency/internal/server"
 	"SpyCatAgency/internal/service"
 	"context"
+	"expvar"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
+	"time"
 
+	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
 func main() {
-	ctx := context.Background()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
 	// Load configuration
 	cfg, err := config.New()
@@ -39,20 +59,91 @@ func main() {
 	}
 
 	// Initialize CatAPI client
-	catAPI := client.NewCatAPI(cfg.CatAPIURL, cfg.CatAPIKey)
+	catAPI := client.NewCatAPI(cfg.CatAPIURL, cfg.CatAPIKey, client.HTTPConfig{
+		Timeout:          cfg.CatAPITimeout,
+		AttemptTimeout:   cfg.CatAPIAttemptTimeout,
+		MaxRetries:       cfg.CatAPIMaxRetries,
+		RetryBaseDelay:   cfg.CatAPIRetryBaseDelay,
+		RetryMaxDelay:    cfg.CatAPIRetryMaxDelay,
+		BreakerThreshold: cfg.CatAPIBreakerThreshold,
+		BreakerCooldown:  cfg.CatAPIBreakerCooldown,
+	})
+
+	// Load breed catalog and keep it refreshed in the background
+	breedCatalog := client.NewBreedCatalog(catAPI, cfg.BreedCacheTTL, cfg.BreedCacheFile)
+	breedCatalog.Start(ctx)
+
+	// Initialize token verification: HS256 with the shared secret, RS256 with the JWKS keys
+	tokenConfig := auth.TokenConfig{
+		Secret:   []byte(cfg.JWTSecret),
+		Issuer:   cfg.JWTIssuer,
+		Audience: cfg.JWTAudience,
+		TTL:      cfg.JWTTTL,
+	}
+	if cfg.JWTJWKSFile != "" {
+		if tokenConfig.Keys, err = auth.LoadJWKS(cfg.JWTJWKSFile); err != nil {
+			logger.Fatal(ctx, err)
+		}
+	}
+	tokenManager := auth.NewTokenManager(tokenConfig)
 
 	// Initialize repositories
+	txManager := repository.NewTxManager(db.DB)
 	catRepo := repository.NewCatRepository(db.DB)
 	missionRepo := repository.NewMissionRepository(db.DB)
 	targetRepo := repository.NewTargetRepository(db.DB)
+	targetNoteRepo := repository.NewTargetNoteRepository(db.DB)
+	assignmentRepo := repository.NewAssignmentRepository(db.DB)
+	salaryRepo := repository.NewSalaryRepository(db.DB)
+	payrollRepo := repository.NewPayrollRepository(db.DB)
+	outboxRepo := repository.NewOutboxRepository(db.DB)
+	webhookRepo := repository.NewWebhookRepository(db.DB)
+	auditRepo := repository.NewAuditRepository(db.DB)
+	userRepo := repository.NewUserRepository(db.DB)
+	apiKeyRepo := repository.NewAPIKeyRepository(db.DB)
+	idempotencyRepo := repository.NewIdempotencyRepository(db.DB)
 
 	// Initialize services
-	catService := service.NewCatService(catRepo, catAPI)
-	missionService := service.NewMissionService(missionRepo, targetRepo, catRepo)
+	auditService := service.NewAuditService(auditRepo)
+	eventService := service.NewEventService(outboxRepo)
+	authService := service.NewAuthService(userRepo, catRepo, tokenManager)
+	apiKeyService := service.NewAPIKeyService(txManager, apiKeyRepo, auditService)
+	idempotencyService := service.NewIdempotencyService(idempotencyRepo, cfg.IdempotencyTTL)
+	catService := service.NewCatService(txManager, catRepo, salaryRepo, payrollRepo, auditService, eventService, breedCatalog, service.BreedPolicy(cfg.BreedValidationPolicy))
+	missionService := service.NewMissionService(txManager, missionRepo, targetRepo, targetNoteRepo, assignmentRepo, catRepo, auditService, eventService)
+	payrollService := service.NewPayrollService(txManager, catRepo, salaryRepo, payrollRepo, auditService)
+	webhookService := service.NewWebhookService(txManager, webhookRepo, outboxRepo, auditService, cfg.WebhookPollInterval, cfg.WebhookTimeout, cfg.WebhookMaxAttempts)
+	streamService := service.NewStreamService(outboxRepo, cfg.StreamPollInterval, cfg.StreamBuffer)
+	purgeService := service.NewPurgeService(txManager, catRepo, missionRepo, targetRepo, cfg.PurgeRetention, cfg.PurgeInterval)
+
+	graphExecutor, err := graph.NewExecutor(catService, missionService, graph.Limits{
+		MaxDepth:      cfg.GraphQLMaxDepth,
+		MaxComplexity: cfg.GraphQLMaxComplexity,
+	})
+	if err != nil {
+		logger.Fatal(ctx, err)
+	}
+
+	// Create the initial admin account
+	if cfg.AdminUsername != "" && cfg.AdminPassword != "" {
+		if err := authService.EnsureAdmin(ctx, cfg.AdminUsername, cfg.AdminPassword); err != nil {
+			logger.Fatal(ctx, err)
+		}
+	}
 
 	// Initialize handlers
-	catHandler := handler.NewCatHandler(catService)
-	missionHandler := handler.NewMissionHandler(missionService)
+	handlers := &handler.Handlers{
+		Auth:    handler.NewAuthHandler(authService),
+		Cat:     handler.NewCatHandler(catService),
+		Mission: handler.NewMissionHandler(missionService),
+		Audit:   handler.NewAuditHandler(auditService),
+		User:    handler.NewUserHandler(authService),
+		APIKey:  handler.NewAPIKeyHandler(apiKeyService),
+		Payroll: handler.NewPayrollHandler(payrollService),
+		Webhook: handler.NewWebhookHandler(webhookService),
+		Event:   handler.NewEventHandler(streamService, cfg.StreamHeartbeat),
+		GraphQL: handler.NewGraphQLHandler(graphExecutor),
+	}
 
 	// Initialize server
 	srv := server.NewServer(cfg)
@@ -60,12 +151,62 @@ func main() {
 	// Add Swagger UI endpoint
 	srv.Router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	// Register routes
-	catHandler.RegisterRoutes(srv.Router)
-	missionHandler.RegisterRoutes(srv.Router)
+	// Rate limits apply per client IP before authentication, so credential guessing is limited as well, and then
+	// per caller and route: anonymous callers by IP, authenticated ones by user or API key
+	publicMiddleware := []gin.HandlerFunc{}
+	apiMiddleware := []gin.HandlerFunc{}
+	if cfg.RateLimitEnabled {
+		limiter := ratelimit.NewLimiter()
+		rateLimitByIP := middleware.RateLimitByIP(limiter, ratelimit.Limit{Rate: cfg.RateLimitIPRPS, Burst: cfg.RateLimitIPBurst})
+		rateLimit := middleware.RateLimit(limiter, middleware.RateLimitConfig{
+			Default: ratelimit.Limit{Rate: cfg.RateLimitRPS, Burst: cfg.RateLimitBurst},
+			Routes:  cfg.RouteRateLimits,
+		})
+		publicMiddleware = append(publicMiddleware, rateLimitByIP, rateLimit)
+		apiMiddleware = append(apiMiddleware, rateLimitByIP, middleware.Authenticate(tokenManager, apiKeyService), rateLimit)
+	} else {
+		apiMiddleware = append(apiMiddleware, middleware.Authenticate(tokenManager, apiKeyService))
+	}
+	// Retried POSTs with an Idempotency-Key replay the first response instead of running again
+	apiMiddleware = append(apiMiddleware, middleware.Idempotency(idempotencyService))
+
+	public := srv.Router.Group("/", publicMiddleware...)
+	api := srv.Router.Group("/", apiMiddleware...)
+
+	// Register routes; everything except login requires a valid token or API key
+	handlers.RegisterRoutes(public, api)
+
+	// Runtime metrics, including rejected requests per route; they expose process internals, so admins only
+	api.GET("/debug/vars", middleware.RequirePermission(auth.PermMetricsRead), gin.WrapH(expvar.Handler()))
+
+	// Background workers and servers run until ctx is cancelled; main waits for all of them before exiting
+	var wg sync.WaitGroup
+	background := func(run func(ctx context.Context)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			run(ctx)
+		}()
+	}
+
+	// Permanently remove rows soft-deleted longer than the retention window
+	background(purgeService.Run)
+
+	// Deliver domain events from the outbox to the registered webhooks
+	background(webhookService.Run)
 
-	// Start server
-	go srv.Run(ctx)
+	// Push new mission and target events to live streams
+	background(streamService.Run)
+
+	// Drop idempotency keys older than the replay window
+	background(func(ctx context.Context) { idempotencyService.Run(ctx, time.Hour) })
+
+	// Start server; it shuts down gracefully once ctx is cancelled
+	background(srv.Run)
+
+	// Serve the cat and mission services over gRPC on their own port
+	grpcServer := grpcserver.NewServer(cfg.GRPCPort, tokenManager, apiKeyService, catService, missionService)
+	background(grpcServer.Run)
 
 	// Wait for interrupt signal
 	quit := make(chan os.Signal, 1)
@@ -73,4 +214,10 @@ func main() {
 	<-quit
 
 	logger.Info(ctx, "Shutting down server...")
+	cancel()
+	grpcServer.Shutdown(ctx)
+
+	// In-flight requests, webhook deliveries and worker iterations finish before the process exits
+	wg.Wait()
+	logger.Info(ctx, "Shutdown complete")
 }
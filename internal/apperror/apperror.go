@@ -0,0 +1,108 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Error kinds shared by repositories, services and handlers. Check them with errors.Is.
+var (
+	ErrNotFound   = errors.New("not found")
+	ErrConflict   = errors.New("conflict")
+	ErrValidation = errors.New("validation failed")
+	ErrUpstream   = errors.New("upstream service failure")
+
+	ErrPreconditionFailed = errors.New("precondition failed")
+	ErrUnauthorized       = errors.New("unauthorized")
+	ErrForbidden          = errors.New("forbidden")
+	ErrRateLimited        = errors.New("rate limited")
+)
+
+// Error is a domain error of a known kind. Details are exposed to API clients next to the message.
+type Error struct {
+	kind    error
+	message string
+	cause   error
+	details map[string]any
+}
+
+func (e *Error) Error() string {
+	if e.cause != nil {
+		return fmt.Sprintf("%s: %s", e.message, e.cause)
+	}
+	return e.message
+}
+
+func (e *Error) Unwrap() []error {
+	if e.cause != nil {
+		return []error{e.kind, e.cause}
+	}
+	return []error{e.kind}
+}
+
+// Message returns the error text without the underlying cause
+func (e *Error) Message() string {
+	return e.message
+}
+
+// Details returns additional fields describing the error
+func (e *Error) Details() map[string]any {
+	return e.details
+}
+
+// WithDetail attaches a field that is returned to the client together with the message
+func (e *Error) WithDetail(key string, value any) *Error {
+	if e.details == nil {
+		e.details = make(map[string]any)
+	}
+	e.details[key] = value
+	return e
+}
+
+func newError(kind, cause error, format string, args ...any) *Error {
+	return &Error{
+		kind:    kind,
+		message: fmt.Sprintf(format, args...),
+		cause:   cause,
+	}
+}
+
+// NotFound reports that the requested resource does not exist
+func NotFound(format string, args ...any) *Error {
+	return newError(ErrNotFound, nil, format, args...)
+}
+
+// Conflict reports that the operation is not allowed in the current state of a resource
+func Conflict(format string, args ...any) *Error {
+	return newError(ErrConflict, nil, format, args...)
+}
+
+// Validation reports that the request is well-formed but violates a business rule
+func Validation(format string, args ...any) *Error {
+	return newError(ErrValidation, nil, format, args...)
+}
+
+// PreconditionFailed reports that the resource changed since the version the client based its request on
+func PreconditionFailed(format string, args ...any) *Error {
+	return newError(ErrPreconditionFailed, nil, format, args...)
+}
+
+// Upstream reports that an external service the operation depends on failed
+func Upstream(cause error, format string, args ...any) *Error {
+	return newError(ErrUpstream, cause, format, args...)
+}
+
+// Unauthorized reports that the caller could not be identified
+func Unauthorized(format string, args ...any) *Error {
+	return newError(ErrUnauthorized, nil, format, args...)
+}
+
+// Forbidden reports that the caller is identified but not allowed to perform the operation
+func Forbidden(format string, args ...any) *Error {
+	return newError(ErrForbidden, nil, format, args...)
+}
+
+// RateLimited reports that the caller sent too many requests and has to slow down
+func RateLimited(format string, args ...any) *Error {
+	return newError(ErrRateLimited, nil, format, args...)
+}
@@ -0,0 +1,11 @@
+package auth
+
+import "strings"
+
+// APIKeyPrefix starts every API key, which tells them apart from JWTs
+const APIKeyPrefix = "sca_"
+
+// IsAPIKey reports whether a bearer credential is an API key
+func IsAPIKey(raw string) bool {
+	return strings.HasPrefix(raw, APIKeyPrefix)
+}
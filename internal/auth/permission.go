@@ -0,0 +1,81 @@
+package auth
+
+import "slices"
+
+// Role is the kind of account a principal belongs to
+type Role string
+
+const (
+	// RoleAdmin manages cats, salaries, payroll, webhooks and user accounts and reads the runtime metrics
+	RoleAdmin Role = "admin"
+	// RoleHandler creates and assigns missions
+	RoleHandler Role = "handler"
+	// RoleCat works on the missions assigned to its own cat
+	RoleCat Role = "cat"
+)
+
+// Permission allows a group of operations. The names double as API key scopes.
+type Permission string
+
+const (
+	PermCatsRead      Permission = "cats:read"
+	PermCatsWrite     Permission = "cats:write"
+	PermMissionsRead  Permission = "missions:read"
+	PermMissionsWrite Permission = "missions:write"
+	PermTargetsWrite  Permission = "targets:write"
+	PermAuditRead     Permission = "audit:read"
+	PermUsersWrite    Permission = "users:write"
+	PermAPIKeysWrite  Permission = "apikeys:write"
+	PermDeletedRead   Permission = "deleted:read"
+	PermPayrollRead   Permission = "payroll:read"
+	PermPayrollWrite  Permission = "payroll:write"
+	PermWebhooksWrite Permission = "webhooks:write"
+	PermMetricsRead   Permission = "metrics:read"
+)
+
+// Permissions lists every permission, in the order they are documented
+var Permissions = []Permission{
+	PermCatsRead, PermCatsWrite,
+	PermMissionsRead, PermMissionsWrite, PermTargetsWrite,
+	PermAuditRead, PermUsersWrite, PermAPIKeysWrite, PermDeletedRead,
+	PermPayrollRead, PermPayrollWrite,
+	PermWebhooksWrite, PermMetricsRead,
+}
+
+// rolePermissions lists what each role may do. Cats are further limited to their own missions.
+var rolePermissions = map[Role][]Permission{
+	RoleAdmin: {
+		PermCatsRead, PermCatsWrite,
+		PermMissionsRead, PermMissionsWrite, PermTargetsWrite,
+		PermAuditRead, PermUsersWrite, PermAPIKeysWrite, PermDeletedRead,
+		PermPayrollRead, PermPayrollWrite,
+		PermWebhooksWrite, PermMetricsRead,
+	},
+	RoleHandler: {
+		PermCatsRead,
+		PermMissionsRead, PermMissionsWrite, PermTargetsWrite,
+	},
+	RoleCat: {
+		PermMissionsRead, PermTargetsWrite,
+	},
+}
+
+// Valid reports whether the role is one of the known roles
+func (r Role) Valid() bool {
+	_, ok := rolePermissions[r]
+	return ok
+}
+
+// ValidPermission reports whether the name is a known permission
+func ValidPermission(name string) bool {
+	return slices.Contains(Permissions, Permission(name))
+}
+
+// Can reports whether the principal has the permission. API key principals have
+// exactly the scopes of their key, user principals the permissions of their role.
+func (p *Principal) Can(perm Permission) bool {
+	if p.Scopes != nil {
+		return slices.Contains(p.Scopes, perm)
+	}
+	return slices.Contains(rolePermissions[p.Role], perm)
+}
@@ -0,0 +1,31 @@
+package auth
+
+import "context"
+
+// Principal is the authenticated caller of a request
+type Principal struct {
+	// Subject is the "sub" claim of the token: the user id for tokens issued by this API
+	Subject string
+	// Name is a human-readable identity used in logs and the audit log
+	Name string
+	// Role decides what the principal is allowed to do
+	Role Role
+	// CatID is the cat a principal with RoleCat acts as
+	CatID *uint
+	// Scopes are the permissions of an API key; nil for users
+	Scopes []Permission
+}
+
+// principalKey is a private struct used as the key for the principal in context
+type principalKey struct{}
+
+// WithPrincipal returns a context carrying the authenticated caller
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFrom returns the authenticated caller, or nil if the request was not authenticated
+func PrincipalFrom(ctx context.Context) *Principal {
+	p, _ := ctx.Value(principalKey{}).(*Principal)
+	return p
+}
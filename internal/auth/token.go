@@ -0,0 +1,139 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrSigningDisabled is returned by Issue when no HS256 secret is configured
+var ErrSigningDisabled = errors.New("token signing is not configured")
+
+// TokenConfig configures how tokens are issued and verified
+type TokenConfig struct {
+	// Secret signs and verifies HS256 tokens. Without it only RS256 tokens are accepted.
+	Secret []byte
+	// Keys verify RS256 tokens by their "kid" header, usually loaded with LoadJWKS
+	Keys map[string]*rsa.PublicKey
+	// Issuer and Audience are set on issued tokens and, when not empty, required on verified ones
+	Issuer   string
+	Audience string
+	// TTL is the lifetime of issued tokens
+	TTL time.Duration
+}
+
+// claims are the JWT claims understood by the API
+type claims struct {
+	Name  string `json:"name,omitempty"`
+	Role  Role   `json:"role,omitempty"`
+	CatID *uint  `json:"cat_id,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// TokenManager issues HS256 access tokens and verifies HS256 and RS256 tokens
+type TokenManager struct {
+	cfg    TokenConfig
+	parser *jwt.Parser
+}
+
+func NewTokenManager(cfg TokenConfig) *TokenManager {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg(), jwt.SigningMethodRS256.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(30 * time.Second),
+	}
+	if cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Audience))
+	}
+
+	return &TokenManager{
+		cfg:    cfg,
+		parser: jwt.NewParser(opts...),
+	}
+}
+
+// TTL returns the lifetime of issued tokens
+func (m *TokenManager) TTL() time.Duration {
+	return m.cfg.TTL
+}
+
+// Issue signs an HS256 token for the principal
+func (m *TokenManager) Issue(p *Principal) (string, error) {
+	if len(m.cfg.Secret) == 0 {
+		return "", ErrSigningDisabled
+	}
+
+	now := time.Now()
+	c := claims{
+		Name:  p.Name,
+		Role:  p.Role,
+		CatID: p.CatID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   p.Subject,
+			Issuer:    m.cfg.Issuer,
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(m.cfg.TTL)),
+		},
+	}
+	if m.cfg.Audience != "" {
+		c.Audience = jwt.ClaimStrings{m.cfg.Audience}
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, c).SignedString(m.cfg.Secret)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+	return token, nil
+}
+
+// Verify checks the signature and claims of a token and returns its principal
+func (m *TokenManager) Verify(raw string) (*Principal, error) {
+	var c claims
+	if _, err := m.parser.ParseWithClaims(raw, &c, m.key); err != nil {
+		return nil, err
+	}
+
+	if c.Subject == "" {
+		return nil, errors.New("token has no subject")
+	}
+
+	if c.Role != "" && !c.Role.Valid() {
+		return nil, fmt.Errorf("unknown role %q", c.Role)
+	}
+	if (c.Role == RoleCat) != (c.CatID != nil) {
+		return nil, errors.New("cat_id claim must be set exactly for the cat role")
+	}
+
+	p := &Principal{Subject: c.Subject, Name: c.Name, Role: c.Role, CatID: c.CatID}
+	if p.Name == "" {
+		p.Name = c.Subject
+	}
+	return p, nil
+}
+
+// key selects the verification key for the signing method of the token
+func (m *TokenManager) key(token *jwt.Token) (any, error) {
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		if len(m.cfg.Secret) == 0 {
+			return nil, errors.New("HS256 tokens are not accepted")
+		}
+		return m.cfg.Secret, nil
+	case *jwt.SigningMethodRSA:
+		kid, _ := token.Header["kid"].(string)
+		key, ok := m.cfg.Keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown signing key %q", kid)
+		}
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+	}
+}
@@ -0,0 +1,212 @@
+package bulk
+
+import (
+	"SpyCatAgency/internal/model"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// CatColumns are the CSV columns of cats. Exports add a leading id column, which imports ignore.
+var CatColumns = []string{"name", "years_experience", "breed", "salary"}
+
+// MissionColumns are the CSV columns of missions: one line per target, grouped into missions by mission_ref.
+// Exports use the mission id as mission_ref.
+var MissionColumns = []string{"mission_ref", "name", "cat_id", "target_name", "target_country", "target_notes"}
+
+// table is a CSV file read by column name
+type table struct {
+	reader  *csv.Reader
+	columns map[string]int
+	line    int
+}
+
+func newTable(r io.Reader, required []string) (*table, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("CSV is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV header: %w", err)
+	}
+
+	t := &table{reader: reader, columns: make(map[string]int), line: 1}
+	for i, name := range header {
+		t.columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range required {
+		if _, ok := t.columns[name]; !ok {
+			return nil, fmt.Errorf("CSV header is missing column %q", name)
+		}
+	}
+	return t, nil
+}
+
+// next returns the following record, or io.EOF
+func (t *table) next() ([]string, error) {
+	record, err := t.reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	t.line++
+	return record, nil
+}
+
+func (t *table) get(record []string, column string) string {
+	i, ok := t.columns[column]
+	if !ok || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+// ReadCatsCSV decodes cats. Values that cannot be parsed are reported on their row instead of failing the file.
+func ReadCatsCSV(r io.Reader) ([]model.ImportRow[model.CatCreate], error) {
+	t, err := newTable(r, CatColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []model.ImportRow[model.CatCreate]
+	for {
+		record, err := t.next()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+
+		row := model.ImportRow[model.CatCreate]{Value: model.CatCreate{
+			Name:  t.get(record, "name"),
+			Breed: t.get(record, "breed"),
+		}}
+		if row.Value.YearsExperience, err = strconv.Atoi(t.get(record, "years_experience")); err != nil {
+			row.Errors = append(row.Errors, fmt.Sprintf("line %d: years_experience must be a whole number", t.line))
+		}
+		if row.Value.Salary, err = strconv.ParseFloat(t.get(record, "salary"), 64); err != nil {
+			row.Errors = append(row.Errors, fmt.Sprintf("line %d: salary must be a number", t.line))
+		}
+		rows = append(rows, row)
+	}
+}
+
+// WriteCatsCSV encodes cats with the columns accepted by ReadCatsCSV
+func WriteCatsCSV(w io.Writer, cats []model.Cat) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(append([]string{"id"}, CatColumns...)); err != nil {
+		return err
+	}
+
+	for _, cat := range cats {
+		if err := writer.Write([]string{
+			strconv.FormatUint(uint64(cat.ID), 10),
+			cat.Name,
+			strconv.Itoa(cat.YearsExperience),
+			cat.Breed,
+			strconv.FormatFloat(cat.Salary, 'f', 2, 64),
+		}); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// ReadMissionsCSV decodes missions. Lines with the same mission_ref form one mission; its name and
+// cat_id are taken from its first line.
+func ReadMissionsCSV(r io.Reader) ([]model.ImportRow[model.MissionCreate], error) {
+	t, err := newTable(r, MissionColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []model.ImportRow[model.MissionCreate]
+	byRef := make(map[string]int)
+	for {
+		record, err := t.next()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+
+		ref := t.get(record, "mission_ref")
+		i, seen := byRef[ref]
+		if !seen || ref == "" {
+			row := model.ImportRow[model.MissionCreate]{Value: model.MissionCreate{Name: t.get(record, "name")}}
+			if ref == "" {
+				row.Errors = append(row.Errors, fmt.Sprintf("line %d: mission_ref is required", t.line))
+			}
+			if catID := t.get(record, "cat_id"); catID != "" {
+				id, err := strconv.ParseUint(catID, 10, 32)
+				if err != nil {
+					row.Errors = append(row.Errors, fmt.Sprintf("line %d: cat_id must be a positive whole number", t.line))
+				} else {
+					v := uint(id)
+					row.Value.CatID = &v
+				}
+			}
+			rows = append(rows, row)
+			i = len(rows) - 1
+			byRef[ref] = i
+		}
+
+		target := model.TargetCreate{
+			Name:    t.get(record, "target_name"),
+			Country: t.get(record, "target_country"),
+			Notes:   t.get(record, "target_notes"),
+		}
+		// Missions without targets are exported as a line with empty target columns
+		if target != (model.TargetCreate{}) {
+			rows[i].Value.Targets = append(rows[i].Value.Targets, target)
+		}
+	}
+}
+
+// WriteMissionsCSV encodes missions with their targets in the columns accepted by ReadMissionsCSV
+func WriteMissionsCSV(w io.Writer, missions []model.Mission) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(MissionColumns); err != nil {
+		return err
+	}
+
+	for _, mission := range missions {
+		catID := ""
+		if mission.CatID != nil {
+			catID = strconv.FormatUint(uint64(*mission.CatID), 10)
+		}
+		ref := strconv.FormatUint(uint64(mission.ID), 10)
+
+		// A mission without live targets still gets a line, with the target columns left empty
+		if len(mission.Targets) == 0 {
+			if err := writer.Write([]string{ref, mission.Name, catID, "", "", ""}); err != nil {
+				return err
+			}
+		}
+		for _, target := range mission.Targets {
+			if err := writer.Write([]string{
+				ref,
+				mission.Name,
+				catID,
+				target.Name,
+				target.Country,
+				target.Notes,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
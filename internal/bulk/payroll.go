@@ -0,0 +1,34 @@
+package bulk
+
+import (
+	"SpyCatAgency/internal/model"
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+// PayrollColumns are the CSV columns of a payroll run export, one line per paid cat
+var PayrollColumns = []string{"period", "cat_id", "cat_name", "days_paid", "amount"}
+
+// WritePayrollCSV encodes the lines of a payroll run
+func WritePayrollCSV(w io.Writer, run *model.PayrollRun) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(PayrollColumns); err != nil {
+		return err
+	}
+
+	for _, line := range run.Lines {
+		if err := writer.Write([]string{
+			run.Period,
+			strconv.FormatUint(uint64(line.CatID), 10),
+			line.CatName,
+			strconv.Itoa(line.DaysPaid),
+			strconv.FormatFloat(line.Amount, 'f', 2, 64),
+		}); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
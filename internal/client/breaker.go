@@ -0,0 +1,79 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without calling CatAPI while the circuit breaker is open
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// breakerTransport stops calling a failing upstream. After threshold consecutive failures the
+// circuit opens and requests fail fast for the cooldown; then a single trial request decides
+// whether the circuit closes again.
+type breakerTransport struct {
+	next      http.RoundTripper
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	state    breakerState
+	failures int
+	openedAt time.Time
+}
+
+func (t *breakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.allow() {
+		return nil, ErrCircuitOpen
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	t.record(!retryable(resp, err))
+	return resp, err
+}
+
+func (t *breakerTransport) allow() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	switch t.state {
+	case breakerOpen:
+		if time.Since(t.openedAt) < t.cooldown {
+			return false
+		}
+		t.state = breakerHalfOpen
+		return true
+	case breakerHalfOpen:
+		// Only the trial request may pass until it completes
+		return false
+	default:
+		return true
+	}
+}
+
+func (t *breakerTransport) record(success bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if success {
+		t.state = breakerClosed
+		t.failures = 0
+		return
+	}
+
+	t.failures++
+	if t.state == breakerHalfOpen || t.failures >= t.threshold {
+		t.state = breakerOpen
+		t.openedAt = time.Now()
+	}
+}
@@ -0,0 +1,211 @@
+package client
+
+import (
+	"SpyCatAgency/internal/apperror"
+	"SpyCatAgency/internal/logger"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// retryInterval is how soon a failed background refresh is retried when the TTL is longer
+const retryInterval = time.Minute
+
+// breedSnapshot is a copy of the breed list as it was downloaded at LoadedAt
+type breedSnapshot struct {
+	LoadedAt time.Time  `json:"loaded_at"`
+	Breeds   []CatBreed `json:"breeds"`
+}
+
+// BreedCatalog keeps an in-process copy of the TheCatAPI breed list. The list is loaded once,
+// refreshed in the background every TTL and, if CatAPI is unavailable, the last good snapshot
+// keeps being served. When a cache file is configured the snapshot also survives restarts.
+type BreedCatalog struct {
+	api       *CatAPI
+	ttl       time.Duration
+	cacheFile string
+
+	mu       sync.RWMutex
+	snapshot breedSnapshot
+	names    map[string]struct{}
+
+	refreshMu sync.Mutex
+}
+
+func NewBreedCatalog(api *CatAPI, ttl time.Duration, cacheFile string) *BreedCatalog {
+	return &BreedCatalog{
+		api:       api,
+		ttl:       ttl,
+		cacheFile: cacheFile,
+	}
+}
+
+// Start loads the catalog and keeps refreshing it until ctx is cancelled
+func (c *BreedCatalog) Start(ctx context.Context) {
+	if err := c.loadFromDisk(); err != nil {
+		logger.Error(ctx, fmt.Errorf("failed to load breed cache file: %w", err))
+	}
+
+	if err := c.Refresh(ctx); err != nil {
+		logger.Error(ctx, fmt.Errorf("failed to load breed catalog: %w", err))
+	}
+
+	go c.refreshLoop(ctx)
+}
+
+// Refresh downloads the breed list and replaces the current snapshot. On failure the
+// previous snapshot is kept.
+func (c *BreedCatalog) Refresh(ctx context.Context) error {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	breeds, err := c.api.ListBreeds(ctx)
+	if err != nil {
+		return err
+	}
+
+	snapshot := breedSnapshot{LoadedAt: time.Now(), Breeds: breeds}
+	c.set(snapshot)
+
+	if err := c.saveToDisk(snapshot); err != nil {
+		logger.Error(ctx, fmt.Errorf("failed to save breed cache file: %w", err))
+	}
+
+	logger.Info(ctx, "breed catalog refreshed", slog.Int("breeds", len(breeds)))
+	return nil
+}
+
+// Breeds returns the known breeds from the last good snapshot. The catalog is loaded on
+// demand if no snapshot exists yet.
+func (c *BreedCatalog) Breeds(ctx context.Context) ([]CatBreed, error) {
+	if err := c.ensureLoaded(ctx, true); err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.snapshot.Breeds, nil
+}
+
+// Contains reports whether a breed with exactly this name exists. Unless allowStale is set,
+// a snapshot that background refreshes failed to renew is reloaded first, and an
+// upstream error is returned if that is not possible.
+func (c *BreedCatalog) Contains(ctx context.Context, name string, allowStale bool) (bool, error) {
+	if err := c.ensureLoaded(ctx, allowStale); err != nil {
+		return false, err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	_, ok := c.names[name]
+	return ok, nil
+}
+
+// LoadedAt returns when the current snapshot was downloaded, or zero time if there is none
+func (c *BreedCatalog) LoadedAt() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.snapshot.LoadedAt
+}
+
+// stale reports whether the snapshot is older than background refreshing should allow
+func (c *BreedCatalog) stale() bool {
+	return time.Since(c.LoadedAt()) > c.ttl+retryInterval
+}
+
+func (c *BreedCatalog) ensureLoaded(ctx context.Context, allowStale bool) error {
+	loaded := !c.LoadedAt().IsZero()
+	if loaded && (allowStale || !c.stale()) {
+		return nil
+	}
+
+	if err := c.Refresh(ctx); err != nil {
+		return apperror.Upstream(err, "breed catalog is unavailable")
+	}
+	return nil
+}
+
+func (c *BreedCatalog) refreshLoop(ctx context.Context) {
+	wait := c.ttl
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+
+		wait = c.ttl
+		if err := c.Refresh(ctx); err != nil {
+			logger.Error(ctx, fmt.Errorf("failed to refresh breed catalog: %w", err))
+			wait = min(c.ttl, retryInterval)
+		}
+	}
+}
+
+func (c *BreedCatalog) set(snapshot breedSnapshot) {
+	names := make(map[string]struct{}, len(snapshot.Breeds))
+	for _, b := range snapshot.Breeds {
+		names[b.Name] = struct{}{}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.snapshot = snapshot
+	c.names = names
+}
+
+func (c *BreedCatalog) loadFromDisk() error {
+	if c.cacheFile == "" {
+		return nil
+	}
+
+	raw, err := os.ReadFile(c.cacheFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var snapshot breedSnapshot
+	if err := json.Unmarshal(raw, &snapshot); err != nil {
+		return err
+	}
+
+	c.set(snapshot)
+	return nil
+}
+
+// saveToDisk writes the snapshot atomically so a crash never leaves a truncated cache file
+func (c *BreedCatalog) saveToDisk(snapshot breedSnapshot) error {
+	if c.cacheFile == "" {
+		return nil
+	}
+
+	raw, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(c.cacheFile), filepath.Base(c.cacheFile)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), c.cacheFile)
+}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"SpyCatAgency/internal/apperror"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -18,43 +19,38 @@ type CatAPI struct {
 	httpClient *http.Client
 }
 
-func NewCatAPI(baseURL, apiKey string) *CatAPI {
+func NewCatAPI(baseURL, apiKey string, httpCfg HTTPConfig) *CatAPI {
 	return &CatAPI{
 		baseURL:    baseURL,
 		apiKey:     apiKey,
-		httpClient: &http.Client{},
+		httpClient: newHTTPClient(httpCfg),
 	}
 }
 
-func (c *CatAPI) ValidateBreed(ctx context.Context, breed string) (bool, error) {
+// ListBreeds downloads the full list of breeds known to TheCatAPI
+func (c *CatAPI) ListBreeds(ctx context.Context) ([]CatBreed, error) {
 
 	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/breeds", c.baseURL), nil)
 	if err != nil {
-		return false, fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("x-api-key", c.apiKey)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return false, fmt.Errorf("failed to make request: %w", err)
+		return nil, apperror.Upstream(err, "failed to reach CatAPI")
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return false, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return nil, apperror.Upstream(fmt.Errorf("unexpected status code: %d", resp.StatusCode), "CatAPI request failed")
 	}
 
 	var breeds []CatBreed
 	if err := json.NewDecoder(resp.Body).Decode(&breeds); err != nil {
-		return false, fmt.Errorf("failed to decode response: %w", err)
+		return nil, apperror.Upstream(err, "failed to decode CatAPI response")
 	}
 
-	for _, b := range breeds {
-		if b.Name == breed {
-			return true, nil
-		}
-	}
-
-	return false, nil
+	return breeds, nil
 }
@@ -0,0 +1,53 @@
+package client
+
+import (
+	"net"
+	"net/http"
+	"time"
+)
+
+// HTTPConfig controls timeouts, retries and circuit breaking of outbound requests
+type HTTPConfig struct {
+	// Timeout bounds a whole call including retries
+	Timeout time.Duration
+	// AttemptTimeout bounds connecting and waiting for response headers of a single attempt
+	AttemptTimeout   time.Duration
+	MaxRetries       int
+	RetryBaseDelay   time.Duration
+	RetryMaxDelay    time.Duration
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+}
+
+// newHTTPClient builds a client whose requests pass through the circuit breaker and then the retry loop
+func newHTTPClient(cfg HTTPConfig) *http.Client {
+	base := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   cfg.AttemptTimeout,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSHandshakeTimeout:   cfg.AttemptTimeout,
+		ResponseHeaderTimeout: cfg.AttemptTimeout,
+		MaxIdleConns:          10,
+		IdleConnTimeout:       90 * time.Second,
+	}
+
+	retry := &retryTransport{
+		next:       base,
+		maxRetries: cfg.MaxRetries,
+		baseDelay:  cfg.RetryBaseDelay,
+		maxDelay:   cfg.RetryMaxDelay,
+	}
+
+	breaker := &breakerTransport{
+		next:      retry,
+		threshold: max(cfg.BreakerThreshold, 1),
+		cooldown:  cfg.BreakerCooldown,
+	}
+
+	return &http.Client{
+		Timeout:   cfg.Timeout,
+		Transport: breaker,
+	}
+}
@@ -1,13 +1,18 @@
 package config
 
 import (
+	"SpyCatAgency/internal/ratelimit"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/caarlos0/env/v11"
 )
 
 type Config struct {
 	AppPort   string `env:"APP_PORT" envDefault:":8080"`
+	GRPCPort  string `env:"GRPC_PORT" envDefault:":9090"`
 	DBHost    string `env:"DB_HOST" envDefault:"localhost"`
 	DBPort    string `env:"DB_PORT" envDefault:"5432"`
 	DBUser    string `env:"DB_USER" envDefault:"postgres"`
@@ -15,6 +20,57 @@ type Config struct {
 	DBName    string `env:"DB_NAME" envDefault:"spycat"`
 	CatAPIURL string `env:"CAT_API_URL" envDefault:"https://api.thecatapi.com/v1"`
 	CatAPIKey string `env:"CAT_API_KEY" envDefault:""`
+
+	CatAPITimeout          time.Duration `env:"CAT_API_TIMEOUT" envDefault:"10s"`
+	CatAPIAttemptTimeout   time.Duration `env:"CAT_API_ATTEMPT_TIMEOUT" envDefault:"3s"`
+	CatAPIMaxRetries       int           `env:"CAT_API_MAX_RETRIES" envDefault:"3"`
+	CatAPIRetryBaseDelay   time.Duration `env:"CAT_API_RETRY_BASE_DELAY" envDefault:"200ms"`
+	CatAPIRetryMaxDelay    time.Duration `env:"CAT_API_RETRY_MAX_DELAY" envDefault:"2s"`
+	CatAPIBreakerThreshold int           `env:"CAT_API_BREAKER_THRESHOLD" envDefault:"5"`
+	CatAPIBreakerCooldown  time.Duration `env:"CAT_API_BREAKER_COOLDOWN" envDefault:"30s"`
+
+	BreedCacheTTL         time.Duration `env:"BREED_CACHE_TTL" envDefault:"24h"`
+	BreedCacheFile        string        `env:"BREED_CACHE_FILE" envDefault:""`
+	BreedValidationPolicy string        `env:"BREED_VALIDATION_POLICY" envDefault:"cache"`
+
+	PurgeRetention time.Duration `env:"PURGE_RETENTION" envDefault:"720h"`
+	PurgeInterval  time.Duration `env:"PURGE_INTERVAL" envDefault:"1h"`
+
+	WebhookPollInterval time.Duration `env:"WEBHOOK_POLL_INTERVAL" envDefault:"5s"`
+	WebhookTimeout      time.Duration `env:"WEBHOOK_TIMEOUT" envDefault:"10s"`
+	WebhookMaxAttempts  int           `env:"WEBHOOK_MAX_ATTEMPTS" envDefault:"8"`
+
+	StreamPollInterval time.Duration `env:"STREAM_POLL_INTERVAL" envDefault:"1s"`
+	StreamHeartbeat    time.Duration `env:"STREAM_HEARTBEAT" envDefault:"15s"`
+	StreamBuffer       int           `env:"STREAM_BUFFER" envDefault:"256"`
+
+	GraphQLMaxDepth      int `env:"GRAPHQL_MAX_DEPTH" envDefault:"8"`
+	GraphQLMaxComplexity int `env:"GRAPHQL_MAX_COMPLEXITY" envDefault:"1000"`
+
+	JWTSecret   string        `env:"JWT_SECRET" envDefault:""`
+	JWTJWKSFile string        `env:"JWT_JWKS_FILE" envDefault:""`
+	JWTIssuer   string        `env:"JWT_ISSUER" envDefault:""`
+	JWTAudience string        `env:"JWT_AUDIENCE" envDefault:""`
+	JWTTTL      time.Duration `env:"JWT_TTL" envDefault:"1h"`
+
+	AdminUsername string `env:"ADMIN_USERNAME" envDefault:""`
+	AdminPassword string `env:"ADMIN_PASSWORD" envDefault:""`
+
+	TrustedProxies []string `env:"TRUSTED_PROXIES" envSeparator:","`
+
+	IdempotencyTTL time.Duration `env:"IDEMPOTENCY_TTL" envDefault:"24h"`
+
+	RateLimitEnabled bool    `env:"RATE_LIMIT_ENABLED" envDefault:"true"`
+	RateLimitRPS     float64 `env:"RATE_LIMIT_RPS" envDefault:"10"`
+	RateLimitBurst   int     `env:"RATE_LIMIT_BURST" envDefault:"20"`
+	// RateLimitIPRPS and RateLimitIPBurst limit all requests of a client IP before authentication
+	RateLimitIPRPS   float64 `env:"RATE_LIMIT_IP_RPS" envDefault:"50"`
+	RateLimitIPBurst int     `env:"RATE_LIMIT_IP_BURST" envDefault:"100"`
+	// RateLimitRoutes overrides the limit of single routes as "METHOD /route=rps:burst" pairs
+	RateLimitRoutes map[string]string `env:"RATE_LIMIT_ROUTES" envSeparator:"," envKeyValSeparator:"=" envDefault:"POST /api/cats/create=1:5,POST /api/auth/token=1:5"`
+
+	// RouteRateLimits is RateLimitRoutes parsed by New
+	RouteRateLimits map[string]ratelimit.Limit `env:"-"`
 }
 
 func New() (*Config, error) {
@@ -22,9 +78,83 @@ func New() (*Config, error) {
 	if err := env.Parse(cfg); err != nil {
 		return nil, fmt.Errorf("failed to parse config: %w", err)
 	}
+
+	switch cfg.BreedValidationPolicy {
+	case "reject", "accept", "cache":
+	default:
+		return nil, fmt.Errorf("invalid BREED_VALIDATION_POLICY %q: must be reject, accept or cache", cfg.BreedValidationPolicy)
+	}
+
+	if cfg.PurgeInterval <= 0 {
+		return nil, fmt.Errorf("invalid PURGE_INTERVAL %s: must be positive", cfg.PurgeInterval)
+	}
+
+	if cfg.WebhookPollInterval <= 0 || cfg.WebhookTimeout <= 0 {
+		return nil, fmt.Errorf("WEBHOOK_POLL_INTERVAL and WEBHOOK_TIMEOUT must be positive")
+	}
+	if cfg.WebhookMaxAttempts < 1 {
+		return nil, fmt.Errorf("invalid WEBHOOK_MAX_ATTEMPTS %d: must be at least 1", cfg.WebhookMaxAttempts)
+	}
+
+	if cfg.StreamPollInterval <= 0 || cfg.StreamHeartbeat <= 0 {
+		return nil, fmt.Errorf("STREAM_POLL_INTERVAL and STREAM_HEARTBEAT must be positive")
+	}
+	if cfg.StreamBuffer < 1 {
+		return nil, fmt.Errorf("invalid STREAM_BUFFER %d: must be at least 1", cfg.StreamBuffer)
+	}
+
+	if cfg.GraphQLMaxDepth < 1 || cfg.GraphQLMaxComplexity < 1 {
+		return nil, fmt.Errorf("GRAPHQL_MAX_DEPTH and GRAPHQL_MAX_COMPLEXITY must be at least 1")
+	}
+
+	if cfg.JWTSecret == "" && cfg.JWTJWKSFile == "" {
+		return nil, fmt.Errorf("JWT_SECRET or JWT_JWKS_FILE must be set")
+	}
+	if cfg.JWTSecret != "" && len(cfg.JWTSecret) < 32 {
+		return nil, fmt.Errorf("JWT_SECRET must be at least 32 bytes long")
+	}
+
+	if cfg.IdempotencyTTL <= 0 {
+		return nil, fmt.Errorf("invalid IDEMPOTENCY_TTL %s: must be positive", cfg.IdempotencyTTL)
+	}
+
+	if cfg.RateLimitRPS <= 0 || cfg.RateLimitBurst < 1 {
+		return nil, fmt.Errorf("RATE_LIMIT_RPS must be positive and RATE_LIMIT_BURST at least 1")
+	}
+	if cfg.RateLimitIPRPS <= 0 || cfg.RateLimitIPBurst < 1 {
+		return nil, fmt.Errorf("RATE_LIMIT_IP_RPS must be positive and RATE_LIMIT_IP_BURST at least 1")
+	}
+	cfg.RouteRateLimits = make(map[string]ratelimit.Limit, len(cfg.RateLimitRoutes))
+	for route, value := range cfg.RateLimitRoutes {
+		limit, err := parseLimit(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RATE_LIMIT_ROUTES entry %q: %w", route, err)
+		}
+		cfg.RouteRateLimits[strings.TrimSpace(route)] = limit
+	}
+
 	return cfg, nil
 }
 
+// parseLimit parses a "rps:burst" pair
+func parseLimit(value string) (ratelimit.Limit, error) {
+	rps, burst, ok := strings.Cut(value, ":")
+	if !ok {
+		return ratelimit.Limit{}, fmt.Errorf("expected rps:burst")
+	}
+
+	rate, err := strconv.ParseFloat(strings.TrimSpace(rps), 64)
+	if err != nil || rate <= 0 {
+		return ratelimit.Limit{}, fmt.Errorf("rps must be a positive number")
+	}
+	size, err := strconv.Atoi(strings.TrimSpace(burst))
+	if err != nil || size < 1 {
+		return ratelimit.Limit{}, fmt.Errorf("burst must be at least 1")
+	}
+
+	return ratelimit.Limit{Rate: rate, Burst: size}, nil
+}
+
 func (c *Config) GetDSN() string {
 	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
 		c.DBHost, c.DBPort, c.DBUser, c.DBPass, c.DBName)
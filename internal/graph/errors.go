@@ -0,0 +1,56 @@
+package graph
+
+import (
+	"SpyCatAgency/internal/apperror"
+	"SpyCatAgency/internal/logger"
+	"context"
+	"errors"
+	"maps"
+)
+
+// errorCodes are the extensions.code values of the API error kinds
+var errorCodes = []struct {
+	kind error
+	code string
+}{
+	{apperror.ErrNotFound, "NOT_FOUND"},
+	{apperror.ErrConflict, "CONFLICT"},
+	{apperror.ErrValidation, "BAD_USER_INPUT"},
+	{apperror.ErrPreconditionFailed, "PRECONDITION_FAILED"},
+	{apperror.ErrUnauthorized, "UNAUTHENTICATED"},
+	{apperror.ErrForbidden, "FORBIDDEN"},
+	{apperror.ErrRateLimited, "RATE_LIMITED"},
+	{apperror.ErrUpstream, "UPSTREAM_FAILURE"},
+}
+
+// apiError is an error reported in the errors list of a response, with its code and details as extensions
+type apiError struct {
+	message    string
+	extensions map[string]any
+}
+
+func (e *apiError) Error() string {
+	return e.message
+}
+
+func (e *apiError) Extensions() map[string]any {
+	return e.extensions
+}
+
+// resolverError converts a service error into the error reported to the client. Like the REST error
+// handler, it hides the message of unexpected errors, which are logged instead.
+func resolverError(ctx context.Context, err error) error {
+	var appErr *apperror.Error
+	if errors.As(err, &appErr) {
+		for _, c := range errorCodes {
+			if errors.Is(err, c.kind) {
+				extensions := map[string]any{"code": c.code}
+				maps.Copy(extensions, appErr.Details())
+				return &apiError{message: appErr.Message(), extensions: extensions}
+			}
+		}
+	}
+
+	logger.Error(ctx, err)
+	return &apiError{message: "Internal Server Error", extensions: map[string]any{"code": "INTERNAL_SERVER_ERROR"}}
+}
@@ -0,0 +1,73 @@
+// Package graph serves the GraphQL API over the cat and mission services
+package graph
+
+import (
+	"SpyCatAgency/internal/service"
+	"context"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// Limits bound the cost of a single request. Both are checked before anything is resolved.
+type Limits struct {
+	// MaxDepth is the deepest nesting of fields allowed
+	MaxDepth int
+	// MaxComplexity is the highest estimated number of resolved fields allowed; see checkLimits
+	MaxComplexity int
+}
+
+// Request is a GraphQL request as sent over HTTP
+type Request struct {
+	Query         string         `json:"query" form:"query" binding:"required"`
+	OperationName string         `json:"operationName" form:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// Executor runs GraphQL requests against the schema
+type Executor struct {
+	schema   graphql.Schema
+	missions *service.MissionService
+	limits   Limits
+}
+
+func NewExecutor(cats *service.CatService, missions *service.MissionService, limits Limits) (*Executor, error) {
+	schema, err := newSchema(&resolver{catService: cats, missionService: missions})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Executor{schema: schema, missions: missions, limits: limits}, nil
+}
+
+// Execute parses and validates the request, rejects it if it is too deep or too complex and otherwise runs it.
+// Relations are loaded in batches shared by the whole request.
+func (e *Executor) Execute(ctx context.Context, req Request) *graphql.Result {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{Body: []byte(req.Query), Name: "GraphQL request"}),
+	})
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+
+	validation := graphql.ValidateDocument(&e.schema, doc, nil)
+	if !validation.IsValid {
+		return &graphql.Result{Errors: validation.Errors}
+	}
+
+	if err := e.checkLimits(doc, req); err != nil {
+		formatted := gqlerrors.FormatError(err)
+		formatted.Extensions = map[string]any{"code": "QUERY_TOO_COMPLEX"}
+		return &graphql.Result{Errors: []gqlerrors.FormattedError{formatted}}
+	}
+
+	return graphql.Execute(graphql.ExecuteParams{
+		Schema:        e.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       withLoaders(ctx, newLoaders(e.missions)),
+	})
+}
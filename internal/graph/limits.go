@@ -0,0 +1,149 @@
+package graph
+
+import (
+	"SpyCatAgency/internal/model"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// checkLimits rejects a request whose operation nests fields deeper than MaxDepth or whose complexity is above
+// MaxComplexity. The complexity counts every field once, with the fields below a list counted once per item:
+// paginated fields are taken to return their limit argument and other lists their listSizes estimate.
+func (e *Executor) checkLimits(doc *ast.Document, req Request) error {
+	var operation *ast.OperationDefinition
+	fragments := make(map[string]*ast.FragmentDefinition)
+	for _, definition := range doc.Definitions {
+		switch d := definition.(type) {
+		case *ast.OperationDefinition:
+			if req.OperationName == "" || d.Name != nil && d.Name.Value == req.OperationName {
+				operation = d
+			}
+		case *ast.FragmentDefinition:
+			fragments[d.Name.Value] = d
+		}
+	}
+	if operation == nil {
+		return nil
+	}
+
+	root := e.schema.QueryType()
+	if operation.Operation == ast.OperationTypeMutation {
+		root = e.schema.MutationType()
+	}
+
+	c := &costCounter{schema: &e.schema, fragments: fragments, variables: req.Variables}
+	complexity, depth := c.selectionSet(root, operation.SelectionSet)
+	if depth > e.limits.MaxDepth {
+		return fmt.Errorf("query depth %d exceeds the limit of %d", depth, e.limits.MaxDepth)
+	}
+	if complexity > e.limits.MaxComplexity {
+		return fmt.Errorf("query complexity %d exceeds the limit of %d", complexity, e.limits.MaxComplexity)
+	}
+	return nil
+}
+
+// costCounter measures the complexity and depth of an operation. Fragment cycles are rejected by validation
+// before it runs.
+type costCounter struct {
+	schema    *graphql.Schema
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]any
+}
+
+func (c *costCounter) selectionSet(parent graphql.Type, set *ast.SelectionSet) (complexity, depth int) {
+	if set == nil {
+		return 0, 0
+	}
+
+	for _, selection := range set.Selections {
+		var cost, level int
+		switch s := selection.(type) {
+		case *ast.Field:
+			cost, level = c.field(parent, s)
+		case *ast.InlineFragment:
+			cost, level = c.selectionSet(c.fragmentType(parent, s.TypeCondition), s.SelectionSet)
+		case *ast.FragmentSpread:
+			if fragment := c.fragments[s.Name.Value]; fragment != nil {
+				cost, level = c.selectionSet(c.fragmentType(parent, fragment.TypeCondition), fragment.SelectionSet)
+			}
+		}
+		complexity += cost
+		depth = max(depth, level)
+	}
+	return complexity, depth
+}
+
+func (c *costCounter) field(parent graphql.Type, field *ast.Field) (complexity, depth int) {
+	name := field.Name.Value
+	object, ok := parent.(*graphql.Object)
+	if !ok || strings.HasPrefix(name, "__") {
+		return 0, 0
+	}
+	definition := object.Fields()[name]
+	if definition == nil {
+		return 0, 0
+	}
+
+	children, depth := c.selectionSet(namedType(definition.Type), field.SelectionSet)
+	return 1 + c.listSize(object, definition, field)*children, depth + 1
+}
+
+// listSize is the number of times the selections of a field are expected to be resolved
+func (c *costCounter) listSize(parent *graphql.Object, definition *graphql.FieldDefinition, field *ast.Field) int {
+	for _, arg := range definition.Args {
+		if arg.Name() == "limit" {
+			return max(c.intArgument(field, "limit", model.DefaultPageLimit), 1)
+		}
+	}
+	if size, ok := listSizes[parent.Name()+"."+definition.Name]; ok {
+		return size
+	}
+	return 1
+}
+
+func (c *costCounter) intArgument(field *ast.Field, name string, fallback int) int {
+	for _, arg := range field.Arguments {
+		if arg.Name.Value != name {
+			continue
+		}
+		switch v := arg.Value.(type) {
+		case *ast.IntValue:
+			if n, err := strconv.Atoi(v.Value); err == nil {
+				return n
+			}
+		case *ast.Variable:
+			switch n := c.variables[v.Name.Value].(type) {
+			case float64:
+				return int(n)
+			case int:
+				return n
+			}
+		}
+	}
+	return fallback
+}
+
+func (c *costCounter) fragmentType(parent graphql.Type, condition *ast.Named) graphql.Type {
+	if condition == nil {
+		return parent
+	}
+	return c.schema.Type(condition.Name.Value)
+}
+
+// namedType strips the list and non-null wrappers of a type
+func namedType(typ graphql.Type) graphql.Type {
+	for {
+		switch t := typ.(type) {
+		case *graphql.List:
+			typ = t.OfType
+		case *graphql.NonNull:
+			typ = t.OfType
+		default:
+			return typ
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package graph
+
+import (
+	"SpyCatAgency/internal/model"
+	"SpyCatAgency/internal/service"
+	"context"
+	"sync"
+)
+
+// loader batches the lookups of one relation. Resolvers register their key with load and get a thunk;
+// graphql-go runs the thunks of a level only after every field of that level was resolved, so the first
+// thunk fetches the keys of all of its siblings in one call.
+type loader[K comparable, V any] struct {
+	fetch func(ctx context.Context, keys []K) (map[K]V, error)
+
+	mu      sync.Mutex
+	pending []K
+	results map[K]V
+	errs    map[K]error
+}
+
+func newLoader[K comparable, V any](fetch func(ctx context.Context, keys []K) (map[K]V, error)) *loader[K, V] {
+	return &loader[K, V]{fetch: fetch, results: make(map[K]V), errs: make(map[K]error)}
+}
+
+// load returns a thunk resolving to the value of the key, or the zero value if there is none
+func (l *loader[K, V]) load(ctx context.Context, key K) func() (any, error) {
+	l.mu.Lock()
+	if _, ok := l.results[key]; !ok && l.errs[key] == nil {
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (any, error) {
+		value, err := l.get(ctx, key)
+		if err != nil {
+			// graphql-go drops the extensions of errors returned by thunks but keeps those of panics
+			panic(resolverError(ctx, err))
+		}
+		return value, nil
+	}
+}
+
+func (l *loader[K, V]) get(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if value, ok := l.results[key]; ok {
+		return value, nil
+	}
+	if err := l.errs[key]; err != nil {
+		var zero V
+		return zero, err
+	}
+
+	keys := make([]K, 0, len(l.pending))
+	for _, k := range l.pending {
+		if _, ok := l.results[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	l.pending = nil
+
+	fetched, err := l.fetch(ctx, keys)
+	if err != nil {
+		// every field waiting on the batch fails with it
+		for _, k := range keys {
+			l.errs[k] = err
+		}
+		var zero V
+		return zero, err
+	}
+	for _, k := range keys {
+		l.results[k] = fetched[k]
+	}
+	return l.results[key], nil
+}
+
+// loaders are the batch loaders of one request
+type loaders struct {
+	cats             *loader[uint, *model.Cat]
+	missionsByCat    *loader[uint, []model.Mission]
+	targetsByMission *loader[uint, []model.Target]
+}
+
+func newLoaders(missions *service.MissionService) *loaders {
+	return &loaders{
+		cats:             newLoader(missions.CatsByIDs),
+		missionsByCat:    newLoader(missions.MissionsByCatIDs),
+		targetsByMission: newLoader(missions.TargetsByMissionIDs),
+	}
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
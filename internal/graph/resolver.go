@@ -0,0 +1,344 @@
+package graph
+
+import (
+	"SpyCatAgency/internal/apperror"
+	"SpyCatAgency/internal/model"
+	"SpyCatAgency/internal/service"
+	"context"
+	"strconv"
+
+	"github.com/graphql-go/graphql"
+)
+
+// resolver resolves the fields of the schema through the services, which authorize every operation
+type resolver struct {
+	catService     *service.CatService
+	missionService *service.MissionService
+}
+
+func (r *resolver) cat(p graphql.ResolveParams) (any, error) {
+	id, err := parseID(p.Args["id"])
+	if err != nil {
+		return nil, resolverError(p.Context, err)
+	}
+	cat, err := r.catService.GetByID(p.Context, id)
+	return result(p.Context, cat, err)
+}
+
+func (r *resolver) cats(p graphql.ResolveParams) (any, error) {
+	filter := model.CatFilter{
+		ListParams:    listParams(p.Args),
+		Breed:         stringArg(p.Args, "breed"),
+		MinExperience: optionalArg[int](p.Args, "minExperience"),
+		MaxExperience: optionalArg[int](p.Args, "maxExperience"),
+		MinSalary:     optionalArg[float64](p.Args, "minSalary"),
+		MaxSalary:     optionalArg[float64](p.Args, "maxSalary"),
+	}
+	if err := service.ValidateInput(filter); err != nil {
+		return nil, resolverError(p.Context, err)
+	}
+
+	page, err := r.catService.List(p.Context, filter)
+	return result(p.Context, page, err)
+}
+
+func (r *resolver) mission(p graphql.ResolveParams) (any, error) {
+	id, err := parseID(p.Args["id"])
+	if err != nil {
+		return nil, resolverError(p.Context, err)
+	}
+	mission, err := r.missionService.GetByID(p.Context, id, &model.MissionInclude{})
+	return result(p.Context, mission, err)
+}
+
+func (r *resolver) missions(p graphql.ResolveParams) (any, error) {
+	catID, err := optionalIDArg(p.Args, "catId")
+	if err != nil {
+		return nil, resolverError(p.Context, err)
+	}
+
+	filter := model.MissionFilter{
+		ListParams: listParams(p.Args),
+		Status:     model.MissionStatus(stringArg(p.Args, "status")),
+		Completed:  optionalArg[bool](p.Args, "completed"),
+		CatID:      catID,
+		Country:    stringArg(p.Args, "country"),
+	}
+	if err := service.ValidateInput(filter); err != nil {
+		return nil, resolverError(p.Context, err)
+	}
+
+	page, err := r.missionService.List(p.Context, filter, &model.MissionInclude{})
+	return result(p.Context, page, err)
+}
+
+// catMissions loads the missions of all cats of a level in one batch
+func (r *resolver) catMissions(p graphql.ResolveParams) (any, error) {
+	cat := sourceOf[model.Cat](p)
+	return loadersFrom(p.Context).missionsByCat.load(p.Context, cat.ID), nil
+}
+
+// missionCat returns the cat loaded with the mission, or loads the cats of all missions of a level in one batch
+func (r *resolver) missionCat(p graphql.ResolveParams) (any, error) {
+	mission := sourceOf[model.Mission](p)
+	if mission.Cat != nil {
+		return mission.Cat, nil
+	}
+	if mission.CatID == nil {
+		return nil, nil
+	}
+	return loadersFrom(p.Context).cats.load(p.Context, *mission.CatID), nil
+}
+
+// missionTargets returns the targets loaded with the mission, or loads those of all missions of a level in one batch
+func (r *resolver) missionTargets(p graphql.ResolveParams) (any, error) {
+	mission := sourceOf[model.Mission](p)
+	if mission.Targets != nil {
+		return mission.Targets, nil
+	}
+	return loadersFrom(p.Context).targetsByMission.load(p.Context, mission.ID), nil
+}
+
+func (r *resolver) createCat(p graphql.ResolveParams) (any, error) {
+	input := p.Args["input"].(map[string]any)
+	create := model.CatCreate{
+		Name:            stringArg(input, "name"),
+		YearsExperience: input["yearsExperience"].(int),
+		Breed:           stringArg(input, "breed"),
+		Salary:          input["salary"].(float64),
+	}
+	if err := service.ValidateInput(create); err != nil {
+		return nil, resolverError(p.Context, err)
+	}
+
+	cat, err := r.catService.Create(p.Context, create)
+	return result(p.Context, cat, err)
+}
+
+func (r *resolver) updateCatSalary(p graphql.ResolveParams) (any, error) {
+	id, err := parseID(p.Args["id"])
+	if err != nil {
+		return nil, resolverError(p.Context, err)
+	}
+
+	input := p.Args["input"].(map[string]any)
+	update := model.CatUpdate{
+		Salary:        input["salary"].(float64),
+		EffectiveFrom: stringArg(input, "effectiveFrom"),
+		Reason:        stringArg(input, "reason"),
+	}
+	if err := service.ValidateInput(update); err != nil {
+		return nil, resolverError(p.Context, err)
+	}
+
+	cat, err := r.catService.Update(p.Context, id, versionArg(p.Args), update)
+	return result(p.Context, cat, err)
+}
+
+func (r *resolver) deleteCat(p graphql.ResolveParams) (any, error) {
+	id, err := parseID(p.Args["id"])
+	if err != nil {
+		return nil, resolverError(p.Context, err)
+	}
+	err = r.catService.Delete(p.Context, id, versionArg(p.Args))
+	return result(p.Context, true, err)
+}
+
+func (r *resolver) restoreCat(p graphql.ResolveParams) (any, error) {
+	id, err := parseID(p.Args["id"])
+	if err != nil {
+		return nil, resolverError(p.Context, err)
+	}
+	cat, err := r.catService.Restore(p.Context, id)
+	return result(p.Context, cat, err)
+}
+
+func (r *resolver) createMission(p graphql.ResolveParams) (any, error) {
+	input := p.Args["input"].(map[string]any)
+	catID, err := optionalIDArg(input, "catId")
+	if err != nil {
+		return nil, resolverError(p.Context, err)
+	}
+
+	create := model.MissionCreate{Name: stringArg(input, "name"), CatID: catID}
+	for _, target := range input["targets"].([]any) {
+		create.Targets = append(create.Targets, targetCreate(target.(map[string]any)))
+	}
+	if err := service.ValidateInput(create); err != nil {
+		return nil, resolverError(p.Context, err)
+	}
+
+	mission, err := r.missionService.Create(p.Context, create)
+	return result(p.Context, mission, err)
+}
+
+func (r *resolver) updateMissionStatus(p graphql.ResolveParams) (any, error) {
+	id, err := parseID(p.Args["id"])
+	if err != nil {
+		return nil, resolverError(p.Context, err)
+	}
+
+	update := model.MissionUpdate{Status: model.MissionStatus(stringArg(p.Args, "status"))}
+	mission, err := r.missionService.Update(p.Context, id, versionArg(p.Args), update)
+	return result(p.Context, mission, err)
+}
+
+func (r *resolver) deleteMission(p graphql.ResolveParams) (any, error) {
+	id, err := parseID(p.Args["id"])
+	if err != nil {
+		return nil, resolverError(p.Context, err)
+	}
+	err = r.missionService.Delete(p.Context, id, versionArg(p.Args))
+	return result(p.Context, true, err)
+}
+
+func (r *resolver) restoreMission(p graphql.ResolveParams) (any, error) {
+	id, err := parseID(p.Args["id"])
+	if err != nil {
+		return nil, resolverError(p.Context, err)
+	}
+	mission, err := r.missionService.Restore(p.Context, id)
+	return result(p.Context, mission, err)
+}
+
+func (r *resolver) assignCat(p graphql.ResolveParams) (any, error) {
+	missionID, err := parseID(p.Args["missionId"])
+	if err != nil {
+		return nil, resolverError(p.Context, err)
+	}
+	catID, err := parseID(p.Args["catId"])
+	if err != nil {
+		return nil, resolverError(p.Context, err)
+	}
+
+	assign := model.CatAssign{CatID: catID, Reason: stringArg(p.Args, "reason")}
+	if err := service.ValidateInput(assign); err != nil {
+		return nil, resolverError(p.Context, err)
+	}
+	if err := r.missionService.AssignCat(p.Context, missionID, assign); err != nil {
+		return nil, resolverError(p.Context, err)
+	}
+
+	mission, err := r.missionService.GetByID(p.Context, missionID, &model.MissionInclude{})
+	return result(p.Context, mission, err)
+}
+
+func (r *resolver) addTarget(p graphql.ResolveParams) (any, error) {
+	missionID, err := parseID(p.Args["missionId"])
+	if err != nil {
+		return nil, resolverError(p.Context, err)
+	}
+
+	create := targetCreate(p.Args["input"].(map[string]any))
+	if err := service.ValidateInput(create); err != nil {
+		return nil, resolverError(p.Context, err)
+	}
+
+	target, err := r.missionService.AddTarget(p.Context, missionID, create)
+	return result(p.Context, target, err)
+}
+
+func (r *resolver) updateTarget(p graphql.ResolveParams) (any, error) {
+	id, err := parseID(p.Args["id"])
+	if err != nil {
+		return nil, resolverError(p.Context, err)
+	}
+
+	input := p.Args["input"].(map[string]any)
+	update := model.TargetUpdate{Notes: stringArg(input, "notes")}
+	if completed, ok := input["completed"].(bool); ok {
+		update.Completed = completed
+	}
+
+	target, err := r.missionService.UpdateTarget(p.Context, id, versionArg(p.Args), update)
+	return result(p.Context, target, err)
+}
+
+func (r *resolver) deleteTarget(p graphql.ResolveParams) (any, error) {
+	id, err := parseID(p.Args["id"])
+	if err != nil {
+		return nil, resolverError(p.Context, err)
+	}
+	err = r.missionService.DeleteTarget(p.Context, id, versionArg(p.Args))
+	return result(p.Context, true, err)
+}
+
+func (r *resolver) restoreTarget(p graphql.ResolveParams) (any, error) {
+	id, err := parseID(p.Args["id"])
+	if err != nil {
+		return nil, resolverError(p.Context, err)
+	}
+	target, err := r.missionService.RestoreTarget(p.Context, id)
+	return result(p.Context, target, err)
+}
+
+// result returns the value of a service call or its error as reported to the client
+func result(ctx context.Context, value any, err error) (any, error) {
+	if err != nil {
+		return nil, resolverError(ctx, err)
+	}
+	return value, nil
+}
+
+func targetCreate(input map[string]any) model.TargetCreate {
+	return model.TargetCreate{
+		Name:    stringArg(input, "name"),
+		Country: stringArg(input, "country"),
+		Notes:   stringArg(input, "notes"),
+	}
+}
+
+// parseID reads an ID argument, which graphql-go passes as a string
+func parseID(value any) (uint, error) {
+	s, _ := value.(string)
+	id, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return 0, apperror.Validation("invalid id %q", s)
+	}
+	return uint(id), nil
+}
+
+func optionalIDArg(args map[string]any, name string) (*uint, error) {
+	if args[name] == nil {
+		return nil, nil
+	}
+	id, err := parseID(args[name])
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+func optionalID(id *uint) any {
+	if id == nil {
+		return nil
+	}
+	return *id
+}
+
+func stringArg(args map[string]any, name string) string {
+	s, _ := args[name].(string)
+	return s
+}
+
+func optionalArg[T any](args map[string]any, name string) *T {
+	if v, ok := args[name].(T); ok {
+		return &v
+	}
+	return nil
+}
+
+// versionArg is the expected version of a change; 0 when the argument is omitted skips the check
+func versionArg(args map[string]any) int {
+	version, _ := args["version"].(int)
+	return version
+}
+
+func listParams(args map[string]any) model.ListParams {
+	limit, _ := args["limit"].(int)
+	return model.ListParams{
+		Limit:  limit,
+		Cursor: stringArg(args, "cursor"),
+		Sort:   stringArg(args, "sort"),
+	}
+}
@@ -0,0 +1,351 @@
+package graph
+
+import (
+	"SpyCatAgency/internal/model"
+
+	"github.com/graphql-go/graphql"
+)
+
+// listSizes estimates the length of list fields that are not paginated, for the complexity limit
+var listSizes = map[string]int{
+	"Cat.missions":    5,
+	"Mission.targets": 3,
+}
+
+var missionStatusEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name: "MissionStatus",
+	Values: graphql.EnumValueConfigMap{
+		"DRAFT":       {Value: string(model.MissionStatusDraft)},
+		"ASSIGNED":    {Value: string(model.MissionStatusAssigned)},
+		"IN_PROGRESS": {Value: string(model.MissionStatusInProgress)},
+		"COMPLETED":   {Value: string(model.MissionStatusCompleted)},
+		"ABORTED":     {Value: string(model.MissionStatusAborted)},
+	},
+})
+
+// prop is a field read from the source object, which is a T or a *T
+func prop[T any](typ graphql.Output, get func(*T) any) *graphql.Field {
+	return &graphql.Field{
+		Type: typ,
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			return get(sourceOf[T](p)), nil
+		},
+	}
+}
+
+func sourceOf[T any](p graphql.ResolveParams) *T {
+	switch v := p.Source.(type) {
+	case *T:
+		return v
+	case T:
+		return &v
+	}
+	return nil
+}
+
+func newSchema(r *resolver) (graphql.Schema, error) {
+	var catType, missionType *graphql.Object
+
+	targetType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Target",
+		Fields: graphql.Fields{
+			"id":        prop(graphql.NewNonNull(graphql.ID), func(t *model.Target) any { return t.ID }),
+			"missionId": prop(graphql.NewNonNull(graphql.ID), func(t *model.Target) any { return t.MissionID }),
+			"name":      prop(graphql.NewNonNull(graphql.String), func(t *model.Target) any { return t.Name }),
+			"country":   prop(graphql.NewNonNull(graphql.String), func(t *model.Target) any { return t.Country }),
+			"notes":     prop(graphql.NewNonNull(graphql.String), func(t *model.Target) any { return t.Notes }),
+			"completed": prop(graphql.NewNonNull(graphql.Boolean), func(t *model.Target) any { return t.Completed }),
+			"version":   prop(graphql.NewNonNull(graphql.Int), func(t *model.Target) any { return t.Version }),
+			"createdAt": prop(graphql.NewNonNull(graphql.DateTime), func(t *model.Target) any { return t.CreatedAt }),
+			"updatedAt": prop(graphql.NewNonNull(graphql.DateTime), func(t *model.Target) any { return t.UpdatedAt }),
+			"deletedAt": prop(graphql.DateTime, func(t *model.Target) any { return t.DeletedAt }),
+		},
+	})
+
+	catType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Cat",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":              prop(graphql.NewNonNull(graphql.ID), func(c *model.Cat) any { return c.ID }),
+				"name":            prop(graphql.NewNonNull(graphql.String), func(c *model.Cat) any { return c.Name }),
+				"yearsExperience": prop(graphql.NewNonNull(graphql.Int), func(c *model.Cat) any { return c.YearsExperience }),
+				"breed":           prop(graphql.NewNonNull(graphql.String), func(c *model.Cat) any { return c.Breed }),
+				"breedVerified":   prop(graphql.NewNonNull(graphql.Boolean), func(c *model.Cat) any { return c.BreedVerified }),
+				"salary":          prop(graphql.NewNonNull(graphql.Float), func(c *model.Cat) any { return c.Salary }),
+				"version":         prop(graphql.NewNonNull(graphql.Int), func(c *model.Cat) any { return c.Version }),
+				"createdAt":       prop(graphql.NewNonNull(graphql.DateTime), func(c *model.Cat) any { return c.CreatedAt }),
+				"updatedAt":       prop(graphql.NewNonNull(graphql.DateTime), func(c *model.Cat) any { return c.UpdatedAt }),
+				"deletedAt":       prop(graphql.DateTime, func(c *model.Cat) any { return c.DeletedAt }),
+				"missions": {
+					Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(missionType))),
+					Description: "Missions the cat is assigned to, including finished ones",
+					Resolve:     r.catMissions,
+				},
+			}
+		}),
+	})
+
+	missionType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mission",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":        prop(graphql.NewNonNull(graphql.ID), func(m *model.Mission) any { return m.ID }),
+				"name":      prop(graphql.NewNonNull(graphql.String), func(m *model.Mission) any { return m.Name }),
+				"status":    prop(graphql.NewNonNull(missionStatusEnum), func(m *model.Mission) any { return string(m.Status) }),
+				"catId":     prop(graphql.ID, func(m *model.Mission) any { return optionalID(m.CatID) }),
+				"version":   prop(graphql.NewNonNull(graphql.Int), func(m *model.Mission) any { return m.Version }),
+				"createdAt": prop(graphql.NewNonNull(graphql.DateTime), func(m *model.Mission) any { return m.CreatedAt }),
+				"updatedAt": prop(graphql.NewNonNull(graphql.DateTime), func(m *model.Mission) any { return m.UpdatedAt }),
+				"deletedAt": prop(graphql.DateTime, func(m *model.Mission) any { return m.DeletedAt }),
+				"cat": {
+					Type:    catType,
+					Resolve: r.missionCat,
+				},
+				"targets": {
+					Type:    graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(targetType))),
+					Resolve: r.missionTargets,
+				},
+			}
+		}),
+	})
+
+	catPageType := pageType("CatPage", catType)
+	missionPageType := pageType("MissionPage", missionType)
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"cat": {
+				Type:    catType,
+				Args:    graphql.FieldConfigArgument{"id": idArgument()},
+				Resolve: r.cat,
+			},
+			"cats": {
+				Type: graphql.NewNonNull(catPageType),
+				Args: withListArgs(graphql.FieldConfigArgument{
+					"breed":         {Type: graphql.String},
+					"minExperience": {Type: graphql.Int},
+					"maxExperience": {Type: graphql.Int},
+					"minSalary":     {Type: graphql.Float},
+					"maxSalary":     {Type: graphql.Float},
+				}),
+				Resolve: r.cats,
+			},
+			"mission": {
+				Type:    missionType,
+				Args:    graphql.FieldConfigArgument{"id": idArgument()},
+				Resolve: r.mission,
+			},
+			"missions": {
+				Type: graphql.NewNonNull(missionPageType),
+				Args: withListArgs(graphql.FieldConfigArgument{
+					"status":    {Type: missionStatusEnum},
+					"completed": {Type: graphql.Boolean},
+					"catId":     {Type: graphql.ID},
+					"country":   {Type: graphql.String},
+				}),
+				Resolve: r.missions,
+			},
+		},
+	})
+
+	targetCreateInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "TargetCreateInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"name":    {Type: graphql.NewNonNull(graphql.String)},
+			"country": {Type: graphql.NewNonNull(graphql.String)},
+			"notes":   {Type: graphql.String},
+		},
+	})
+
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createCat": {
+				Type: graphql.NewNonNull(catType),
+				Args: graphql.FieldConfigArgument{
+					"input": {Type: graphql.NewNonNull(graphql.NewInputObject(graphql.InputObjectConfig{
+						Name: "CatCreateInput",
+						Fields: graphql.InputObjectConfigFieldMap{
+							"name":            {Type: graphql.NewNonNull(graphql.String)},
+							"yearsExperience": {Type: graphql.NewNonNull(graphql.Int)},
+							"breed":           {Type: graphql.NewNonNull(graphql.String)},
+							"salary":          {Type: graphql.NewNonNull(graphql.Float)},
+						},
+					}))},
+				},
+				Resolve: r.createCat,
+			},
+			"updateCatSalary": {
+				Type: graphql.NewNonNull(catType),
+				Args: graphql.FieldConfigArgument{
+					"id":      idArgument(),
+					"version": versionArgument(),
+					"input": {Type: graphql.NewNonNull(graphql.NewInputObject(graphql.InputObjectConfig{
+						Name: "CatSalaryInput",
+						Fields: graphql.InputObjectConfigFieldMap{
+							"salary":        {Type: graphql.NewNonNull(graphql.Float)},
+							"effectiveFrom": {Type: graphql.String, Description: "First day of the new salary as YYYY-MM-DD, today by default"},
+							"reason":        {Type: graphql.String},
+						},
+					}))},
+				},
+				Resolve: r.updateCatSalary,
+			},
+			"deleteCat": {
+				Type:    graphql.NewNonNull(graphql.Boolean),
+				Args:    graphql.FieldConfigArgument{"id": idArgument(), "version": versionArgument()},
+				Resolve: r.deleteCat,
+			},
+			"restoreCat": {
+				Type:    graphql.NewNonNull(catType),
+				Args:    graphql.FieldConfigArgument{"id": idArgument()},
+				Resolve: r.restoreCat,
+			},
+			"createMission": {
+				Type: graphql.NewNonNull(missionType),
+				Args: graphql.FieldConfigArgument{
+					"input": {Type: graphql.NewNonNull(graphql.NewInputObject(graphql.InputObjectConfig{
+						Name: "MissionCreateInput",
+						Fields: graphql.InputObjectConfigFieldMap{
+							"name":    {Type: graphql.NewNonNull(graphql.String)},
+							"catId":   {Type: graphql.ID},
+							"targets": {Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(targetCreateInput)))},
+						},
+					}))},
+				},
+				Resolve: r.createMission,
+			},
+			"updateMissionStatus": {
+				Type: graphql.NewNonNull(missionType),
+				Args: graphql.FieldConfigArgument{
+					"id":      idArgument(),
+					"version": versionArgument(),
+					"status":  {Type: graphql.NewNonNull(missionStatusEnum)},
+				},
+				Resolve: r.updateMissionStatus,
+			},
+			"deleteMission": {
+				Type:    graphql.NewNonNull(graphql.Boolean),
+				Args:    graphql.FieldConfigArgument{"id": idArgument(), "version": versionArgument()},
+				Resolve: r.deleteMission,
+			},
+			"restoreMission": {
+				Type:    graphql.NewNonNull(missionType),
+				Args:    graphql.FieldConfigArgument{"id": idArgument()},
+				Resolve: r.restoreMission,
+			},
+			"assignCat": {
+				Type: graphql.NewNonNull(missionType),
+				Args: graphql.FieldConfigArgument{
+					"missionId": {Type: graphql.NewNonNull(graphql.ID)},
+					"catId":     {Type: graphql.NewNonNull(graphql.ID)},
+					"reason":    {Type: graphql.String, Description: "Recorded on the assignment of the cat being replaced"},
+				},
+				Resolve: r.assignCat,
+			},
+			"addTarget": {
+				Type: graphql.NewNonNull(targetType),
+				Args: graphql.FieldConfigArgument{
+					"missionId": {Type: graphql.NewNonNull(graphql.ID)},
+					"input":     {Type: graphql.NewNonNull(targetCreateInput)},
+				},
+				Resolve: r.addTarget,
+			},
+			"updateTarget": {
+				Type: graphql.NewNonNull(targetType),
+				Args: graphql.FieldConfigArgument{
+					"id":      idArgument(),
+					"version": versionArgument(),
+					"input": {Type: graphql.NewNonNull(graphql.NewInputObject(graphql.InputObjectConfig{
+						Name: "TargetUpdateInput",
+						Fields: graphql.InputObjectConfigFieldMap{
+							"notes":     {Type: graphql.String},
+							"completed": {Type: graphql.Boolean},
+						},
+					}))},
+				},
+				Resolve: r.updateTarget,
+			},
+			"deleteTarget": {
+				Type:    graphql.NewNonNull(graphql.Boolean),
+				Args:    graphql.FieldConfigArgument{"id": idArgument(), "version": versionArgument()},
+				Resolve: r.deleteTarget,
+			},
+			"restoreTarget": {
+				Type:    graphql.NewNonNull(targetType),
+				Args:    graphql.FieldConfigArgument{"id": idArgument()},
+				Resolve: r.restoreTarget,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query, Mutation: mutation})
+}
+
+// pageType is the envelope of a paginated list, like the REST list responses
+func pageType(name string, item *graphql.Object) *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: name,
+		Fields: graphql.Fields{
+			"items": {
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(item))),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					switch page := p.Source.(type) {
+					case *model.CatPage:
+						return page.Items, nil
+					case *model.MissionPage:
+						return page.Items, nil
+					}
+					return nil, nil
+				},
+			},
+			"total": {
+				Type: graphql.NewNonNull(graphql.Int),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return pageInfo(p.Source).Total, nil
+				},
+			},
+			"nextCursor": {
+				Type:        graphql.String,
+				Description: "Cursor of the next page, null on the last page",
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					if cursor := pageInfo(p.Source).NextCursor; cursor != "" {
+						return cursor, nil
+					}
+					return nil, nil
+				},
+			},
+		},
+	})
+}
+
+func pageInfo(page any) model.PageInfo {
+	switch page := page.(type) {
+	case *model.CatPage:
+		return page.PageInfo
+	case *model.MissionPage:
+		return page.PageInfo
+	}
+	return model.PageInfo{}
+}
+
+func idArgument() *graphql.ArgumentConfig {
+	return &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}
+}
+
+// versionArgument is the optimistic lock of a change, like the If-Match header of the REST routes
+func versionArgument() *graphql.ArgumentConfig {
+	return &graphql.ArgumentConfig{
+		Type:        graphql.Int,
+		Description: "Apply the change only if this is still the current version",
+	}
+}
+
+// withListArgs adds the pagination and sorting arguments of list queries
+func withListArgs(args graphql.FieldConfigArgument) graphql.FieldConfigArgument {
+	args["limit"] = &graphql.ArgumentConfig{Type: graphql.Int, Description: "Page size (1-100, default 20)"}
+	args["cursor"] = &graphql.ArgumentConfig{Type: graphql.String, Description: "nextCursor of the previous page"}
+	args["sort"] = &graphql.ArgumentConfig{Type: graphql.String, Description: "Sort column; prefix with - for descending"}
+	return args
+}
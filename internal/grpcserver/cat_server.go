@@ -0,0 +1,96 @@
+package grpcserver
+
+import (
+	spycatv1 "SpyCatAgency/api/spycat/v1"
+	"SpyCatAgency/internal/model"
+	"SpyCatAgency/internal/service"
+	"context"
+)
+
+type catServer struct {
+	spycatv1.UnimplementedCatServiceServer
+	service *service.CatService
+}
+
+func (s *catServer) CreateCat(ctx context.Context, req *spycatv1.CreateCatRequest) (*spycatv1.Cat, error) {
+	create := model.CatCreate{
+		Name:            req.Name,
+		YearsExperience: int(req.YearsExperience),
+		Breed:           req.Breed,
+		Salary:          req.Salary,
+	}
+	if err := service.ValidateInput(create); err != nil {
+		return nil, err
+	}
+
+	cat, err := s.service.Create(ctx, create)
+	if err != nil {
+		return nil, err
+	}
+	return toCat(cat), nil
+}
+
+func (s *catServer) GetCat(ctx context.Context, req *spycatv1.GetCatRequest) (*spycatv1.Cat, error) {
+	cat, err := s.service.GetByID(ctx, uint(req.Id))
+	if err != nil {
+		return nil, err
+	}
+	return toCat(cat), nil
+}
+
+func (s *catServer) ListCats(ctx context.Context, req *spycatv1.ListCatsRequest) (*spycatv1.ListCatsResponse, error) {
+	filter := model.CatFilter{
+		ListParams:    fromListParams(req.Page),
+		Breed:         req.Breed,
+		MinExperience: fromOptionalInt(req.MinExperience),
+		MaxExperience: fromOptionalInt(req.MaxExperience),
+		MinSalary:     req.MinSalary,
+		MaxSalary:     req.MaxSalary,
+	}
+	if err := service.ValidateInput(filter); err != nil {
+		return nil, err
+	}
+
+	page, err := s.service.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &spycatv1.ListCatsResponse{Page: toPageInfo(page.PageInfo)}
+	for i := range page.Items {
+		resp.Items = append(resp.Items, toCat(&page.Items[i]))
+	}
+	return resp, nil
+}
+
+func (s *catServer) UpdateCatSalary(ctx context.Context, req *spycatv1.UpdateCatSalaryRequest) (*spycatv1.Cat, error) {
+	update := model.CatUpdate{
+		Salary:        req.Salary,
+		EffectiveFrom: req.EffectiveFrom,
+		Reason:        req.Reason,
+	}
+	if err := service.ValidateInput(update); err != nil {
+		return nil, err
+	}
+
+	cat, err := s.service.Update(ctx, uint(req.Id), int(req.Version), update)
+	if err != nil {
+		return nil, err
+	}
+	return toCat(cat), nil
+}
+
+func (s *catServer) DeleteCat(ctx context.Context, req *spycatv1.DeleteCatRequest) (*spycatv1.DeleteCatResponse, error) {
+	if err := s.service.Delete(ctx, uint(req.Id), int(req.Version)); err != nil {
+		return nil, err
+	}
+	return &spycatv1.DeleteCatResponse{}, nil
+}
+
+func (s *catServer) RestoreCat(ctx context.Context, req *spycatv1.RestoreCatRequest) (*spycatv1.Cat, error) {
+	cat, err := s.service.Restore(ctx, uint(req.Id))
+	if err != nil {
+		return nil, err
+	}
+	return toCat(cat), nil
+}
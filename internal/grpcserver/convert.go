@@ -0,0 +1,137 @@
+package grpcserver
+
+import (
+	spycatv1 "SpyCatAgency/api/spycat/v1"
+	"SpyCatAgency/internal/model"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var missionStatuses = map[model.MissionStatus]spycatv1.MissionStatus{
+	model.MissionStatusDraft:      spycatv1.MissionStatus_MISSION_STATUS_DRAFT,
+	model.MissionStatusAssigned:   spycatv1.MissionStatus_MISSION_STATUS_ASSIGNED,
+	model.MissionStatusInProgress: spycatv1.MissionStatus_MISSION_STATUS_IN_PROGRESS,
+	model.MissionStatusCompleted:  spycatv1.MissionStatus_MISSION_STATUS_COMPLETED,
+	model.MissionStatusAborted:    spycatv1.MissionStatus_MISSION_STATUS_ABORTED,
+}
+
+func toCat(cat *model.Cat) *spycatv1.Cat {
+	if cat == nil {
+		return nil
+	}
+	return &spycatv1.Cat{
+		Id:              uint32(cat.ID),
+		Name:            cat.Name,
+		YearsExperience: int32(cat.YearsExperience),
+		Breed:           cat.Breed,
+		BreedVerified:   cat.BreedVerified,
+		Salary:          cat.Salary,
+		Version:         int32(cat.Version),
+		CreatedAt:       timestamppb.New(cat.CreatedAt),
+		UpdatedAt:       timestamppb.New(cat.UpdatedAt),
+		DeletedAt:       toTimestamp(cat.DeletedAt),
+	}
+}
+
+func toMission(mission *model.Mission) *spycatv1.Mission {
+	out := &spycatv1.Mission{
+		Id:        uint32(mission.ID),
+		Name:      mission.Name,
+		CatId:     toOptionalID(mission.CatID),
+		Status:    missionStatuses[mission.Status],
+		Version:   int32(mission.Version),
+		CreatedAt: timestamppb.New(mission.CreatedAt),
+		UpdatedAt: timestamppb.New(mission.UpdatedAt),
+		DeletedAt: toTimestamp(mission.DeletedAt),
+		Cat:       toCat(mission.Cat),
+	}
+	for i := range mission.Targets {
+		out.Targets = append(out.Targets, toTarget(&mission.Targets[i]))
+	}
+	return out
+}
+
+func toTarget(target *model.Target) *spycatv1.Target {
+	return &spycatv1.Target{
+		Id:        uint32(target.ID),
+		MissionId: uint32(target.MissionID),
+		Name:      target.Name,
+		Country:   target.Country,
+		Notes:     target.Notes,
+		Completed: target.Completed,
+		Version:   int32(target.Version),
+		CreatedAt: timestamppb.New(target.CreatedAt),
+		UpdatedAt: timestamppb.New(target.UpdatedAt),
+		DeletedAt: toTimestamp(target.DeletedAt),
+	}
+}
+
+func toPageInfo(page model.PageInfo) *spycatv1.PageInfo {
+	return &spycatv1.PageInfo{Total: int32(page.Total), NextCursor: page.NextCursor}
+}
+
+func toTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func toOptionalID(id *uint) *uint32 {
+	if id == nil {
+		return nil
+	}
+	v := uint32(*id)
+	return &v
+}
+
+// fromMissionStatus returns the status of the model, or "" for MISSION_STATUS_UNSPECIFIED and unknown values
+func fromMissionStatus(status spycatv1.MissionStatus) model.MissionStatus {
+	for s, v := range missionStatuses {
+		if v == status {
+			return s
+		}
+	}
+	return ""
+}
+
+func fromListParams(page *spycatv1.ListParams) model.ListParams {
+	return model.ListParams{
+		Limit:  int(page.GetLimit()),
+		Cursor: page.GetCursor(),
+		Sort:   page.GetSort(),
+	}
+}
+
+// fromInclude returns nil when no include is sent, so the service picks the default relations
+func fromInclude(include *spycatv1.MissionInclude) *model.MissionInclude {
+	if include == nil {
+		return nil
+	}
+	return &model.MissionInclude{Cat: include.Cat, Targets: include.Targets}
+}
+
+func fromTargetCreate(target *spycatv1.CreateTarget) model.TargetCreate {
+	return model.TargetCreate{
+		Name:    target.GetName(),
+		Country: target.GetCountry(),
+		Notes:   target.GetNotes(),
+	}
+}
+
+func fromOptionalID(id *uint32) *uint {
+	if id == nil {
+		return nil
+	}
+	v := uint(*id)
+	return &v
+}
+
+func fromOptionalInt(v *int32) *int {
+	if v == nil {
+		return nil
+	}
+	n := int(*v)
+	return &n
+}
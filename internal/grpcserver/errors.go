@@ -0,0 +1,69 @@
+package grpcserver
+
+import (
+	"SpyCatAgency/internal/apperror"
+	"SpyCatAgency/internal/logger"
+	"context"
+	"encoding/json"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// errorCodes are the gRPC codes of the API error kinds
+var errorCodes = []struct {
+	kind error
+	code codes.Code
+}{
+	{apperror.ErrNotFound, codes.NotFound},
+	{apperror.ErrConflict, codes.Aborted},
+	{apperror.ErrValidation, codes.InvalidArgument},
+	{apperror.ErrPreconditionFailed, codes.FailedPrecondition},
+	{apperror.ErrUnauthorized, codes.Unauthenticated},
+	{apperror.ErrForbidden, codes.PermissionDenied},
+	{apperror.ErrRateLimited, codes.ResourceExhausted},
+	{apperror.ErrUpstream, codes.Unavailable},
+}
+
+// statusError converts a service error into the status returned to the client. The details of the error,
+// e.g. the current version after a conflict, are attached as a google.protobuf.Struct. Like the HTTP error
+// handler, it hides the message of unexpected errors, which are logged instead.
+func statusError(ctx context.Context, err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var appErr *apperror.Error
+	if errors.As(err, &appErr) {
+		for _, c := range errorCodes {
+			if errors.Is(err, c.kind) {
+				return withDetails(status.New(c.code, appErr.Message()), appErr.Details())
+			}
+		}
+	}
+
+	logger.Error(ctx, err)
+	return status.Error(codes.Internal, "Internal Server Error")
+}
+
+func withDetails(st *status.Status, details map[string]any) error {
+	if len(details) == 0 {
+		return st.Err()
+	}
+
+	// round trip through JSON so any JSON-encodable detail converts, e.g. []string
+	doc, err := json.Marshal(details)
+	if err != nil {
+		return st.Err()
+	}
+	detail := &structpb.Struct{}
+	if err := detail.UnmarshalJSON(doc); err != nil {
+		return st.Err()
+	}
+	if withDetail, err := st.WithDetails(detail); err == nil {
+		return withDetail.Err()
+	}
+	return st.Err()
+}
@@ -0,0 +1,120 @@
+package grpcserver
+
+import (
+	"SpyCatAgency/internal/apperror"
+	"SpyCatAgency/internal/auth"
+	"SpyCatAgency/internal/logger"
+	"SpyCatAgency/internal/middleware"
+	"SpyCatAgency/internal/requestctx"
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// logCalls gives every call a request id, logs its start and end like the HTTP Logger middleware, converts
+// the errors of the services into gRPC statuses and turns panics into Internal errors
+func logCalls() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		reqID := ulid.Make().String()
+		ctx = requestctx.WithRequestID(ctx, reqID)
+		ctx = logger.WithAttr(ctx, slog.String("request_id", reqID), slog.String("method", info.FullMethod))
+		_ = grpc.SetHeader(ctx, metadata.Pairs("x-request-id", reqID))
+
+		logger.Info(ctx, "request start")
+		startedAt := time.Now()
+
+		call := &callInfo{}
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic: %v", r)
+			}
+			if err != nil {
+				err = statusError(ctx, err)
+			}
+
+			attrs := []slog.Attr{
+				slog.Int64("duration_ms", time.Since(startedAt).Milliseconds()),
+				slog.String("code", status.Code(err).String()),
+			}
+			if call.user != "" {
+				attrs = append(attrs, slog.String("user", call.user))
+			}
+			if err != nil {
+				attrs = append(attrs, slog.String("resp_message", status.Convert(err).Message()))
+			}
+			logger.Info(ctx, "request end", attrs...)
+		}()
+
+		return handler(withCallInfo(ctx, call), req)
+	}
+}
+
+// authenticate rejects calls without a valid JWT or API key and puts the principal of the credential into
+// the context. The credential is sent in the authorization metadata as a bearer token, or in x-api-key.
+func authenticate(tokens *auth.TokenManager, keys middleware.APIKeyAuthenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		credential := strings.TrimSpace(first(md.Get("x-api-key")))
+		if credential == "" {
+			scheme, token, ok := strings.Cut(first(md.Get("authorization")), " ")
+			if ok && strings.EqualFold(scheme, "Bearer") {
+				credential = strings.TrimSpace(token)
+			}
+		}
+		if credential == "" {
+			return nil, apperror.Unauthorized("missing bearer token or API key")
+		}
+
+		var principal *auth.Principal
+		var err error
+		if auth.IsAPIKey(credential) {
+			principal, err = keys.Authenticate(ctx, credential)
+		} else {
+			principal, err = tokens.Verify(credential)
+			if err != nil {
+				err = apperror.Unauthorized("invalid or expired token")
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		ctx = auth.WithPrincipal(ctx, principal)
+		ctx = requestctx.WithActor(ctx, principal.Name)
+		if call := callInfoFrom(ctx); call != nil {
+			call.user = principal.Name
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// callInfo carries what inner interceptors learn about a call back to logCalls
+type callInfo struct {
+	user string
+}
+
+type callInfoKey struct{}
+
+func withCallInfo(ctx context.Context, call *callInfo) context.Context {
+	return context.WithValue(ctx, callInfoKey{}, call)
+}
+
+func callInfoFrom(ctx context.Context) *callInfo {
+	call, _ := ctx.Value(callInfoKey{}).(*callInfo)
+	return call
+}
+
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
@@ -0,0 +1,153 @@
+package grpcserver
+
+import (
+	spycatv1 "SpyCatAgency/api/spycat/v1"
+	"SpyCatAgency/internal/model"
+	"SpyCatAgency/internal/service"
+	"context"
+)
+
+type missionServer struct {
+	spycatv1.UnimplementedMissionServiceServer
+	service *service.MissionService
+}
+
+func (s *missionServer) CreateMission(ctx context.Context, req *spycatv1.CreateMissionRequest) (*spycatv1.Mission, error) {
+	create := model.MissionCreate{Name: req.Name, CatID: fromOptionalID(req.CatId)}
+	for _, target := range req.Targets {
+		create.Targets = append(create.Targets, fromTargetCreate(target))
+	}
+	if err := service.ValidateInput(create); err != nil {
+		return nil, err
+	}
+
+	mission, err := s.service.Create(ctx, create)
+	if err != nil {
+		return nil, err
+	}
+	return toMission(mission), nil
+}
+
+func (s *missionServer) GetMission(ctx context.Context, req *spycatv1.GetMissionRequest) (*spycatv1.Mission, error) {
+	mission, err := s.service.GetByID(ctx, uint(req.Id), fromInclude(req.Include))
+	if err != nil {
+		return nil, err
+	}
+	return toMission(mission), nil
+}
+
+func (s *missionServer) ListMissions(ctx context.Context, req *spycatv1.ListMissionsRequest) (*spycatv1.ListMissionsResponse, error) {
+	filter := model.MissionFilter{
+		ListParams: fromListParams(req.Page),
+		Status:     fromMissionStatus(req.Status),
+		Completed:  req.Completed,
+		CatID:      fromOptionalID(req.CatId),
+		Country:    req.Country,
+	}
+	if err := service.ValidateInput(filter); err != nil {
+		return nil, err
+	}
+
+	page, err := s.service.List(ctx, filter, fromInclude(req.Include))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &spycatv1.ListMissionsResponse{Page: toPageInfo(page.PageInfo)}
+	for i := range page.Items {
+		resp.Items = append(resp.Items, toMission(&page.Items[i]))
+	}
+	return resp, nil
+}
+
+func (s *missionServer) UpdateMissionStatus(
+	ctx context.Context,
+	req *spycatv1.UpdateMissionStatusRequest,
+) (*spycatv1.Mission, error) {
+	update := model.MissionUpdate{Status: fromMissionStatus(req.Status)}
+	if err := service.ValidateInput(update); err != nil {
+		return nil, err
+	}
+
+	mission, err := s.service.Update(ctx, uint(req.Id), int(req.Version), update)
+	if err != nil {
+		return nil, err
+	}
+	return toMission(mission), nil
+}
+
+func (s *missionServer) DeleteMission(
+	ctx context.Context,
+	req *spycatv1.DeleteMissionRequest,
+) (*spycatv1.DeleteMissionResponse, error) {
+	if err := s.service.Delete(ctx, uint(req.Id), int(req.Version)); err != nil {
+		return nil, err
+	}
+	return &spycatv1.DeleteMissionResponse{}, nil
+}
+
+func (s *missionServer) RestoreMission(ctx context.Context, req *spycatv1.RestoreMissionRequest) (*spycatv1.Mission, error) {
+	mission, err := s.service.Restore(ctx, uint(req.Id))
+	if err != nil {
+		return nil, err
+	}
+	return toMission(mission), nil
+}
+
+// AssignCat returns the mission with the cat it was assigned, like GetMission with the default relations
+func (s *missionServer) AssignCat(ctx context.Context, req *spycatv1.AssignCatRequest) (*spycatv1.Mission, error) {
+	assign := model.CatAssign{CatID: uint(req.CatId), Reason: req.Reason}
+	if err := service.ValidateInput(assign); err != nil {
+		return nil, err
+	}
+	if err := s.service.AssignCat(ctx, uint(req.MissionId), assign); err != nil {
+		return nil, err
+	}
+
+	mission, err := s.service.GetByID(ctx, uint(req.MissionId), nil)
+	if err != nil {
+		return nil, err
+	}
+	return toMission(mission), nil
+}
+
+func (s *missionServer) AddTarget(ctx context.Context, req *spycatv1.AddTargetRequest) (*spycatv1.Target, error) {
+	create := fromTargetCreate(req.Target)
+	if err := service.ValidateInput(create); err != nil {
+		return nil, err
+	}
+
+	target, err := s.service.AddTarget(ctx, uint(req.MissionId), create)
+	if err != nil {
+		return nil, err
+	}
+	return toTarget(target), nil
+}
+
+func (s *missionServer) UpdateTarget(ctx context.Context, req *spycatv1.UpdateTargetRequest) (*spycatv1.Target, error) {
+	update := model.TargetUpdate{Notes: req.Notes, Completed: req.Completed}
+
+	target, err := s.service.UpdateTarget(ctx, uint(req.Id), int(req.Version), update)
+	if err != nil {
+		return nil, err
+	}
+	return toTarget(target), nil
+}
+
+func (s *missionServer) DeleteTarget(
+	ctx context.Context,
+	req *spycatv1.DeleteTargetRequest,
+) (*spycatv1.DeleteTargetResponse, error) {
+	if err := s.service.DeleteTarget(ctx, uint(req.Id), int(req.Version)); err != nil {
+		return nil, err
+	}
+	return &spycatv1.DeleteTargetResponse{}, nil
+}
+
+func (s *missionServer) RestoreTarget(ctx context.Context, req *spycatv1.RestoreTargetRequest) (*spycatv1.Target, error) {
+	target, err := s.service.RestoreTarget(ctx, uint(req.Id))
+	if err != nil {
+		return nil, err
+	}
+	return toTarget(target), nil
+}
@@ -0,0 +1,75 @@
+// Package grpcserver serves the cat and mission services over gRPC, next to the HTTP API
+package grpcserver
+
+import (
+	spycatv1 "SpyCatAgency/api/spycat/v1"
+	"SpyCatAgency/internal/auth"
+	"SpyCatAgency/internal/logger"
+	"SpyCatAgency/internal/middleware"
+	"SpyCatAgency/internal/service"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// shutdownTimeout bounds how long Shutdown waits for running calls before cancelling them
+const shutdownTimeout = 5 * time.Second
+
+type Server struct {
+	addr   string
+	server *grpc.Server
+}
+
+// NewServer registers the services behind interceptors that log every call and authenticate it with the
+// same JWTs and API keys as the HTTP API
+func NewServer(
+	addr string,
+	tokens *auth.TokenManager,
+	keys middleware.APIKeyAuthenticator,
+	cats *service.CatService,
+	missions *service.MissionService,
+) *Server {
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		logCalls(),
+		authenticate(tokens, keys),
+	))
+	spycatv1.RegisterCatServiceServer(server, &catServer{service: cats})
+	spycatv1.RegisterMissionServiceServer(server, &missionServer{service: missions})
+
+	return &Server{addr: addr, server: server}
+}
+
+// Run serves calls until Shutdown is called
+func (s *Server) Run(ctx context.Context) {
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		logger.Fatal(ctx, fmt.Errorf("grpc listen: %w", err))
+	}
+
+	if err := s.server.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		logger.Fatal(ctx, fmt.Errorf("grpc serve: %w", err))
+	}
+}
+
+// Shutdown stops accepting calls and waits for running ones, cancelling those still running after shutdownTimeout
+func (s *Server) Shutdown(ctx context.Context) {
+	logger.Info(ctx, "Shutdown gRPC server ...")
+
+	stopped := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(shutdownTimeout):
+		s.server.Stop()
+	}
+
+	logger.Info(ctx, "gRPC server exiting")
+}
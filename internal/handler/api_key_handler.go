@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"SpyCatAgency/internal/model"
+	"SpyCatAgency/internal/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type APIKeyHandler struct {
+	service *service.APIKeyService
+}
+
+func NewAPIKeyHandler(service *service.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{service: service}
+}
+
+func (h *APIKeyHandler) RegisterRoutes(router gin.IRouter) {
+	keys := router.Group("/api/apikeys")
+	{
+		keys.POST("", h.Create)
+		keys.GET("", h.List)
+		keys.POST("/:id/rotate", h.Rotate)
+		keys.DELETE("/:id", h.Revoke)
+	}
+}
+
+// @Summary Create an API key
+// @Description Issue an API key limited to the given scopes. The key is only returned in this response. Admins only
+// @Tags API keys
+// @Accept json
+// @Produce json
+// @Param body body model.APIKeyCreate true "API key create body"
+// @Success 201 {object} model.APIKeySecret
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Failure 403 {object} map[string]string "Not allowed for the caller's role"
+// @Failure 422 {object} map[string]interface{} "Unknown scope or expiry in the past"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/apikeys [post]
+func (h *APIKeyHandler) Create(ctx *gin.Context) {
+	var create model.APIKeyCreate
+	if err := ctx.ShouldBindJSON(&create); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	key, err := h.service.Create(ctx.Request.Context(), create)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.Header("Cache-Control", "no-store")
+	ctx.JSON(http.StatusCreated, key)
+}
+
+// @Summary List API keys
+// @Description List all API keys, including revoked and expired ones. Admins only
+// @Tags API keys
+// @Produce json
+// @Success 200 {array} model.APIKey
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Failure 403 {object} map[string]string "Not allowed for the caller's role"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/apikeys [get]
+func (h *APIKeyHandler) List(ctx *gin.Context) {
+	keys, err := h.service.List(ctx.Request.Context())
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, keys)
+}
+
+// @Summary Rotate an API key
+// @Description Replace the secret of an API key. The old key stops working immediately. Admins only
+// @Tags API keys
+// @Produce json
+// @Param id path int true "API key ID"
+// @Success 200 {object} model.APIKeySecret
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Failure 403 {object} map[string]string "Not allowed for the caller's role"
+// @Failure 404 {object} map[string]string "API key not found"
+// @Failure 409 {object} map[string]string "API key is revoked"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/apikeys/{id}/rotate [post]
+func (h *APIKeyHandler) Rotate(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	key, err := h.service.Rotate(ctx.Request.Context(), uint(id))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.Header("Cache-Control", "no-store")
+	ctx.JSON(http.StatusOK, key)
+}
+
+// @Summary Revoke an API key
+// @Description Permanently disable an API key. Admins only
+// @Tags API keys
+// @Produce plain
+// @Param id path int true "API key ID"
+// @Success 204 {string} string "No content"
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Failure 403 {object} map[string]string "Not allowed for the caller's role"
+// @Failure 404 {object} map[string]string "API key not found"
+// @Failure 409 {object} map[string]string "API key is already revoked"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/apikeys/{id} [delete]
+func (h *APIKeyHandler) Revoke(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	if err := h.service.Revoke(ctx.Request.Context(), uint(id)); err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"SpyCatAgency/internal/model"
+	"SpyCatAgency/internal/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuditHandler struct {
+	service *service.AuditService
+}
+
+func NewAuditHandler(service *service.AuditService) *AuditHandler {
+	return &AuditHandler{service: service}
+}
+
+func (h *AuditHandler) RegisterRoutes(router gin.IRouter) {
+	router.GET("/api/audit", h.List)
+}
+
+// @Summary List audit log entries
+// @Description Get a page of recorded changes to cats, missions and targets, optionally filtered
+// @Tags Audit
+// @Produce json
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Sort column: id, created_at; prefix with - for descending"
+// @Param entity query string false "Entity type" Enums(cat, mission, target, api_key, payroll_run, webhook)
+// @Param entity_id query int false "Entity ID"
+// @Param action query string false "Action" Enums(create, update, delete, restore, assign)
+// @Param actor query string false "Who made the change"
+// @Param request_id query string false "ID of the request that made the change (X-Request-ID header)"
+// @Param from query string false "Only changes at or after this time (RFC 3339)"
+// @Param to query string false "Only changes before this time (RFC 3339)"
+// @Success 200 {object} model.AuditPage
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 422 {object} map[string]string "Invalid sort column or cursor"
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Failure 403 {object} map[string]string "Not allowed for the caller's role"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/audit [get]
+func (h *AuditHandler) List(ctx *gin.Context) {
+	var filter model.AuditFilter
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := h.service.List(ctx.Request.Context(), filter)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	page.Next = nextPageLink(ctx, page.NextCursor)
+	ctx.JSON(http.StatusOK, page)
+}
@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"SpyCatAgency/internal/model"
+	"SpyCatAgency/internal/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuthHandler struct {
+	service *service.AuthService
+}
+
+func NewAuthHandler(service *service.AuthService) *AuthHandler {
+	return &AuthHandler{service: service}
+}
+
+func (h *AuthHandler) RegisterRoutes(router gin.IRouter) {
+	router.POST("/api/auth/token", h.Token)
+}
+
+// @Summary Get an access token
+// @Description Exchange a username and password for a bearer token used by every other endpoint
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param body body model.Credentials true "Login credentials"
+// @Success 200 {object} model.Token
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 401 {object} map[string]string "Invalid username or password"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/auth/token [post]
+func (h *AuthHandler) Token(ctx *gin.Context) {
+	var credentials model.Credentials
+	if err := ctx.ShouldBindJSON(&credentials); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	token, err := h.service.Login(ctx.Request.Context(), credentials)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.Header("Cache-Control", "no-store")
+	ctx.JSON(http.StatusOK, token)
+}
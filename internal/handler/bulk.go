@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"SpyCatAgency/internal/model"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxImportBody is the largest import request body accepted
+const maxImportBody = 5 << 20
+
+const csvContentType = "text/csv"
+
+// importRequest holds the decoded items of an import and whether it is all-or-nothing
+type importRequest[T any] struct {
+	rows   []model.ImportRow[T]
+	atomic bool
+}
+
+// readImport decodes an import body: a CSV file when the Content-Type is text/csv, a JSON array otherwise.
+// On failure it writes the error response and returns false.
+func readImport[T any](
+	ctx *gin.Context,
+	readCSV func(io.Reader) ([]model.ImportRow[T], error),
+) (*importRequest[T], bool) {
+	req := &importRequest[T]{}
+
+	if raw := ctx.Query("atomic"); raw != "" {
+		atomic, err := strconv.ParseBool(raw)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid atomic"})
+			return nil, false
+		}
+		req.atomic = atomic
+	}
+
+	body := http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxImportBody)
+
+	var err error
+	if ctx.ContentType() == csvContentType {
+		req.rows, err = readCSV(body)
+	} else {
+		var values []T
+		if err = json.NewDecoder(body).Decode(&values); err == nil {
+			req.rows = make([]model.ImportRow[T], len(values))
+			for i, value := range values {
+				req.rows[i].Value = value
+			}
+		}
+	}
+
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("import body exceeds %d bytes", tooLarge.Limit)})
+		return nil, false
+	}
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	return req, true
+}
+
+// exportFormat returns the format requested with ?format=, json by default
+func exportFormat(ctx *gin.Context) (string, bool) {
+	switch format := ctx.DefaultQuery("format", "json"); format {
+	case "json", "csv":
+		return format, true
+	default:
+		return "", false
+	}
+}
+
+// writeExport sends an export as a file download named after the entity
+func writeExport(ctx *gin.Context, name, format string, items any, writeCSV func(io.Writer) error) {
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, name, format))
+
+	if format == "json" {
+		ctx.JSON(http.StatusOK, items)
+		return
+	}
+
+	ctx.Header("Content-Type", csvContentType+"; charset=utf-8")
+	ctx.Status(http.StatusOK)
+	if err := writeCSV(ctx.Writer); err != nil {
+		_ = ctx.Error(err)
+	}
+}
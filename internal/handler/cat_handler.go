@@ -1,8 +1,10 @@
 package handler
 
 import (
+	"SpyCatAgency/internal/bulk"
 	"SpyCatAgency/internal/model"
 	"SpyCatAgency/internal/service"
+	"io"
 	"net/http"
 	"strconv"
 
@@ -17,7 +19,7 @@ func NewCatHandler(service *service.CatService) *CatHandler {
 	return &CatHandler{service: service}
 }
 
-func (h *CatHandler) RegisterRoutes(router *gin.Engine) {
+func (h *CatHandler) RegisterRoutes(router gin.IRouter) {
 	cats := router.Group("/api/cats")
 	{
 		cats.POST("/create", h.Create)
@@ -25,7 +27,13 @@ func (h *CatHandler) RegisterRoutes(router *gin.Engine) {
 		cats.DELETE("/:id", h.Delete)
 		cats.GET("/:id", h.GetByID)
 		cats.GET("/list", h.List)
+		cats.POST("/:id/restore", h.Restore)
+		cats.POST("/import", h.Import)
+		cats.GET("/export", h.Export)
+		cats.GET("/:id/salary-history", h.SalaryHistory)
 	}
+
+	router.GET("/api/breeds", h.ListBreeds)
 }
 
 // @Summary Create a new spy cat
@@ -34,9 +42,18 @@ func (h *CatHandler) RegisterRoutes(router *gin.Engine) {
 // @Accept json
 // @Produce json
 // @Param body body model.CatCreate true "CreateCat request body"
+// @Param Idempotency-Key header string false "Unique key making retries of this request safe"
+// @Header 201 {string} Idempotent-Replayed "true if the response was stored for an earlier request with the same Idempotency-Key"
 // @Success 201 {object} model.Cat
 // @Failure 400 {object} map[string]interface{} "Bad request"
+// @Failure 409 {object} map[string]interface{} "A request with the same Idempotency-Key is in progress"
+// @Failure 422 {object} map[string]interface{} "Invalid breed, or Idempotency-Key reused with a different payload"
+// @Failure 502 {object} map[string]interface{} "CatAPI unavailable"
+// @Failure 401 {object} map[string]interface{} "Missing or invalid token"
+// @Failure 403 {object} map[string]interface{} "Not allowed for the caller's role"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/cats/create [post]
 func (h *CatHandler) Create(c *gin.Context) {
 	var create model.CatCreate
@@ -47,7 +64,7 @@ func (h *CatHandler) Create(c *gin.Context) {
 
 	cat, err := h.service.Create(c.Request.Context(), create)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
@@ -55,15 +72,26 @@ func (h *CatHandler) Create(c *gin.Context) {
 }
 
 // @Summary Update cat salary
-// @Description Update salary of a spy cat by ID
+// @Description Update salary of a spy cat by ID from effective_from (today by default) on. The change is added to
+// @Description the salary history; it cannot take effect in the future, before the previous change or in a month
+// @Description that was already paid.
 // @Tags Cats
 // @Accept json
 // @Produce json
 // @Param id path int true "Cat ID"
 // @Param body body model.CatUpdate true "Update salary request body"
+// @Param If-Match header string false "ETag of the version being modified"
 // @Success 200 {object} model.Cat
 // @Failure 400 {object} map[string]interface{} "Bad request"
+// @Failure 404 {object} map[string]interface{} "Cat not found"
+// @Failure 409 {object} map[string]interface{} "Payroll was already run for the effective date"
+// @Failure 412 {object} map[string]interface{} "Version does not match If-Match"
+// @Failure 422 {object} map[string]interface{} "Effective date in the future or before the previous change"
+// @Failure 401 {object} map[string]interface{} "Missing or invalid token"
+// @Failure 403 {object} map[string]interface{} "Not allowed for the caller's role"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/cats/{id}/salary [put]
 func (h *CatHandler) Update(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -78,23 +106,38 @@ func (h *CatHandler) Update(c *gin.Context) {
 		return
 	}
 
-	cat, err := h.service.Update(c.Request.Context(), uint(id), update)
+	version, err := ifMatchVersion(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
+	cat, err := h.service.Update(c.Request.Context(), uint(id), version, update)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	setETag(c, cat.Version)
 	c.JSON(http.StatusOK, cat)
 }
 
 // @Summary Delete a spy cat
-// @Description Remove a spy cat by ID
+// @Description Soft-delete a spy cat by ID. Deleted cats can be restored until they are purged
 // @Tags Cats
 // @Produce plain
 // @Param id path int true "Cat ID"
+// @Param If-Match header string false "ETag of the version being modified"
 // @Success 204 {string} string "No content"
 // @Failure 400 {object} map[string]interface{} "Bad request"
+// @Failure 404 {object} map[string]interface{} "Cat not found"
+// @Failure 409 {object} map[string]interface{} "Cat is on an active mission"
+// @Failure 412 {object} map[string]interface{} "Version does not match If-Match"
+// @Failure 401 {object} map[string]interface{} "Missing or invalid token"
+// @Failure 403 {object} map[string]interface{} "Not allowed for the caller's role"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/cats/{id} [delete]
 func (h *CatHandler) Delete(ctx *gin.Context) {
 	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
@@ -103,8 +146,14 @@ func (h *CatHandler) Delete(ctx *gin.Context) {
 		return
 	}
 
-	if err := h.service.Delete(ctx.Request.Context(), uint(id)); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	version, err := ifMatchVersion(ctx)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	if err := h.service.Delete(ctx.Request.Context(), uint(id), version); err != nil {
+		_ = ctx.Error(err)
 		return
 	}
 
@@ -116,9 +165,16 @@ func (h *CatHandler) Delete(ctx *gin.Context) {
 // @Tags Cats
 // @Produce json
 // @Param id path int true "Cat ID"
+// @Param include_deleted query bool false "Also return the cat if it is soft-deleted"
 // @Success 200 {object} model.Cat
+// @Header 200 {string} ETag "Current version of the cat"
 // @Failure 400 {object} map[string]interface{} "Bad request"
+// @Failure 404 {object} map[string]interface{} "Cat not found"
+// @Failure 401 {object} map[string]interface{} "Missing or invalid token"
+// @Failure 403 {object} map[string]interface{} "Not allowed for the caller's role"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/cats/{id} [get]
 func (h *CatHandler) GetByID(ctx *gin.Context) {
 	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
@@ -127,28 +183,246 @@ func (h *CatHandler) GetByID(ctx *gin.Context) {
 		return
 	}
 
-	cat, err := h.service.GetByID(ctx.Request.Context(), uint(id))
+	readCtx, err := readContext(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid include_deleted"})
 		return
 	}
 
+	cat, err := h.service.GetByID(readCtx, uint(id))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	setETag(ctx, cat.Version)
 	ctx.JSON(http.StatusOK, cat)
 }
 
-// @Summary List all spy cats
-// @Description Retrieve a full list of all registered spy cats in the agency
+// @Summary List spy cats
+// @Description Retrieve a page of spy cats, optionally filtered and sorted
 // @Tags Cats
 // @Produce json
-// @Success 200 {array} model.Cat
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Sort column: id, name, years_experience, salary, created_at; prefix with - for descending"
+// @Param breed query string false "Breed"
+// @Param min_experience query int false "Minimum years of experience"
+// @Param max_experience query int false "Maximum years of experience"
+// @Param min_salary query number false "Minimum salary"
+// @Param max_salary query number false "Maximum salary"
+// @Param include_deleted query bool false "Include soft-deleted cats"
+// @Success 200 {object} model.CatPage
+// @Failure 400 {object} map[string]interface{} "Bad request"
+// @Failure 422 {object} map[string]interface{} "Invalid sort column or cursor"
+// @Failure 401 {object} map[string]interface{} "Missing or invalid token"
+// @Failure 403 {object} map[string]interface{} "Not allowed for the caller's role"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/cats/list [get]
 func (h *CatHandler) List(c *gin.Context) {
-	cats, err := h.service.List(c.Request.Context())
+	var filter model.CatFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	readCtx, err := readContext(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid include_deleted"})
+		return
+	}
+
+	page, err := h.service.List(readCtx, filter)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	page.Next = nextPageLink(c, page.NextCursor)
+	c.JSON(http.StatusOK, page)
+}
+
+// @Summary Restore a spy cat
+// @Description Undo the soft delete of a spy cat
+// @Tags Cats
+// @Produce json
+// @Param id path int true "Cat ID"
+// @Success 200 {object} model.Cat
+// @Header 200 {string} ETag "Current version of the cat"
+// @Failure 400 {object} map[string]interface{} "Bad request"
+// @Failure 404 {object} map[string]interface{} "Cat not found"
+// @Failure 409 {object} map[string]interface{} "Cat is not deleted"
+// @Failure 401 {object} map[string]interface{} "Missing or invalid token"
+// @Failure 403 {object} map[string]interface{} "Not allowed for the caller's role"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/cats/{id}/restore [post]
+func (h *CatHandler) Restore(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	cat, err := h.service.Restore(ctx.Request.Context(), uint(id))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	setETag(ctx, cat.Version)
+	ctx.JSON(http.StatusOK, cat)
+}
+
+// @Summary Get cat salary history
+// @Description Salary changes of a spy cat, oldest first, with their effective date, author and reason
+// @Tags Cats
+// @Produce json
+// @Param id path int true "Cat ID"
+// @Param include_deleted query bool false "Also return the history of a soft-deleted cat"
+// @Success 200 {array} model.SalaryChange
+// @Failure 400 {object} map[string]interface{} "Bad request"
+// @Failure 404 {object} map[string]interface{} "Cat not found"
+// @Failure 401 {object} map[string]interface{} "Missing or invalid token"
+// @Failure 403 {object} map[string]interface{} "Not allowed for the caller's role"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/cats/{id}/salary-history [get]
+func (h *CatHandler) SalaryHistory(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	readCtx, err := readContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid include_deleted"})
+		return
+	}
+
+	history, err := h.service.SalaryHistory(readCtx, uint(id))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, history)
+}
+
+// @Summary Import spy cats
+// @Description Create up to 1000 cats from a JSON array or a CSV file (Content-Type text/csv) with the columns
+// @Description name, years_experience, breed and salary. Every item is validated, including its breed.
+// @Description With atomic=true any invalid item rejects the whole import; otherwise the valid items are
+// @Description created and the report lists the errors of the others.
+// @Tags Cats
+// @Accept json
+// @Accept text/csv
+// @Produce json
+// @Param body body []model.CatCreate true "Cats to create"
+// @Param atomic query bool false "Create nothing unless every item is valid"
+// @Param Idempotency-Key header string false "Unique key making retries of this request safe"
+// @Success 200 {object} model.ImportReport
+// @Failure 400 {object} map[string]interface{} "Malformed JSON or CSV"
+// @Failure 413 {object} map[string]interface{} "Body larger than 5 MiB"
+// @Failure 422 {object} map[string]interface{} "Too many items, or an invalid item in atomic mode"
+// @Failure 502 {object} map[string]interface{} "CatAPI unavailable"
+// @Failure 401 {object} map[string]interface{} "Missing or invalid token"
+// @Failure 403 {object} map[string]interface{} "Not allowed for the caller's role"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/cats/import [post]
+func (h *CatHandler) Import(ctx *gin.Context) {
+	req, ok := readImport(ctx, bulk.ReadCatsCSV)
+	if !ok {
+		return
+	}
+
+	report, err := h.service.Import(ctx.Request.Context(), req.rows, req.atomic)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// @Summary Export spy cats
+// @Description Download every cat matching the filters as JSON or as CSV in the format accepted by the import
+// @Tags Cats
+// @Produce json
+// @Produce text/csv
+// @Param format query string false "Export format" Enums(json, csv)
+// @Param sort query string false "Sort column: id, name, years_experience, salary, created_at; prefix with - for descending"
+// @Param breed query string false "Breed"
+// @Param min_experience query int false "Minimum years of experience"
+// @Param max_experience query int false "Maximum years of experience"
+// @Param min_salary query number false "Minimum salary"
+// @Param max_salary query number false "Maximum salary"
+// @Param include_deleted query bool false "Include soft-deleted cats"
+// @Success 200 {array} model.Cat
+// @Header 200 {string} Content-Disposition "attachment; filename=cats.json or cats.csv"
+// @Failure 400 {object} map[string]interface{} "Bad request"
+// @Failure 422 {object} map[string]interface{} "Invalid sort column"
+// @Failure 401 {object} map[string]interface{} "Missing or invalid token"
+// @Failure 403 {object} map[string]interface{} "Not allowed for the caller's role"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/cats/export [get]
+func (h *CatHandler) Export(ctx *gin.Context) {
+	format, ok := exportFormat(ctx)
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid format"})
+		return
+	}
+
+	var filter model.CatFilter
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	readCtx, err := readContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid include_deleted"})
+		return
+	}
+
+	cats, err := h.service.Export(readCtx, filter)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	writeExport(ctx, "cats", format, cats, func(w io.Writer) error {
+		return bulk.WriteCatsCSV(w, cats)
+	})
+}
+
+// @Summary List cat breeds
+// @Description Breeds accepted when creating a spy cat, served from the cached TheCatAPI catalog
+// @Tags Cats
+// @Produce json
+// @Success 200 {array} client.CatBreed
+// @Failure 502 {object} map[string]interface{} "Breed catalog unavailable"
+// @Failure 401 {object} map[string]interface{} "Missing or invalid token"
+// @Failure 403 {object} map[string]interface{} "Not allowed for the caller's role"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/breeds [get]
+func (h *CatHandler) ListBreeds(c *gin.Context) {
+	breeds, err := h.service.ListBreeds(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, cats)
+	c.JSON(http.StatusOK, breeds)
 }
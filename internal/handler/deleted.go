@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"SpyCatAgency/internal/repository"
+	"context"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// readContext returns the request context, including soft-deleted rows when ?include_deleted=true is set
+func readContext(ctx *gin.Context) (context.Context, error) {
+	reqCtx := ctx.Request.Context()
+
+	raw := ctx.Query("include_deleted")
+	if raw == "" {
+		return reqCtx, nil
+	}
+
+	include, err := strconv.ParseBool(raw)
+	if err != nil {
+		return nil, err
+	}
+	if include {
+		return repository.WithDeleted(reqCtx), nil
+	}
+	return reqCtx, nil
+}
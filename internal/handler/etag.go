@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"SpyCatAgency/internal/apperror"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setETag exposes the version of the returned resource as its entity tag
+func setETag(ctx *gin.Context, version int) {
+	ctx.Header("ETag", strconv.Quote(strconv.Itoa(version)))
+}
+
+// ifMatchVersion returns the version required by the If-Match header, or 0 if any version is acceptable
+func ifMatchVersion(ctx *gin.Context) (int, error) {
+	header := strings.TrimSpace(ctx.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+
+	tag := strings.TrimPrefix(header, "W/")
+	unquoted, err := strconv.Unquote(tag)
+	if err != nil {
+		return 0, apperror.PreconditionFailed("If-Match must be a single entity tag returned by the API")
+	}
+
+	version, err := strconv.Atoi(unquoted)
+	if err != nil || version <= 0 {
+		return 0, apperror.PreconditionFailed("If-Match %s does not match any version", header)
+	}
+
+	return version, nil
+}
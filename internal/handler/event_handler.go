@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"SpyCatAgency/internal/model"
+	"SpyCatAgency/internal/requestctx"
+	"SpyCatAgency/internal/service"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// streamRetry is the reconnection delay suggested to clients whose stream ended
+const streamRetry = 3 * time.Second
+
+type EventHandler struct {
+	service   *service.StreamService
+	heartbeat time.Duration
+}
+
+func NewEventHandler(service *service.StreamService, heartbeat time.Duration) *EventHandler {
+	return &EventHandler{service: service, heartbeat: heartbeat}
+}
+
+func (h *EventHandler) RegisterRoutes(router gin.IRouter) {
+	router.GET("/api/events", h.Stream)
+}
+
+// @Summary Stream mission events
+// @Description Server-Sent Events stream of mission and target events as they happen, optionally limited to one mission or cat. Every event has its outbox id as the SSE id and its type as the SSE event; data is the event as delivered to webhooks. Reconnecting with the Last-Event-ID header (or last_event_id) resumes after that event. Clients that fall too far behind are disconnected and should reconnect the same way. Cats only receive events of their own missions
+// @Tags Events
+// @Produce text/event-stream
+// @Param mission_id query int false "Mission ID"
+// @Param cat_id query int false "Cat ID"
+// @Param last_event_id query int false "Resume after this event; the Last-Event-ID header takes precedence"
+// @Param Last-Event-ID header int false "Resume after this event"
+// @Success 200 {object} model.Event "One SSE message per event"
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Failure 403 {object} map[string]string "Not allowed for the caller's role"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/events [get]
+func (h *EventHandler) Stream(ctx *gin.Context) {
+	var filter model.EventStreamFilter
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if header := ctx.GetHeader("Last-Event-ID"); header != "" {
+		id, err := strconv.ParseUint(header, 10, 64)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid Last-Event-ID"})
+			return
+		}
+		filter.LastEventID = &id
+	}
+
+	reqCtx := ctx.Request.Context()
+	sub, err := h.service.Subscribe(reqCtx, filter)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	defer h.service.Unsubscribe(sub)
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	// Proxies must not buffer the stream
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+
+	w := ctx.Writer
+	fmt.Fprintf(w, "retry: %d\n\n", streamRetry.Milliseconds())
+	w.Flush()
+
+	// Events up to the one the client resumes from were already seen, whether replayed or live
+	var last uint64
+	send := func(event model.Event) error {
+		if event.ID <= last {
+			return nil
+		}
+		data, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data); err != nil {
+			return err
+		}
+		w.Flush()
+		last = event.ID
+		return nil
+	}
+
+	if filter.LastEventID != nil {
+		last = *filter.LastEventID
+		if err := h.service.Replay(reqCtx, sub, last, send); err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-reqCtx.Done():
+			return
+		case <-requestctx.ShuttingDown(reqCtx):
+			// The client reconnects, possibly to another instance, and resumes with Last-Event-ID
+			fmt.Fprint(w, ": shutting down, reconnect to resume\n\n")
+			w.Flush()
+			return
+		case event, ok := <-sub.Events():
+			if !ok {
+				// The client fell behind; it catches up from the outbox when it reconnects with Last-Event-ID
+				fmt.Fprint(w, ": dropped, reconnect to resume\n\n")
+				w.Flush()
+				return
+			}
+			if err := send(event); err != nil {
+				_ = ctx.Error(err)
+				return
+			}
+		case <-heartbeat.C:
+			// Comments keep idle connections open through proxies
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+			w.Flush()
+		}
+	}
+}
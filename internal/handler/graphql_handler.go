@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"SpyCatAgency/internal/graph"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GraphQLHandler struct {
+	executor *graph.Executor
+}
+
+func NewGraphQLHandler(executor *graph.Executor) *GraphQLHandler {
+	return &GraphQLHandler{executor: executor}
+}
+
+func (h *GraphQLHandler) RegisterRoutes(router gin.IRouter) {
+	router.POST("/api/graphql", h.Execute)
+}
+
+// @Summary Run a GraphQL request
+// @Description Runs a GraphQL query or mutation over cats, missions and targets. Relations (Cat.missions, Mission.cat, Mission.targets) are loaded in one batch per level of the query. Requests nested deeper than GRAPHQL_MAX_DEPTH or estimated to resolve more than GRAPHQL_MAX_COMPLEXITY fields are rejected before they run. The response is always 200 with the errors in the errors list; their extensions.code is NOT_FOUND, CONFLICT, BAD_USER_INPUT, PRECONDITION_FAILED, UNAUTHENTICATED, FORBIDDEN, RATE_LIMITED, UPSTREAM_FAILURE or INTERNAL_SERVER_ERROR, or QUERY_TOO_COMPLEX when a limit is exceeded. The same roles and permissions apply as on the REST routes
+// @Tags GraphQL
+// @Accept json
+// @Produce json
+// @Param request body graph.Request true "GraphQL request"
+// @Success 200 {object} map[string]interface{} "GraphQL response with data and errors"
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/graphql [post]
+func (h *GraphQLHandler) Execute(ctx *gin.Context) {
+	var req graph.Request
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, h.executor.Execute(ctx.Request.Context(), req))
+}
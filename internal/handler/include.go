@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"SpyCatAgency/internal/model"
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// missionInclude parses ?include=cat,targets. It returns nil when the parameter is absent so the
+// service applies its default; an empty value loads no relations.
+func missionInclude(ctx *gin.Context) (*model.MissionInclude, error) {
+	raw, ok := ctx.GetQuery("include")
+	if !ok {
+		return nil, nil
+	}
+
+	include := &model.MissionInclude{}
+	for _, name := range strings.Split(raw, ",") {
+		switch strings.TrimSpace(name) {
+		case "":
+		case "cat":
+			include.Cat = true
+		case "targets":
+			include.Targets = true
+		default:
+			return nil, fmt.Errorf("invalid include %q, expected cat or targets", name)
+		}
+	}
+	return include, nil
+}
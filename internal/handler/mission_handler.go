@@ -1,8 +1,10 @@
 package handler
 
 import (
+	"SpyCatAgency/internal/bulk"
 	"SpyCatAgency/internal/model"
 	"SpyCatAgency/internal/service"
+	"io"
 	"net/http"
 	"strconv"
 
@@ -17,7 +19,7 @@ func NewMissionHandler(service *service.MissionService) *MissionHandler {
 	return &MissionHandler{service: service}
 }
 
-func (h *MissionHandler) RegisterRoutes(router *gin.Engine) {
+func (h *MissionHandler) RegisterRoutes(router gin.IRouter) {
 	missions := router.Group("/api/missions")
 	{
 		missions.POST("", h.Create)
@@ -26,21 +28,39 @@ func (h *MissionHandler) RegisterRoutes(router *gin.Engine) {
 		missions.GET("/:id", h.GetByID)
 		missions.GET("", h.List)
 		missions.POST("/:id/assign", h.AssignCat)
+		missions.GET("/:id/assignments", h.MissionAssignments)
 		missions.POST("/:id/targets", h.AddTarget)
 		missions.DELETE("/targets/:id", h.DeleteTarget)
 		missions.PUT("/targets/:id", h.UpdateTarget)
+		missions.POST("/:id/restore", h.Restore)
+		missions.POST("/targets/:id/restore", h.RestoreTarget)
+		missions.GET("/targets/:id/notes", h.TargetNotes)
+		missions.GET("/targets/:id/notes/diff", h.DiffTargetNotes)
+		missions.POST("/import", h.Import)
+		missions.GET("/export", h.Export)
 	}
+
+	router.GET("/api/cats/:id/assignments", h.CatAssignments)
 }
 
 // @Summary Create a new mission
-// @Description Create a mission with 1–3 targets
+// @Description Create a mission with 1–3 targets. Missions without a cat start as drafts
 // @Tags Missions
 // @Accept json
 // @Produce json
 // @Param body body model.MissionCreate true "Mission create body"
+// @Param Idempotency-Key header string false "Unique key making retries of this request safe"
+// @Header 201 {string} Idempotent-Replayed "true if the response was stored for an earlier request with the same Idempotency-Key"
 // @Success 201 {object} model.Mission
 // @Failure 400 {object} map[string]string "Bad request"
+// @Failure 404 {object} map[string]string "Cat not found"
+// @Failure 409 {object} map[string]interface{} "Cat is already on an active mission, or a request with the same Idempotency-Key is in progress"
+// @Failure 422 {object} map[string]string "Idempotency-Key reused with a different payload"
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Failure 403 {object} map[string]string "Not allowed for the caller's role"
 // @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/missions [post]
 func (h *MissionHandler) Create(ctx *gin.Context) {
 	var create model.MissionCreate
@@ -51,23 +71,32 @@ func (h *MissionHandler) Create(ctx *gin.Context) {
 
 	mission, err := h.service.Create(ctx.Request.Context(), create)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		_ = ctx.Error(err)
 		return
 	}
 
 	ctx.JSON(http.StatusCreated, mission)
 }
 
-// @Summary Create a new mission
-// @Description Create a mission with 1–3 targets
+// @Summary Change mission status
+// @Description Move a mission through its lifecycle: draft, assigned, in_progress, completed, aborted
 // @Tags Missions
 // @Accept json
 // @Produce json
-// @Param body body model.MissionCreate true "Mission create body"
-// @Success 201 {object} model.Mission
+// @Param id path int true "Mission ID"
+// @Param body body model.MissionUpdate true "Mission update body"
+// @Param If-Match header string false "ETag of the version being modified"
+// @Success 200 {object} model.Mission
 // @Failure 400 {object} map[string]string "Bad request"
+// @Failure 404 {object} map[string]string "Mission not found"
+// @Failure 409 {object} map[string]string "Transition not allowed"
+// @Failure 412 {object} map[string]string "Version does not match If-Match"
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Failure 403 {object} map[string]string "Not allowed for the caller's role"
 // @Failure 500 {object} map[string]string "Internal server error"
-// @Router /api/missions [post]
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/missions/{id} [put]
 func (h *MissionHandler) Update(ctx *gin.Context) {
 	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
 	if err != nil {
@@ -81,23 +110,38 @@ func (h *MissionHandler) Update(ctx *gin.Context) {
 		return
 	}
 
-	mission, err := h.service.Update(ctx.Request.Context(), uint(id), update)
+	version, err := ifMatchVersion(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		_ = ctx.Error(err)
 		return
 	}
 
+	mission, err := h.service.Update(ctx.Request.Context(), uint(id), version, update)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	setETag(ctx, mission.Version)
 	ctx.JSON(http.StatusOK, mission)
 }
 
 // @Summary Delete a mission
-// @Description Delete a mission by ID (only if not assigned to a cat)
+// @Description Soft-delete a mission and its targets by ID (only if not assigned to a cat)
 // @Tags Missions
 // @Produce plain
 // @Param id path int true "Mission ID"
+// @Param If-Match header string false "ETag of the version being modified"
 // @Success 204 {string} string "No content"
 // @Failure 400 {object} map[string]string "Bad request"
+// @Failure 404 {object} map[string]string "Mission not found"
+// @Failure 409 {object} map[string]string "Conflict with mission state"
+// @Failure 412 {object} map[string]string "Version does not match If-Match"
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Failure 403 {object} map[string]string "Not allowed for the caller's role"
 // @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/missions/{id} [delete]
 func (h *MissionHandler) Delete(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -106,8 +150,14 @@ func (h *MissionHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.Delete(c.Request.Context(), uint(id)); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), uint(id), version); err != nil {
+		_ = c.Error(err)
 		return
 	}
 
@@ -115,13 +165,22 @@ func (h *MissionHandler) Delete(c *gin.Context) {
 }
 
 // @Summary Get mission by ID
-// @Description Retrieve mission details, including assigned cat and targets
+// @Description Retrieve mission details. By default the assigned cat (for callers allowed to see cats) and
+// @Description the targets are included; ?include selects them explicitly.
 // @Tags Missions
 // @Produce json
 // @Param id path int true "Mission ID"
+// @Param include query string false "Comma-separated relations to load: cat, targets; empty for none"
+// @Param include_deleted query bool false "Also return the mission if it is soft-deleted"
 // @Success 200 {object} model.Mission
+// @Header 200 {string} ETag "Current version of the mission"
 // @Failure 400 {object} map[string]string "Bad request"
+// @Failure 404 {object} map[string]string "Mission not found"
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Failure 403 {object} map[string]string "Not allowed for the caller's role"
 // @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/missions/{id} [get]
 func (h *MissionHandler) GetByID(ctx *gin.Context) {
 	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
@@ -130,34 +189,83 @@ func (h *MissionHandler) GetByID(ctx *gin.Context) {
 		return
 	}
 
-	mission, err := h.service.GetByID(ctx.Request.Context(), uint(id))
+	readCtx, err := readContext(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid include_deleted"})
+		return
+	}
+
+	include, err := missionInclude(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
+	mission, err := h.service.GetByID(readCtx, uint(id), include)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	setETag(ctx, mission.Version)
 	ctx.JSON(http.StatusOK, mission)
 }
 
-// @Summary List all missions
-// @Description Get all created missions
+// @Summary List missions
+// @Description Get a page of missions, optionally filtered and sorted. Cats and targets are loaded as for
+// @Description a single mission.
 // @Tags Missions
 // @Produce json
-// @Success 200 {array} model.Mission
+// @Param include query string false "Comma-separated relations to load: cat, targets; empty for none"
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Sort column: id, name, status, created_at; prefix with - for descending"
+// @Param status query string false "Mission status" Enums(draft, assigned, in_progress, completed, aborted)
+// @Param completed query bool false "Only completed (true) or not completed (false) missions"
+// @Param cat_id query int false "Assigned cat ID"
+// @Param country query string false "Country of any of the mission targets"
+// @Param include_deleted query bool false "Include soft-deleted missions"
+// @Success 200 {object} model.MissionPage
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 422 {object} map[string]string "Invalid sort column or cursor"
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Failure 403 {object} map[string]string "Not allowed for the caller's role"
 // @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/missions [get]
 func (h *MissionHandler) List(ctx *gin.Context) {
-	missions, err := h.service.List(ctx.Request.Context())
+	var filter model.MissionFilter
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	readCtx, err := readContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid include_deleted"})
+		return
+	}
+
+	include, err := missionInclude(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := h.service.List(readCtx, filter, include)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		_ = ctx.Error(err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, missions)
+	page.Next = nextPageLink(ctx, page.NextCursor)
+	ctx.JSON(http.StatusOK, page)
 }
 
 // @Summary Assign cat to mission
-// @Description Assign a cat to a mission (1 cat per mission)
+// @Description Assign a cat to a mission (1 cat per mission, 1 active mission per cat). A replaced cat is
+// @Description released from the mission with the given reason, "reassigned" by default.
 // @Tags Missions
 // @Accept json
 // @Produce plain
@@ -165,7 +273,13 @@ func (h *MissionHandler) List(ctx *gin.Context) {
 // @Param body body model.CatAssign true "Cat assign body"
 // @Success 200 {string} string "OK"
 // @Failure 400 {object} map[string]string "Bad request"
+// @Failure 404 {object} map[string]string "Mission or cat not found"
+// @Failure 409 {object} map[string]interface{} "Mission is closed or cat is already on an active mission"
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Failure 403 {object} map[string]string "Not allowed for the caller's role"
 // @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/missions/{id}/assign [post]
 func (h *MissionHandler) AssignCat(ctx *gin.Context) {
 	missionID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
@@ -181,8 +295,8 @@ func (h *MissionHandler) AssignCat(ctx *gin.Context) {
 		return
 	}
 
-	if err := h.service.AssignCat(ctx.Request.Context(), uint(missionID), request.CatID); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.service.AssignCat(ctx.Request.Context(), uint(missionID), request); err != nil {
+		_ = ctx.Error(err)
 		return
 	}
 
@@ -198,7 +312,14 @@ func (h *MissionHandler) AssignCat(ctx *gin.Context) {
 // @Param body body model.TargetCreate true "Target create body"
 // @Success 201 {object} model.Target
 // @Failure 400 {object} map[string]string "Bad request"
+// @Failure 404 {object} map[string]string "Mission not found"
+// @Failure 409 {object} map[string]string "Conflict with mission state"
+// @Failure 422 {object} map[string]string "Mission already has 3 targets"
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Failure 403 {object} map[string]string "Not allowed for the caller's role"
 // @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/missions/{id}/targets [post]
 func (h *MissionHandler) AddTarget(ctx *gin.Context) {
 	missionID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
@@ -215,7 +336,7 @@ func (h *MissionHandler) AddTarget(ctx *gin.Context) {
 
 	target, err := h.service.AddTarget(ctx.Request.Context(), uint(missionID), targetCreate)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		_ = ctx.Error(err)
 		return
 	}
 
@@ -223,13 +344,21 @@ func (h *MissionHandler) AddTarget(ctx *gin.Context) {
 }
 
 // @Summary Delete target
-// @Description Delete a target by ID (only if target is not completed)
+// @Description Delete a target by ID (only if target is not completed and is not the last target of its mission)
 // @Tags Missions
 // @Produce plain
 // @Param id path int true "Target ID"
+// @Param If-Match header string false "ETag of the version being modified"
 // @Success 204 {string} string "No content"
 // @Failure 400 {object} map[string]string "Bad request"
+// @Failure 404 {object} map[string]string "Target not found"
+// @Failure 409 {object} map[string]string "Conflict with mission state"
+// @Failure 412 {object} map[string]string "Version does not match If-Match"
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Failure 403 {object} map[string]string "Not allowed for the caller's role"
 // @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/missions/targets/{id} [delete]
 func (h *MissionHandler) DeleteTarget(ctx *gin.Context) {
 	targetID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
@@ -238,8 +367,14 @@ func (h *MissionHandler) DeleteTarget(ctx *gin.Context) {
 		return
 	}
 
-	if err := h.service.DeleteTarget(ctx.Request.Context(), uint(targetID)); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	version, err := ifMatchVersion(ctx)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	if err := h.service.DeleteTarget(ctx.Request.Context(), uint(targetID), version); err != nil {
+		_ = ctx.Error(err)
 		return
 	}
 
@@ -253,9 +388,17 @@ func (h *MissionHandler) DeleteTarget(ctx *gin.Context) {
 // @Produce json
 // @Param id path int true "Target ID"
 // @Param body body model.TargetUpdate true "Target update body"
+// @Param If-Match header string false "ETag of the version being modified"
 // @Success 200 {object} model.Target
 // @Failure 400 {object} map[string]string "Bad request"
+// @Failure 404 {object} map[string]string "Target not found"
+// @Failure 409 {object} map[string]string "Conflict with mission state"
+// @Failure 412 {object} map[string]string "Version does not match If-Match"
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Failure 403 {object} map[string]string "Not allowed for the caller's role"
 // @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/missions/targets/{id} [put]
 func (h *MissionHandler) UpdateTarget(ctx *gin.Context) {
 	targetID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
@@ -270,11 +413,334 @@ func (h *MissionHandler) UpdateTarget(ctx *gin.Context) {
 		return
 	}
 
-	target, err := h.service.UpdateTarget(ctx.Request.Context(), uint(targetID), update)
+	version, err := ifMatchVersion(ctx)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	target, err := h.service.UpdateTarget(ctx.Request.Context(), uint(targetID), version, update)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	setETag(ctx, target.Version)
+	ctx.JSON(http.StatusOK, target)
+}
+
+// @Summary Restore a mission
+// @Description Undo the soft delete of a mission, together with the targets deleted with it
+// @Tags Missions
+// @Produce json
+// @Param id path int true "Mission ID"
+// @Success 200 {object} model.Mission
+// @Header 200 {string} ETag "Current version of the mission"
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 404 {object} map[string]string "Mission not found"
+// @Failure 409 {object} map[string]interface{} "Mission is not deleted or its cat is busy"
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Failure 403 {object} map[string]string "Not allowed for the caller's role"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/missions/{id}/restore [post]
+func (h *MissionHandler) Restore(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	mission, err := h.service.Restore(ctx.Request.Context(), uint(id))
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		_ = ctx.Error(err)
 		return
 	}
 
+	setETag(ctx, mission.Version)
+	ctx.JSON(http.StatusOK, mission)
+}
+
+// @Summary Restore target
+// @Description Undo the soft delete of a target of an open mission
+// @Tags Missions
+// @Produce json
+// @Param id path int true "Target ID"
+// @Success 200 {object} model.Target
+// @Header 200 {string} ETag "Current version of the target"
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 404 {object} map[string]string "Target not found"
+// @Failure 409 {object} map[string]string "Target is not deleted or mission is closed"
+// @Failure 422 {object} map[string]string "Mission already has 3 targets"
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Failure 403 {object} map[string]string "Not allowed for the caller's role"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/missions/targets/{id}/restore [post]
+func (h *MissionHandler) RestoreTarget(ctx *gin.Context) {
+	targetID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid target id"})
+		return
+	}
+
+	target, err := h.service.RestoreTarget(ctx.Request.Context(), uint(targetID))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	setETag(ctx, target.Version)
 	ctx.JSON(http.StatusOK, target)
 }
+
+// @Summary List target notes history
+// @Description Every revision of the notes of a target, oldest first. A revision is added whenever a target
+// @Description update changes its notes; the history is frozen with the target.
+// @Tags Missions
+// @Produce json
+// @Param id path int true "Target ID"
+// @Param include_deleted query bool false "Also return the history of a soft-deleted target"
+// @Success 200 {array} model.TargetNote
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 404 {object} map[string]string "Target not found"
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Failure 403 {object} map[string]string "Not allowed for the caller's role"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/missions/targets/{id}/notes [get]
+func (h *MissionHandler) TargetNotes(ctx *gin.Context) {
+	targetID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid target id"})
+		return
+	}
+
+	readCtx, err := readContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid include_deleted"})
+		return
+	}
+
+	notes, err := h.service.TargetNotes(readCtx, uint(targetID))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, notes)
+}
+
+// @Summary Diff target notes revisions
+// @Description Line diff between two revisions of the notes of a target. Revision 0 is the empty note; by
+// @Description default the latest revision is compared with the previous one.
+// @Tags Missions
+// @Produce json
+// @Param id path int true "Target ID"
+// @Param from query int false "Older revision"
+// @Param to query int false "Newer revision"
+// @Param include_deleted query bool false "Also diff the notes of a soft-deleted target"
+// @Success 200 {object} model.TargetNotesDiff
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 404 {object} map[string]string "Target or revision not found"
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Failure 403 {object} map[string]string "Not allowed for the caller's role"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/missions/targets/{id}/notes/diff [get]
+func (h *MissionHandler) DiffTargetNotes(ctx *gin.Context) {
+	targetID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid target id"})
+		return
+	}
+
+	var query model.TargetNotesDiffQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	readCtx, err := readContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid include_deleted"})
+		return
+	}
+
+	diff, err := h.service.DiffTargetNotes(readCtx, uint(targetID), query)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, diff)
+}
+
+// @Summary List mission assignments
+// @Description Cats that worked on the mission, earliest first, with when and why they were released
+// @Tags Missions
+// @Produce json
+// @Param id path int true "Mission ID"
+// @Param include_deleted query bool false "Also return the history of a soft-deleted mission"
+// @Success 200 {array} model.Assignment
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 404 {object} map[string]string "Mission not found"
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Failure 403 {object} map[string]string "Not allowed for the caller's role"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/missions/{id}/assignments [get]
+func (h *MissionHandler) MissionAssignments(ctx *gin.Context) {
+	missionID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid mission id"})
+		return
+	}
+
+	readCtx, err := readContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid include_deleted"})
+		return
+	}
+
+	assignments, err := h.service.MissionAssignments(readCtx, uint(missionID))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, assignments)
+}
+
+// @Summary List cat assignments
+// @Description Missions the cat worked on, earliest first. Cats can only see their own.
+// @Tags Cats
+// @Produce json
+// @Param id path int true "Cat ID"
+// @Param include_deleted query bool false "Also return the history of a soft-deleted cat"
+// @Success 200 {array} model.Assignment
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 404 {object} map[string]string "Cat not found"
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Failure 403 {object} map[string]string "Not allowed for the caller's role"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/cats/{id}/assignments [get]
+func (h *MissionHandler) CatAssignments(ctx *gin.Context) {
+	catID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid cat id"})
+		return
+	}
+
+	readCtx, err := readContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid include_deleted"})
+		return
+	}
+
+	assignments, err := h.service.CatAssignments(readCtx, uint(catID))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, assignments)
+}
+
+// @Summary Import missions
+// @Description Create up to 1000 missions from a JSON array or a CSV file (Content-Type text/csv) with the columns
+// @Description mission_ref, name, cat_id, target_name, target_country and target_notes: one line per target, lines
+// @Description with the same mission_ref form one mission. Every item is validated, including that its cat exists
+// @Description and is free. With atomic=true any invalid item rejects the whole import; otherwise the valid items
+// @Description are created and the report lists the errors of the others.
+// @Tags Missions
+// @Accept json
+// @Accept text/csv
+// @Produce json
+// @Param body body []model.MissionCreate true "Missions to create"
+// @Param atomic query bool false "Create nothing unless every item is valid"
+// @Param Idempotency-Key header string false "Unique key making retries of this request safe"
+// @Success 200 {object} model.ImportReport
+// @Failure 400 {object} map[string]string "Malformed JSON or CSV"
+// @Failure 413 {object} map[string]string "Body larger than 5 MiB"
+// @Failure 422 {object} map[string]interface{} "Too many items, or an invalid item in atomic mode"
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Failure 403 {object} map[string]string "Not allowed for the caller's role"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/missions/import [post]
+func (h *MissionHandler) Import(ctx *gin.Context) {
+	req, ok := readImport(ctx, bulk.ReadMissionsCSV)
+	if !ok {
+		return
+	}
+
+	report, err := h.service.Import(ctx.Request.Context(), req.rows, req.atomic)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// @Summary Export missions
+// @Description Download every mission matching the filters with its targets, as JSON or as CSV in the format
+// @Description accepted by the import. The mission ID is used as mission_ref.
+// @Tags Missions
+// @Produce json
+// @Produce text/csv
+// @Param format query string false "Export format" Enums(json, csv)
+// @Param sort query string false "Sort column: id, name, status, created_at; prefix with - for descending"
+// @Param status query string false "Mission status" Enums(draft, assigned, in_progress, completed, aborted)
+// @Param completed query bool false "Only completed (true) or not completed (false) missions"
+// @Param cat_id query int false "Assigned cat ID"
+// @Param country query string false "Country of any of the mission targets"
+// @Param include_deleted query bool false "Include soft-deleted missions and targets"
+// @Success 200 {array} model.Mission
+// @Header 200 {string} Content-Disposition "attachment; filename=missions.json or missions.csv"
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 422 {object} map[string]string "Invalid sort column"
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Failure 403 {object} map[string]string "Not allowed for the caller's role"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/missions/export [get]
+func (h *MissionHandler) Export(ctx *gin.Context) {
+	format, ok := exportFormat(ctx)
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid format"})
+		return
+	}
+
+	var filter model.MissionFilter
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	readCtx, err := readContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid include_deleted"})
+		return
+	}
+
+	missions, err := h.service.Export(readCtx, filter)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	writeExport(ctx, "missions", format, missions, func(w io.Writer) error {
+		return bulk.WriteMissionsCSV(w, missions)
+	})
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"SpyCatAgency/internal/apperror"
+	"SpyCatAgency/internal/auth"
+	"SpyCatAgency/internal/middleware"
+	"SpyCatAgency/internal/model"
+	"SpyCatAgency/internal/repository"
+	"SpyCatAgency/internal/service"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Fakes serving mission 10 of cat 1 with a single assignment; other methods are not used by the tests
+
+type stubMissions struct {
+	repository.MissionRepository
+}
+
+func (stubMissions) GetByID(_ context.Context, id uint) (*model.Mission, error) {
+	if id != 10 {
+		return nil, apperror.NotFound("mission %d not found", id)
+	}
+	catID := uint(1)
+	return &model.Mission{ID: 10, CatID: &catID, Status: model.MissionStatusAssigned}, nil
+}
+
+type stubCats struct {
+	repository.CatRepository
+}
+
+func (stubCats) GetByID(_ context.Context, id uint) (*model.Cat, error) {
+	if id != 1 {
+		return nil, apperror.NotFound("cat %d not found", id)
+	}
+	return &model.Cat{ID: 1, Name: "Tom"}, nil
+}
+
+type stubAssignments struct {
+	repository.AssignmentRepository
+}
+
+func (stubAssignments) ListByMissionID(_ context.Context, missionID uint) ([]model.Assignment, error) {
+	return []model.Assignment{{ID: 5, MissionID: missionID, CatID: 1}}, nil
+}
+
+func (stubAssignments) ListByCatID(_ context.Context, catID uint) ([]model.Assignment, error) {
+	return []model.Assignment{{ID: 5, MissionID: 10, CatID: catID}}, nil
+}
+
+// newAssignmentsRouter serves the mission routes to the given principal
+func newAssignmentsRouter(p *auth.Principal) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	missions := service.NewMissionService(nil, stubMissions{}, nil, nil, stubAssignments{}, stubCats{}, nil, nil)
+
+	router := gin.New()
+	router.Use(middleware.ErrorHandler(), func(ctx *gin.Context) {
+		ctx.Request = ctx.Request.WithContext(auth.WithPrincipal(ctx.Request.Context(), p))
+	})
+	NewMissionHandler(missions).RegisterRoutes(router)
+	return router
+}
+
+func TestAssignmentRoutes(t *testing.T) {
+	handler := &auth.Principal{Name: "handler", Role: auth.RoleHandler}
+	otherCatID := uint(2)
+	otherCat := &auth.Principal{Name: "felix", Role: auth.RoleCat, CatID: &otherCatID}
+
+	tests := []struct {
+		name      string
+		principal *auth.Principal
+		path      string
+		want      int
+	}{
+		{name: "mission assignments", principal: handler, path: "/api/missions/10/assignments", want: http.StatusOK},
+		{name: "cat assignments", principal: handler, path: "/api/cats/1/assignments", want: http.StatusOK},
+		{name: "missing mission", principal: handler, path: "/api/missions/11/assignments", want: http.StatusNotFound},
+		{name: "missing cat", principal: handler, path: "/api/cats/3/assignments", want: http.StatusNotFound},
+		{name: "invalid mission id", principal: handler, path: "/api/missions/x/assignments", want: http.StatusBadRequest},
+		{name: "other cat's mission", principal: otherCat, path: "/api/missions/10/assignments", want: http.StatusForbidden},
+		{name: "other cat's history", principal: otherCat, path: "/api/cats/1/assignments", want: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			newAssignmentsRouter(tt.principal).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if rec.Code != tt.want {
+				t.Fatalf("GET %s: got status %d, want %d: %s", tt.path, rec.Code, tt.want, rec.Body)
+			}
+			if rec.Code != http.StatusOK {
+				return
+			}
+
+			var assignments []model.Assignment
+			if err := json.Unmarshal(rec.Body.Bytes(), &assignments); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(assignments) != 1 || assignments[0].MissionID != 10 || assignments[0].CatID != 1 {
+				t.Fatalf("unexpected assignments %+v", assignments)
+			}
+		})
+	}
+}
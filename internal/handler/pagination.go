@@ -0,0 +1,19 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// nextPageLink returns the URL of the following page, keeping every other query parameter of the request
+func nextPageLink(ctx *gin.Context, cursor string) string {
+	if cursor == "" {
+		return ""
+	}
+
+	next := *ctx.Request.URL
+	query := next.Query()
+	query.Set("cursor", cursor)
+	next.RawQuery = query.Encode()
+
+	return next.RequestURI()
+}
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"SpyCatAgency/internal/bulk"
+	"SpyCatAgency/internal/model"
+	"SpyCatAgency/internal/service"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PayrollHandler struct {
+	service *service.PayrollService
+}
+
+func NewPayrollHandler(service *service.PayrollService) *PayrollHandler {
+	return &PayrollHandler{service: service}
+}
+
+func (h *PayrollHandler) RegisterRoutes(router gin.IRouter) {
+	runs := router.Group("/api/payroll/runs")
+	{
+		runs.POST("", h.Run)
+		runs.GET("", h.List)
+		runs.GET("/:id", h.GetByID)
+		runs.GET("/:id/export", h.Export)
+	}
+}
+
+// @Summary Run payroll
+// @Description Compute and store the pay of every cat for a month that has ended. Salaries are monthly and
+// @Description prorated by day from the salary history. Each month can be run once. Admins only
+// @Tags Payroll
+// @Accept json
+// @Produce json
+// @Param body body model.PayrollRunCreate true "Month to pay"
+// @Param Idempotency-Key header string false "Unique key making retries of this request safe"
+// @Success 201 {object} model.PayrollRun
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 409 {object} map[string]string "Payroll for the month has already been run"
+// @Failure 422 {object} map[string]string "The month has not ended"
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Failure 403 {object} map[string]string "Not allowed for the caller's role"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/payroll/runs [post]
+func (h *PayrollHandler) Run(ctx *gin.Context) {
+	var create model.PayrollRunCreate
+	if err := ctx.ShouldBindJSON(&create); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	run, err := h.service.Run(ctx.Request.Context(), create)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, run)
+}
+
+// @Summary List payroll runs
+// @Description Payroll runs without their lines, latest month first. Admins only
+// @Tags Payroll
+// @Produce json
+// @Success 200 {array} model.PayrollRun
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Failure 403 {object} map[string]string "Not allowed for the caller's role"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/payroll/runs [get]
+func (h *PayrollHandler) List(ctx *gin.Context) {
+	runs, err := h.service.List(ctx.Request.Context())
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, runs)
+}
+
+// @Summary Get a payroll run
+// @Description A payroll run with the pay of every cat. Admins only
+// @Tags Payroll
+// @Produce json
+// @Param id path int true "Payroll run ID"
+// @Success 200 {object} model.PayrollRun
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 404 {object} map[string]string "Payroll run not found"
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Failure 403 {object} map[string]string "Not allowed for the caller's role"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/payroll/runs/{id} [get]
+func (h *PayrollHandler) GetByID(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	run, err := h.service.GetByID(ctx.Request.Context(), uint(id))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, run)
+}
+
+// @Summary Export a payroll run
+// @Description Download the lines of a payroll run as CSV: period, cat_id, cat_name, days_paid, amount. Admins only
+// @Tags Payroll
+// @Produce text/csv
+// @Param id path int true "Payroll run ID"
+// @Success 200 {string} string "CSV file"
+// @Header 200 {string} Content-Disposition "attachment; filename=payroll-YYYY-MM.csv"
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 404 {object} map[string]string "Payroll run not found"
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Failure 403 {object} map[string]string "Not allowed for the caller's role"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/payroll/runs/{id}/export [get]
+func (h *PayrollHandler) Export(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	run, err := h.service.GetByID(ctx.Request.Context(), uint(id))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	writeExport(ctx, fmt.Sprintf("payroll-%s", run.Period), "csv", nil, func(w io.Writer) error {
+		return bulk.WritePayrollCSV(w, run)
+	})
+}
@@ -0,0 +1,32 @@
+package handler
+
+import "github.com/gin-gonic/gin"
+
+// Handlers are all handlers of the HTTP API
+type Handlers struct {
+	Auth    *AuthHandler
+	Cat     *CatHandler
+	Mission *MissionHandler
+	Audit   *AuditHandler
+	User    *UserHandler
+	APIKey  *APIKeyHandler
+	Payroll *PayrollHandler
+	Webhook *WebhookHandler
+	Event   *EventHandler
+	GraphQL *GraphQLHandler
+}
+
+// RegisterRoutes registers every route of the API: login on the public router, everything else on the
+// router that requires a valid token or API key
+func (h *Handlers) RegisterRoutes(public, api gin.IRouter) {
+	h.Auth.RegisterRoutes(public)
+	h.Cat.RegisterRoutes(api)
+	h.Mission.RegisterRoutes(api)
+	h.Audit.RegisterRoutes(api)
+	h.User.RegisterRoutes(api)
+	h.APIKey.RegisterRoutes(api)
+	h.Payroll.RegisterRoutes(api)
+	h.Webhook.RegisterRoutes(api)
+	h.Event.RegisterRoutes(api)
+	h.GraphQL.RegisterRoutes(api)
+}
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"SpyCatAgency/internal/model"
+	"SpyCatAgency/internal/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UserHandler struct {
+	service *service.AuthService
+}
+
+func NewUserHandler(service *service.AuthService) *UserHandler {
+	return &UserHandler{service: service}
+}
+
+func (h *UserHandler) RegisterRoutes(router gin.IRouter) {
+	router.POST("/api/users", h.Create)
+}
+
+// @Summary Create a user account
+// @Description Create an admin, handler or cat account. Cat accounts act as the cat given by cat_id. Admins only
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param body body model.UserCreate true "User create body"
+// @Success 201 {object} model.User
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Failure 403 {object} map[string]string "Not allowed for the caller's role"
+// @Failure 404 {object} map[string]string "Cat not found"
+// @Failure 409 {object} map[string]string "Username already taken"
+// @Failure 422 {object} map[string]string "Invalid role or cat_id combination"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/users [post]
+func (h *UserHandler) Create(ctx *gin.Context) {
+	var create model.UserCreate
+	if err := ctx.ShouldBindJSON(&create); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.service.CreateUser(ctx.Request.Context(), create)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, user)
+}
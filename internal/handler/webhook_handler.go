@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"SpyCatAgency/internal/model"
+	"SpyCatAgency/internal/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookHandler struct {
+	service *service.WebhookService
+}
+
+func NewWebhookHandler(service *service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{service: service}
+}
+
+func (h *WebhookHandler) RegisterRoutes(router gin.IRouter) {
+	webhooks := router.Group("/api/webhooks")
+	{
+		webhooks.POST("", h.Create)
+		webhooks.GET("", h.List)
+		webhooks.DELETE("/:id", h.Delete)
+		webhooks.GET("/deliveries", h.ListDeliveries)
+		webhooks.POST("/deliveries/:id/retry", h.Retry)
+	}
+}
+
+// @Summary Register a webhook
+// @Description Subscribe a URL to domain events, or to every event when events is empty. Deliveries are POSTed as JSON and signed with the secret, which is only returned in this response. Admins only
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param body body model.WebhookCreate true "Webhook create body"
+// @Success 201 {object} model.WebhookSecret
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Failure 403 {object} map[string]string "Not allowed for the caller's role"
+// @Failure 422 {object} map[string]interface{} "Unknown event"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/webhooks [post]
+func (h *WebhookHandler) Create(ctx *gin.Context) {
+	var create model.WebhookCreate
+	if err := ctx.ShouldBindJSON(&create); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	webhook, err := h.service.Create(ctx.Request.Context(), create)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.Header("Cache-Control", "no-store")
+	ctx.JSON(http.StatusCreated, webhook)
+}
+
+// @Summary List webhooks
+// @Description List the registered webhooks. Admins only
+// @Tags Webhooks
+// @Produce json
+// @Success 200 {array} model.Webhook
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Failure 403 {object} map[string]string "Not allowed for the caller's role"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/webhooks [get]
+func (h *WebhookHandler) List(ctx *gin.Context) {
+	webhooks, err := h.service.List(ctx.Request.Context())
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, webhooks)
+}
+
+// @Summary Delete a webhook
+// @Description Stop delivering events to a webhook, including pending retries. Admins only
+// @Tags Webhooks
+// @Produce plain
+// @Param id path int true "Webhook ID"
+// @Success 204 {string} string "No content"
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Failure 403 {object} map[string]string "Not allowed for the caller's role"
+// @Failure 404 {object} map[string]string "Webhook not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/webhooks/{id} [delete]
+func (h *WebhookHandler) Delete(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	if err := h.service.Delete(ctx.Request.Context(), uint(id)); err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// @Summary List webhook deliveries
+// @Description Get a page of deliveries of events to webhooks. Use status=dead for the dead-letter list of deliveries that failed every attempt. Admins only
+// @Tags Webhooks
+// @Produce json
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Sort column: id, next_attempt_at, created_at; prefix with - for descending"
+// @Param status query string false "Delivery status" Enums(pending, delivered, dead)
+// @Param webhook_id query int false "Webhook ID"
+// @Success 200 {object} model.WebhookDeliveryPage
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Failure 403 {object} map[string]string "Not allowed for the caller's role"
+// @Failure 422 {object} map[string]string "Invalid sort column or cursor"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/webhooks/deliveries [get]
+func (h *WebhookHandler) ListDeliveries(ctx *gin.Context) {
+	var filter model.WebhookDeliveryFilter
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := h.service.ListDeliveries(ctx.Request.Context(), filter)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	page.Next = nextPageLink(ctx, page.NextCursor)
+	ctx.JSON(http.StatusOK, page)
+}
+
+// @Summary Retry a dead webhook delivery
+// @Description Queue a delivery from the dead-letter list again with a fresh set of attempts. Admins only
+// @Tags Webhooks
+// @Produce plain
+// @Param id path int true "Delivery ID"
+// @Success 202 {string} string "Accepted"
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Failure 403 {object} map[string]string "Not allowed for the caller's role"
+// @Failure 404 {object} map[string]string "Delivery not found"
+// @Failure 409 {object} map[string]string "Delivery is not dead"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/webhooks/deliveries/{id}/retry [post]
+func (h *WebhookHandler) Retry(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	if err := h.service.Retry(ctx.Request.Context(), id); err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.Status(http.StatusAccepted)
+}
@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"SpyCatAgency/internal/apperror"
+	"SpyCatAgency/internal/model"
+	"SpyCatAgency/internal/repository"
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+// apiKeyPrefixIndex keeps key prefixes unique so a key can be looked up by its prefix
+const apiKeyPrefixIndex = "api_keys_prefix_key"
+
+// apiKeyColumns are the columns scanned by scanAPIKey, in order
+const apiKeyColumns = `id, name, prefix, key_hash, scopes, created_by, expires_at, last_used_at, revoked_at, created_at, updated_at`
+
+type APIKeyRepository struct {
+	db *sql.DB
+}
+
+func NewAPIKeyRepository(db *sql.DB) repository.APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+func (r *APIKeyRepository) Create(ctx context.Context, key *model.APIKey) error {
+	query := `
+		INSERT INTO api_keys (name, prefix, key_hash, scopes, created_by, expires_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW())
+		RETURNING id, created_at, updated_at`
+
+	err := conn(ctx, r.db).QueryRowContext(
+		ctx, query,
+		key.Name,
+		key.Prefix,
+		key.KeyHash,
+		pq.Array(key.Scopes),
+		key.CreatedBy,
+		key.ExpiresAt,
+	).Scan(&key.ID, &key.CreatedAt, &key.UpdatedAt)
+	if isUniqueViolation(err, apiKeyPrefixIndex) {
+		return apperror.Conflict("API key prefix collision, try again")
+	}
+	return err
+}
+
+func (r *APIKeyRepository) GetByID(ctx context.Context, id uint) (*model.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE id = $1`
+
+	key, err := scanAPIKey(conn(ctx, r.db).QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apperror.NotFound("API key %d not found", id)
+	}
+	return key, err
+}
+
+func (r *APIKeyRepository) GetByPrefix(ctx context.Context, prefix string) (*model.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE prefix = $1`
+
+	key, err := scanAPIKey(conn(ctx, r.db).QueryRowContext(ctx, query, prefix))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apperror.NotFound("API key not found")
+	}
+	return key, err
+}
+
+func (r *APIKeyRepository) List(ctx context.Context) ([]model.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys ORDER BY id`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := []model.APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, *key)
+	}
+	return keys, rows.Err()
+}
+
+// Rotate replaces the prefix and hash of a key that has not been revoked
+func (r *APIKeyRepository) Rotate(ctx context.Context, key *model.APIKey) error {
+	query := `
+		UPDATE api_keys
+		SET prefix = $1, key_hash = $2, last_used_at = NULL, updated_at = NOW()
+		WHERE id = $3 AND revoked_at IS NULL
+		RETURNING updated_at`
+
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, key.Prefix, key.KeyHash, key.ID).Scan(&key.UpdatedAt)
+	if isUniqueViolation(err, apiKeyPrefixIndex) {
+		return apperror.Conflict("API key prefix collision, try again")
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return apperror.Conflict("API key %d is revoked", key.ID)
+	}
+	if err != nil {
+		return err
+	}
+	key.LastUsedAt = nil
+	return nil
+}
+
+func (r *APIKeyRepository) Revoke(ctx context.Context, id uint) error {
+	query := `
+		UPDATE api_keys
+		SET revoked_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND revoked_at IS NULL`
+
+	res, err := conn(ctx, r.db).ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	n, err := affected(res)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return apperror.Conflict("API key %d is already revoked", id)
+	}
+	return nil
+}
+
+// TouchLastUsed records that the key was used. It writes at most once a minute per key.
+func (r *APIKeyRepository) TouchLastUsed(ctx context.Context, id uint) error {
+	query := `
+		UPDATE api_keys
+		SET last_used_at = NOW()
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute')`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, id)
+	return err
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanAPIKey(row rowScanner) (*model.APIKey, error) {
+	key := &model.APIKey{}
+	err := row.Scan(
+		&key.ID,
+		&key.Name,
+		&key.Prefix,
+		&key.KeyHash,
+		pq.Array(&key.Scopes),
+		&key.CreatedBy,
+		&key.ExpiresAt,
+		&key.LastUsedAt,
+		&key.RevokedAt,
+		&key.CreatedAt,
+		&key.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"SpyCatAgency/internal/model"
+	"SpyCatAgency/internal/repository"
+	"context"
+	"database/sql"
+)
+
+type AssignmentRepository struct {
+	db *sql.DB
+}
+
+func NewAssignmentRepository(db *sql.DB) repository.AssignmentRepository {
+	return &AssignmentRepository{db: db}
+}
+
+func (r *AssignmentRepository) Open(ctx context.Context, assignment *model.Assignment) error {
+	query := `
+		INSERT INTO mission_assignments (mission_id, cat_id, assigned_at)
+		VALUES ($1, $2, NOW())
+		RETURNING id, assigned_at`
+
+	return conn(ctx, r.db).QueryRowContext(ctx, query, assignment.MissionID, assignment.CatID).
+		Scan(&assignment.ID, &assignment.AssignedAt)
+}
+
+// Release closes the open assignment of the mission, if there is one
+func (r *AssignmentRepository) Release(ctx context.Context, missionID uint, reason string) error {
+	query := `
+		UPDATE mission_assignments
+		SET released_at = NOW(), reason = $2
+		WHERE mission_id = $1 AND released_at IS NULL`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, missionID, reason)
+	return err
+}
+
+func (r *AssignmentRepository) ListByMissionID(ctx context.Context, missionID uint) ([]model.Assignment, error) {
+	query := `
+		SELECT id, mission_id, cat_id, assigned_at, released_at, COALESCE(reason, '')
+		FROM mission_assignments
+		WHERE mission_id = $1
+		ORDER BY assigned_at, id`
+
+	return r.list(ctx, query, missionID)
+}
+
+func (r *AssignmentRepository) ListByCatID(ctx context.Context, catID uint) ([]model.Assignment, error) {
+	query := `
+		SELECT id, mission_id, cat_id, assigned_at, released_at, COALESCE(reason, '')
+		FROM mission_assignments
+		WHERE cat_id = $1
+		ORDER BY assigned_at, id`
+
+	return r.list(ctx, query, catID)
+}
+
+func (r *AssignmentRepository) list(ctx context.Context, query string, id uint) ([]model.Assignment, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	assignments := []model.Assignment{}
+	for rows.Next() {
+		var assignment model.Assignment
+		if err := rows.Scan(
+			&assignment.ID,
+			&assignment.MissionID,
+			&assignment.CatID,
+			&assignment.AssignedAt,
+			&assignment.ReleasedAt,
+			&assignment.Reason,
+		); err != nil {
+			return nil, err
+		}
+		assignments = append(assignments, assignment)
+	}
+	return assignments, rows.Err()
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"SpyCatAgency/internal/model"
+	"SpyCatAgency/internal/repository"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+type AuditRepository struct {
+	db *sql.DB
+}
+
+func NewAuditRepository(db *sql.DB) repository.AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+func (r *AuditRepository) Create(ctx context.Context, entry *model.AuditEntry) error {
+	query := `
+		INSERT INTO audit_log (request_id, actor, action, entity, entity_id, before, after, changes, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
+		RETURNING id, created_at`
+
+	var changes []byte
+	if len(entry.Changes) > 0 {
+		var err error
+		if changes, err = json.Marshal(entry.Changes); err != nil {
+			return fmt.Errorf("failed to encode audit changes: %w", err)
+		}
+	}
+
+	return conn(ctx, r.db).QueryRowContext(
+		ctx, query,
+		entry.RequestID,
+		entry.Actor,
+		entry.Action,
+		entry.Entity,
+		entry.EntityID,
+		nullableJSON(entry.Before),
+		nullableJSON(entry.After),
+		nullableJSON(changes),
+	).Scan(&entry.ID, &entry.CreatedAt)
+}
+
+// auditSortColumns are the columns GET /api/audit can be sorted by
+var auditSortColumns = map[string]sortColumn{
+	"id":         {name: "id", sqlType: "bigint"},
+	"created_at": {name: "created_at", sqlType: "timestamp"},
+}
+
+func (r *AuditRepository) List(ctx context.Context, filter model.AuditFilter) (*model.AuditPage, error) {
+	q, err := newListQuery(filter.ListParams, auditSortColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	if filter.Entity != "" {
+		q.where("entity = " + q.arg(filter.Entity))
+	}
+	if filter.EntityID != nil {
+		q.where("entity_id = " + q.arg(*filter.EntityID))
+	}
+	if filter.Action != "" {
+		q.where("action = " + q.arg(filter.Action))
+	}
+	if filter.Actor != "" {
+		q.where("actor = " + q.arg(filter.Actor))
+	}
+	if filter.RequestID != "" {
+		q.where("request_id = " + q.arg(filter.RequestID))
+	}
+	if filter.From != nil {
+		q.where("created_at >= " + q.arg(filter.From.UTC()))
+	}
+	if filter.To != nil {
+		q.where("created_at < " + q.arg(filter.To.UTC()))
+	}
+
+	page := &model.AuditPage{Items: []model.AuditEntry{}}
+
+	countQuery, countArgs := q.countSQL("audit_log")
+	if err := conn(ctx, r.db).QueryRowContext(ctx, countQuery, countArgs...).Scan(&page.Total); err != nil {
+		return nil, err
+	}
+
+	query, args := q.pageSQL("audit_log", "id, request_id, actor, action, entity, entity_id, before, after, changes, created_at")
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var entry model.AuditEntry
+		var before, after, changes []byte
+		var key string
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.RequestID,
+			&entry.Actor,
+			&entry.Action,
+			&entry.Entity,
+			&entry.EntityID,
+			&before,
+			&after,
+			&changes,
+			&entry.CreatedAt,
+			&key,
+		); err != nil {
+			return nil, err
+		}
+		entry.Before = before
+		entry.After = after
+		if changes != nil {
+			if err := json.Unmarshal(changes, &entry.Changes); err != nil {
+				return nil, fmt.Errorf("failed to decode audit changes: %w", err)
+			}
+		}
+		page.Items = append(page.Items, entry)
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	page.Items, page.NextCursor = nextCursor(q, page.Items, keys, func(e model.AuditEntry) uint { return e.ID })
+	return page, nil
+}
+
+// nullableJSON stores an empty document as NULL
+func nullableJSON(doc []byte) any {
+	if len(doc) == 0 {
+		return nil
+	}
+	return string(doc)
+}
@@ -1,12 +1,13 @@
 package repository
 
 import (
+	"SpyCatAgency/internal/apperror"
 	"SpyCatAgency/internal/model"
 	"SpyCatAgency/internal/repository"
 	"context"
 	"database/sql"
 	"errors"
-	"fmt"
+	"time"
 )
 
 type CatRepository struct {
@@ -19,53 +20,100 @@ func NewCatRepository(db *sql.DB) repository.CatRepository {
 
 func (r *CatRepository) Create(ctx context.Context, cat *model.Cat) error {
 	query := `
-		INSERT INTO cats (name, years_experience, breed, salary, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, NOW(), NOW())
-		RETURNING id, created_at, updated_at`
+		INSERT INTO cats (name, years_experience, breed, salary, breed_verified, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
+		RETURNING id, version, created_at, updated_at`
 
-	return r.db.QueryRowContext(
+	return conn(ctx, r.db).QueryRowContext(
 		ctx, query,
 		cat.Name,
 		cat.YearsExperience,
 		cat.Breed,
 		cat.Salary,
-	).Scan(&cat.ID, &cat.CreatedAt, &cat.UpdatedAt)
+		cat.BreedVerified,
+	).Scan(&cat.ID, &cat.Version, &cat.CreatedAt, &cat.UpdatedAt)
 }
 
+// Update saves the cat if it still has the version it was read with, and bumps the version
 func (r *CatRepository) Update(ctx context.Context, cat *model.Cat) error {
 	query := `
 		UPDATE cats
-		SET salary = $1, updated_at = NOW()
-		WHERE id = $2
-		RETURNING updated_at`
+		SET salary = $1, version = version + 1, updated_at = NOW()
+		WHERE id = $2 AND version = $3 AND deleted_at IS NULL
+		RETURNING version, updated_at`
 
-	return r.db.QueryRowContext(ctx, query, cat.Salary, cat.ID).Scan(&cat.UpdatedAt)
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, cat.Salary, cat.ID, cat.Version).Scan(&cat.Version, &cat.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return missingOrStale(ctx, r.db, "cats", "cat", cat.ID)
+	}
+	return err
 }
 
-func (r *CatRepository) Delete(ctx context.Context, id uint) error {
-	query := `DELETE FROM cats WHERE id = $1`
-	_, err := r.db.ExecContext(ctx, query, id)
-	return err
+// Delete soft-deletes the cat unless it is working on an active mission.
+// A non-zero version makes the delete conditional on the current version.
+// The cat row is locked for the rest of the transaction before the check, so a mission cannot be given
+// the cat in between: saving an active mission locks its cat too.
+func (r *CatRepository) Delete(ctx context.Context, id uint, version int) error {
+	var locked uint
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT id FROM cats WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, id).Scan(&locked)
+	if errors.Is(err, sql.ErrNoRows) {
+		return apperror.NotFound("cat %d not found", id)
+	}
+	if err != nil {
+		return err
+	}
+
+	query := `
+		SELECT id FROM missions
+		WHERE cat_id = $1 AND status IN ('assigned', 'in_progress') AND deleted_at IS NULL
+		LIMIT 1`
+
+	var missionID uint
+	err = conn(ctx, r.db).QueryRowContext(ctx, query, id).Scan(&missionID)
+	if err == nil {
+		return apperror.Conflict("cat %d is working on active mission %d", id, missionID).
+			WithDetail("mission_id", missionID)
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	return softDelete(ctx, r.db, "cats", "cat", id, version)
+}
+
+func (r *CatRepository) Restore(ctx context.Context, id uint) error {
+	return restore(ctx, r.db, "cats", "cat", id)
+}
+
+// Purge removes cats deleted longer than the retention ago that no mission or user account refers to anymore
+func (r *CatRepository) Purge(ctx context.Context, retention time.Duration) (int64, error) {
+	return purge(ctx, r.db, "cats", `
+		NOT EXISTS (SELECT 1 FROM missions m WHERE m.cat_id = cats.id)
+		AND NOT EXISTS (SELECT 1 FROM users u WHERE u.cat_id = cats.id)`, retention)
 }
 
 func (r *CatRepository) GetByID(ctx context.Context, id uint) (*model.Cat, error) {
 	query := `
-		SELECT id, name, years_experience, breed, salary, created_at, updated_at
+		SELECT id, name, years_experience, breed, salary, breed_verified, version, created_at, updated_at, deleted_at
 		FROM cats
-		WHERE id = $1`
+		WHERE id = $1 AND ` + liveOnly(ctx)
 
 	cat := &model.Cat{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, id).Scan(
 		&cat.ID,
 		&cat.Name,
 		&cat.YearsExperience,
 		&cat.Breed,
 		&cat.Salary,
+		&cat.BreedVerified,
+		&cat.Version,
 		&cat.CreatedAt,
 		&cat.UpdatedAt,
+		&cat.DeletedAt,
 	)
 	if errors.Is(err, sql.ErrNoRows) {
-		return nil, fmt.Errorf("cat not found")
+		return nil, apperror.NotFound("cat %d not found", id)
 	}
 	if err != nil {
 		return nil, err
@@ -73,13 +121,15 @@ func (r *CatRepository) GetByID(ctx context.Context, id uint) (*model.Cat, error
 	return cat, nil
 }
 
-func (r *CatRepository) List(ctx context.Context) ([]model.Cat, error) {
+// ListByIDs returns the cats with the given ids in one query. Unknown ids are skipped.
+func (r *CatRepository) ListByIDs(ctx context.Context, ids []uint) ([]model.Cat, error) {
 	query := `
-		SELECT id, name, years_experience, breed, salary, created_at, updated_at
+		SELECT id, name, years_experience, breed, salary, breed_verified, version, created_at, updated_at, deleted_at
 		FROM cats
+		WHERE id = ANY($1) AND ` + liveOnly(ctx) + `
 		ORDER BY id`
 
-	rows, err := r.db.QueryContext(ctx, query)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, idArray(ids))
 	if err != nil {
 		return nil, err
 	}
@@ -94,8 +144,11 @@ func (r *CatRepository) List(ctx context.Context) ([]model.Cat, error) {
 			&cat.YearsExperience,
 			&cat.Breed,
 			&cat.Salary,
+			&cat.BreedVerified,
+			&cat.Version,
 			&cat.CreatedAt,
 			&cat.UpdatedAt,
+			&cat.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -103,3 +156,81 @@ func (r *CatRepository) List(ctx context.Context) ([]model.Cat, error) {
 	}
 	return cats, rows.Err()
 }
+
+// catSortColumns are the columns GET /api/cats/list can be sorted by
+var catSortColumns = map[string]sortColumn{
+	"id":               {name: "id", sqlType: "integer"},
+	"name":             {name: "name", sqlType: "text"},
+	"years_experience": {name: "years_experience", sqlType: "integer"},
+	"salary":           {name: "salary", sqlType: "numeric"},
+	"created_at":       {name: "created_at", sqlType: "timestamp"},
+}
+
+func (r *CatRepository) List(ctx context.Context, filter model.CatFilter) (*model.CatPage, error) {
+	q, err := newListQuery(filter.ListParams, catSortColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	if !repository.DeletedIncluded(ctx) {
+		q.where("deleted_at IS NULL")
+	}
+	if filter.Breed != "" {
+		q.where("LOWER(breed) = LOWER(" + q.arg(filter.Breed) + ")")
+	}
+	if filter.MinExperience != nil {
+		q.where("years_experience >= " + q.arg(*filter.MinExperience))
+	}
+	if filter.MaxExperience != nil {
+		q.where("years_experience <= " + q.arg(*filter.MaxExperience))
+	}
+	if filter.MinSalary != nil {
+		q.where("salary >= " + q.arg(*filter.MinSalary))
+	}
+	if filter.MaxSalary != nil {
+		q.where("salary <= " + q.arg(*filter.MaxSalary))
+	}
+
+	page := &model.CatPage{Items: []model.Cat{}}
+
+	countQuery, countArgs := q.countSQL("cats")
+	if err := conn(ctx, r.db).QueryRowContext(ctx, countQuery, countArgs...).Scan(&page.Total); err != nil {
+		return nil, err
+	}
+
+	query, args := q.pageSQL("cats", "id, name, years_experience, breed, salary, breed_verified, version, created_at, updated_at, deleted_at")
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var cat model.Cat
+		var key string
+		if err := rows.Scan(
+			&cat.ID,
+			&cat.Name,
+			&cat.YearsExperience,
+			&cat.Breed,
+			&cat.Salary,
+			&cat.BreedVerified,
+			&cat.Version,
+			&cat.CreatedAt,
+			&cat.UpdatedAt,
+			&cat.DeletedAt,
+			&key,
+		); err != nil {
+			return nil, err
+		}
+		page.Items = append(page.Items, cat)
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	page.Items, page.NextCursor = nextCursor(q, page.Items, keys, func(c model.Cat) uint { return c.ID })
+	return page, nil
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"SpyCatAgency/internal/apperror"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// uniqueViolation is the PostgreSQL error code for unique_violation
+const uniqueViolation = "23505"
+
+// isUniqueViolation reports whether err was caused by the given unique constraint or index
+func isUniqueViolation(err error, constraint string) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolation && pqErr.Constraint == constraint
+}
+
+// affected returns the number of rows changed by an update or delete
+func affected(res sql.Result) (int64, error) {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to retrieve affected rows: %w", err)
+	}
+	return n, nil
+}
+
+// missingOrStale explains why a versioned update or delete matched no rows: the row is gone,
+// or it was changed by someone else since it was read
+func missingOrStale(ctx context.Context, db *sql.DB, table, entity string, id uint) error {
+	var exists bool
+	query := fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM %s WHERE id = $1 AND deleted_at IS NULL)`, table)
+	if err := conn(ctx, db).QueryRowContext(ctx, query, id).Scan(&exists); err != nil {
+		return err
+	}
+
+	if !exists {
+		return apperror.NotFound("%s %d not found", entity, id)
+	}
+	return apperror.PreconditionFailed("%s %d was modified by another request", entity, id)
+}
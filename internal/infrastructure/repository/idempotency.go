@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"SpyCatAgency/internal/model"
+	"SpyCatAgency/internal/repository"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type IdempotencyRepository struct {
+	db *sql.DB
+}
+
+func NewIdempotencyRepository(db *sql.DB) repository.IdempotencyRepository {
+	return &IdempotencyRepository{db: db}
+}
+
+// Claim returns nil if the key was claimed for the record, or the record already holding the key
+func (r *IdempotencyRepository) Claim(
+	ctx context.Context,
+	record *model.IdempotencyRecord,
+	window, abandonAfter time.Duration,
+) (*model.IdempotencyRecord, error) {
+	query := `
+		INSERT INTO idempotency_keys (principal, key, request_hash, created_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (principal, key) DO UPDATE
+		SET request_hash = EXCLUDED.request_hash,
+			status_code = NULL,
+			response_headers = NULL,
+			response_body = NULL,
+			created_at = NOW(),
+			completed_at = NULL
+		WHERE idempotency_keys.created_at < NOW() - make_interval(secs => $4)
+			OR (idempotency_keys.completed_at IS NULL AND idempotency_keys.created_at < NOW() - make_interval(secs => $5))
+		RETURNING created_at`
+
+	err := conn(ctx, r.db).QueryRowContext(
+		ctx, query,
+		record.Principal,
+		record.Key,
+		record.RequestHash,
+		window.Seconds(),
+		abandonAfter.Seconds(),
+	).Scan(&record.CreatedAt)
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	return r.get(ctx, record.Principal, record.Key)
+}
+
+func (r *IdempotencyRepository) get(ctx context.Context, principal, key string) (*model.IdempotencyRecord, error) {
+	query := `
+		SELECT principal, key, request_hash, COALESCE(status_code, 0), response_headers, response_body, created_at, completed_at
+		FROM idempotency_keys
+		WHERE principal = $1 AND key = $2`
+
+	record := &model.IdempotencyRecord{}
+	var headers []byte
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, principal, key).Scan(
+		&record.Principal,
+		&record.Key,
+		&record.RequestHash,
+		&record.StatusCode,
+		&headers,
+		&record.Body,
+		&record.CreatedAt,
+		&record.CompletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if headers != nil {
+		if err := json.Unmarshal(headers, &record.Headers); err != nil {
+			return nil, fmt.Errorf("failed to decode stored response headers: %w", err)
+		}
+	}
+	return record, nil
+}
+
+// Complete stores the response of the request holding the key, identified by the time it claimed it
+func (r *IdempotencyRepository) Complete(ctx context.Context, record *model.IdempotencyRecord) error {
+	query := `
+		UPDATE idempotency_keys
+		SET status_code = $1, response_headers = $2, response_body = $3, completed_at = NOW()
+		WHERE principal = $4 AND key = $5 AND request_hash = $6 AND created_at = $7 AND completed_at IS NULL`
+
+	headers, err := json.Marshal(record.Headers)
+	if err != nil {
+		return fmt.Errorf("failed to encode response headers: %w", err)
+	}
+
+	_, err = conn(ctx, r.db).ExecContext(
+		ctx, query,
+		record.StatusCode,
+		string(headers),
+		record.Body,
+		record.Principal,
+		record.Key,
+		record.RequestHash,
+		record.CreatedAt,
+	)
+	return err
+}
+
+// Release frees a key whose request did not complete, so it can be retried, unless another request claimed it since
+func (r *IdempotencyRepository) Release(ctx context.Context, claim *model.IdempotencyRecord) error {
+	query := `DELETE FROM idempotency_keys WHERE principal = $1 AND key = $2 AND created_at = $3 AND completed_at IS NULL`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, claim.Principal, claim.Key, claim.CreatedAt)
+	return err
+}
+
+func (r *IdempotencyRepository) DeleteExpired(ctx context.Context, window time.Duration) (int64, error) {
+	query := `DELETE FROM idempotency_keys WHERE created_at < NOW() - make_interval(secs => $1)`
+
+	res, err := conn(ctx, r.db).ExecContext(ctx, query, window.Seconds())
+	if err != nil {
+		return 0, err
+	}
+	return affected(res)
+}
@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"SpyCatAgency/internal/apperror"
+	"SpyCatAgency/internal/model"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// sortColumn is a column a list can be ordered by, with the SQL type used to compare cursor values
+type sortColumn struct {
+	name    string
+	sqlType string
+}
+
+// cursor marks the position of the last row of a page: its sort value and id
+type cursor struct {
+	Value string `json:"v"`
+	ID    uint   `json:"id"`
+}
+
+func encodeCursor(c cursor) string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(s string) (*cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, apperror.Validation("invalid cursor")
+	}
+
+	var c cursor
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return nil, apperror.Validation("invalid cursor")
+	}
+	return &c, nil
+}
+
+// listQuery builds a filtered, keyset-paginated SELECT for a single table
+type listQuery struct {
+	conds []string
+	args  []any
+
+	sort   sortColumn
+	desc   bool
+	limit  int
+	cursor *cursor
+}
+
+// newListQuery validates the sort and cursor parameters against the sortable columns of a table
+func newListQuery(params model.ListParams, columns map[string]sortColumn) (*listQuery, error) {
+	q := &listQuery{
+		sort:  sortColumn{name: "id", sqlType: "integer"},
+		limit: params.Limit,
+	}
+	if q.limit <= 0 {
+		q.limit = model.DefaultPageLimit
+	}
+
+	if params.Sort != "" {
+		name := strings.TrimPrefix(params.Sort, "-")
+		col, ok := columns[name]
+		if !ok {
+			return nil, apperror.Validation("cannot sort by %q", name)
+		}
+		q.sort = col
+		q.desc = strings.HasPrefix(params.Sort, "-")
+	}
+
+	if params.Cursor != "" {
+		c, err := decodeCursor(params.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		if !validSortValue(q.sort.sqlType, c.Value) || !validSortValue(columns["id"].sqlType, strconv.FormatUint(uint64(c.ID), 10)) {
+			return nil, apperror.Validation("invalid cursor")
+		}
+		q.cursor = c
+	}
+
+	return q, nil
+}
+
+// timestampLayouts are the text forms of timestamp and timestamptz values selected by pageSQL
+var timestampLayouts = []string{"2006-01-02 15:04:05.999999", "2006-01-02 15:04:05.999999-07", "2006-01-02 15:04:05.999999-07:00"}
+
+var numericValue = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+
+// validSortValue reports whether a cursor value can be cast to the SQL type of its sort column. Cursors come
+// from clients, so a tampered one must be rejected before Postgres fails the query on the cast.
+func validSortValue(sqlType, v string) bool {
+	switch sqlType {
+	case "integer":
+		_, err := strconv.ParseInt(v, 10, 32)
+		return err == nil
+	case "bigint":
+		_, err := strconv.ParseInt(v, 10, 64)
+		return err == nil
+	case "numeric":
+		return numericValue.MatchString(v)
+	case "timestamp", "timestamptz":
+		for _, layout := range timestampLayouts {
+			if _, err := time.Parse(layout, v); err == nil {
+				return true
+			}
+		}
+		return false
+	default:
+		return utf8.ValidString(v) && !strings.ContainsRune(v, 0)
+	}
+}
+
+// arg registers a positional argument and returns its placeholder
+func (q *listQuery) arg(v any) string {
+	q.args = append(q.args, v)
+	return fmt.Sprintf("$%d", len(q.args))
+}
+
+// where adds a filter condition; placeholders must come from arg
+func (q *listQuery) where(cond string) {
+	q.conds = append(q.conds, cond)
+}
+
+func (q *listQuery) whereClause() string {
+	if len(q.conds) == 0 {
+		return ""
+	}
+	return "WHERE " + strings.Join(q.conds, " AND ")
+}
+
+// countSQL returns the query counting every row that matches the filters
+func (q *listQuery) countSQL(table string) (string, []any) {
+	return fmt.Sprintf("SELECT COUNT(*) FROM %s %s", table, q.whereClause()), q.args
+}
+
+// pageSQL returns the query selecting one page of rows. The sort key is selected as
+// the last column so the next cursor can be built from the final row.
+// One extra row is fetched to detect whether another page exists.
+func (q *listQuery) pageSQL(table, columns string) (string, []any) {
+	page := &listQuery{conds: slices.Clone(q.conds), args: slices.Clone(q.args)}
+
+	op, dir := ">", "ASC"
+	if q.desc {
+		op, dir = "<", "DESC"
+	}
+
+	if q.cursor != nil {
+		page.where(fmt.Sprintf("(%s, id) %s (%s::%s, %s)",
+			q.sort.name, op, page.arg(q.cursor.Value), q.sort.sqlType, page.arg(q.cursor.ID)))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s, %s::text
+		FROM %s
+		%s
+		ORDER BY %s %s, id %s
+		LIMIT %d`,
+		columns, q.sort.name, table, page.whereClause(), q.sort.name, dir, dir, q.limit+1)
+
+	return query, page.args
+}
+
+// nextCursor trims the extra row fetched by pageSQL and returns the cursor of the following page
+func nextCursor[T any](q *listQuery, items []T, keys []string, id func(T) uint) ([]T, string) {
+	if len(items) <= q.limit {
+		return items, ""
+	}
+
+	items = items[:q.limit]
+	last := items[len(items)-1]
+	return items, encodeCursor(cursor{Value: keys[q.limit-1], ID: id(last)})
+}
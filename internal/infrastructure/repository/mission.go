@@ -1,13 +1,20 @@
 package repository
 
 import (
+	"SpyCatAgency/internal/apperror"
 	"SpyCatAgency/internal/model"
 	"SpyCatAgency/internal/repository"
 	"context"
 	"database/sql"
-	"fmt"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
 )
 
+// activeMissionIndex guarantees that a cat has at most one assigned or in-progress mission
+const activeMissionIndex = "missions_one_active_per_cat"
+
 type MissionRepository struct {
 	db *sql.DB
 }
@@ -17,69 +24,243 @@ func NewMissionRepository(db *sql.DB) repository.MissionRepository {
 }
 
 func (r *MissionRepository) Create(ctx context.Context, mission *model.Mission) error {
+	if err := r.lockCat(ctx, mission); err != nil {
+		return err
+	}
+
 	query := `
-		INSERT INTO missions (name, cat_id, created_at, updated_at)
-		VALUES ($1, $2, NOW(), NOW())
-		RETURNING id, created_at, updated_at`
+		INSERT INTO missions (name, cat_id, status, created_at, updated_at)
+		VALUES ($1, $2, $3, NOW(), NOW())
+		RETURNING id, version, created_at, updated_at`
 
-	return r.db.QueryRowContext(
+	err := conn(ctx, r.db).QueryRowContext(
 		ctx, query,
 		mission.Name,
-		mission.CatID,
-	).Scan(&mission.ID, &mission.CreatedAt, &mission.UpdatedAt)
+		nullableID(mission.CatID),
+		mission.Status,
+	).Scan(&mission.ID, &mission.Version, &mission.CreatedAt, &mission.UpdatedAt)
+	if isUniqueViolation(err, activeMissionIndex) {
+		return repository.ErrCatUnavailable
+	}
+	return err
 }
 
+// Update saves the mission if it still has the version it was read with, and bumps the version
 func (r *MissionRepository) Update(ctx context.Context, mission *model.Mission) error {
+	if err := r.lockCat(ctx, mission); err != nil {
+		return err
+	}
+
 	query := `
 		UPDATE missions
-		SET name = $1, cat_id = $2, updated_at = NOW()
-		WHERE id = $3
-		RETURNING updated_at`
+		SET name = $1, cat_id = $2, status = $3, version = version + 1, updated_at = NOW()
+		WHERE id = $4 AND version = $5 AND deleted_at IS NULL
+		RETURNING version, updated_at`
 
-	return r.db.QueryRowContext(
+	err := conn(ctx, r.db).QueryRowContext(
 		ctx, query,
 		mission.Name,
-		mission.CatID,
+		nullableID(mission.CatID),
+		mission.Status,
 		mission.ID,
-	).Scan(&mission.UpdatedAt)
+		mission.Version,
+	).Scan(&mission.Version, &mission.UpdatedAt)
+	if isUniqueViolation(err, activeMissionIndex) {
+		return repository.ErrCatUnavailable
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return missingOrStale(ctx, r.db, "missions", "mission", mission.ID)
+	}
+	return err
 }
 
-func (r *MissionRepository) Delete(ctx context.Context, id uint) error {
-	query := `DELETE FROM missions WHERE id = $1`
-	_, err := r.db.ExecContext(ctx, query, id)
+// lockCat makes sure the cat of an active mission is not deleted and keeps it from being deleted until the
+// transaction ends. Deleting a cat locks its row as well, so the two cannot interleave.
+func (r *MissionRepository) lockCat(ctx context.Context, mission *model.Mission) error {
+	if mission.CatID == nil || mission.Status.IsFinal() || mission.Status == model.MissionStatusDraft {
+		return nil
+	}
+
+	var locked uint
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT id FROM cats WHERE id = $1 AND deleted_at IS NULL FOR SHARE`, *mission.CatID).Scan(&locked)
+	if errors.Is(err, sql.ErrNoRows) {
+		return apperror.NotFound("cat %d not found", *mission.CatID)
+	}
 	return err
 }
 
+// Delete soft-deletes the mission. A non-zero version makes the delete conditional on the current version.
+func (r *MissionRepository) Delete(ctx context.Context, id uint, version int) error {
+	return softDelete(ctx, r.db, "missions", "mission", id, version)
+}
+
+func (r *MissionRepository) Restore(ctx context.Context, id uint) error {
+	err := restore(ctx, r.db, "missions", "mission", id)
+	if isUniqueViolation(err, activeMissionIndex) {
+		return repository.ErrCatUnavailable
+	}
+	return err
+}
+
+// Purge removes missions deleted longer than the retention ago once their targets are gone
+func (r *MissionRepository) Purge(ctx context.Context, retention time.Duration) (int64, error) {
+	return purge(ctx, r.db, "missions",
+		"NOT EXISTS (SELECT 1 FROM targets t WHERE t.mission_id = missions.id)", retention)
+}
+
 func (r *MissionRepository) GetByID(ctx context.Context, id uint) (*model.Mission, error) {
 	query := `
-		SELECT id, name, cat_id, created_at, updated_at
+		SELECT id, name, cat_id, status, version, created_at, updated_at, deleted_at
 		FROM missions
-		WHERE id = $1`
+		WHERE id = $1 AND ` + liveOnly(ctx)
 
+	mission, err := r.get(ctx, query, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apperror.NotFound("mission %d not found", id)
+	}
+	return mission, err
+}
+
+// GetByIDForUpdate loads the mission and locks its row until the surrounding transaction ends
+func (r *MissionRepository) GetByIDForUpdate(ctx context.Context, id uint) (*model.Mission, error) {
+	query := `
+		SELECT id, name, cat_id, status, version, created_at, updated_at, deleted_at
+		FROM missions
+		WHERE id = $1 AND deleted_at IS NULL
+		FOR UPDATE`
+
+	mission, err := r.get(ctx, query, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apperror.NotFound("mission %d not found", id)
+	}
+	return mission, err
+}
+
+// FindActiveByCatID returns the assigned or in-progress mission of the cat, or nil if the cat is free
+func (r *MissionRepository) FindActiveByCatID(ctx context.Context, catID uint) (*model.Mission, error) {
+	query := `
+		SELECT id, name, cat_id, status, version, created_at, updated_at, deleted_at
+		FROM missions
+		WHERE cat_id = $1 AND status IN ('assigned', 'in_progress') AND deleted_at IS NULL
+		LIMIT 1`
+
+	mission, err := r.get(ctx, query, catID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return mission, err
+}
+
+func (r *MissionRepository) get(ctx context.Context, query string, id uint) (*model.Mission, error) {
 	mission := &model.Mission{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	var catID sql.NullInt64
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, id).Scan(
 		&mission.ID,
 		&mission.Name,
-		&mission.CatID,
+		&catID,
+		&mission.Status,
+		&mission.Version,
 		&mission.CreatedAt,
 		&mission.UpdatedAt,
+		&mission.DeletedAt,
 	)
-	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("mission not found")
-	}
 	if err != nil {
 		return nil, err
 	}
+	mission.CatID = idFromNullable(catID)
 	return mission, nil
 }
 
-func (r *MissionRepository) List(ctx context.Context) ([]model.Mission, error) {
+// missionSortColumns are the columns GET /api/missions can be sorted by
+var missionSortColumns = map[string]sortColumn{
+	"id":         {name: "id", sqlType: "integer"},
+	"name":       {name: "name", sqlType: "text"},
+	"status":     {name: "status", sqlType: "text"},
+	"created_at": {name: "created_at", sqlType: "timestamp"},
+}
+
+func (r *MissionRepository) List(ctx context.Context, filter model.MissionFilter) (*model.MissionPage, error) {
+	q, err := newListQuery(filter.ListParams, missionSortColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	if !repository.DeletedIncluded(ctx) {
+		q.where("deleted_at IS NULL")
+	}
+	if filter.Status != "" {
+		q.where("status = " + q.arg(filter.Status))
+	}
+	if filter.Completed != nil {
+		if *filter.Completed {
+			q.where("status = " + q.arg(model.MissionStatusCompleted))
+		} else {
+			q.where("status <> " + q.arg(model.MissionStatusCompleted))
+		}
+	}
+	if filter.CatID != nil {
+		q.where("cat_id = " + q.arg(*filter.CatID))
+	}
+	if filter.Country != "" {
+		q.where(`EXISTS (
+			SELECT 1 FROM targets t
+			WHERE t.mission_id = missions.id AND t.deleted_at IS NULL AND LOWER(t.country) = LOWER(` + q.arg(filter.Country) + `))`)
+	}
+
+	page := &model.MissionPage{Items: []model.Mission{}}
+
+	countQuery, countArgs := q.countSQL("missions")
+	if err := conn(ctx, r.db).QueryRowContext(ctx, countQuery, countArgs...).Scan(&page.Total); err != nil {
+		return nil, err
+	}
+
+	query, args := q.pageSQL("missions", "id, name, cat_id, status, version, created_at, updated_at, deleted_at")
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var mission model.Mission
+		var catID sql.NullInt64
+		var key string
+		if err := rows.Scan(
+			&mission.ID,
+			&mission.Name,
+			&catID,
+			&mission.Status,
+			&mission.Version,
+			&mission.CreatedAt,
+			&mission.UpdatedAt,
+			&mission.DeletedAt,
+			&key,
+		); err != nil {
+			return nil, err
+		}
+		mission.CatID = idFromNullable(catID)
+		page.Items = append(page.Items, mission)
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	page.Items, page.NextCursor = nextCursor(q, page.Items, keys, func(m model.Mission) uint { return m.ID })
+	return page, nil
+}
+
+// ListByCatIDs returns the missions currently assigned to any of the cats in one query, oldest first
+func (r *MissionRepository) ListByCatIDs(ctx context.Context, catIDs []uint) ([]model.Mission, error) {
 	query := `
-		SELECT id, name, cat_id, created_at, updated_at
+		SELECT id, name, cat_id, status, version, created_at, updated_at, deleted_at
 		FROM missions
+		WHERE cat_id = ANY($1) AND ` + liveOnly(ctx) + `
 		ORDER BY id`
 
-	rows, err := r.db.QueryContext(ctx, query)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, idArray(catIDs))
 	if err != nil {
 		return nil, err
 	}
@@ -88,38 +269,47 @@ func (r *MissionRepository) List(ctx context.Context) ([]model.Mission, error) {
 	var missions []model.Mission
 	for rows.Next() {
 		var mission model.Mission
+		var catID sql.NullInt64
 		if err := rows.Scan(
 			&mission.ID,
 			&mission.Name,
-			&mission.CatID,
+			&catID,
+			&mission.Status,
+			&mission.Version,
 			&mission.CreatedAt,
 			&mission.UpdatedAt,
+			&mission.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
+		mission.CatID = idFromNullable(catID)
 		missions = append(missions, mission)
 	}
 	return missions, rows.Err()
 }
 
-func (r *MissionRepository) AssignCat(ctx context.Context, missionID, catID uint) error {
-	query := `
-		UPDATE missions
-		SET cat_id = $1, updated_at = NOW()
-		WHERE id = $2`
-
-	res, err := r.db.ExecContext(ctx, query, catID, missionID)
-	if err != nil {
-		return fmt.Errorf("failed to assign cat to mission: %w", err)
+// nullableID converts an optional foreign key into a value accepted by database/sql
+func nullableID(id *uint) sql.NullInt64 {
+	if id == nil {
+		return sql.NullInt64{}
 	}
+	return sql.NullInt64{Int64: int64(*id), Valid: true}
+}
 
-	rowsAffected, err := res.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to retrieve affected rows: %w", err)
-	}
-	if rowsAffected == 0 {
-		return fmt.Errorf("mission with id %d not found", missionID)
+// idArray converts ids into a Postgres integer array parameter for "= ANY($n)"
+func idArray(ids []uint) any {
+	values := make([]int64, len(ids))
+	for i, id := range ids {
+		values[i] = int64(id)
 	}
+	return pq.Array(values)
+}
 
-	return nil
+// idFromNullable converts a nullable foreign key column back into an optional id
+func idFromNullable(id sql.NullInt64) *uint {
+	if !id.Valid {
+		return nil
+	}
+	v := uint(id.Int64)
+	return &v
 }
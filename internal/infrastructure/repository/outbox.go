@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"SpyCatAgency/internal/model"
+	"SpyCatAgency/internal/repository"
+	"context"
+	"database/sql"
+	"time"
+)
+
+type OutboxRepository struct {
+	db *sql.DB
+}
+
+func NewOutboxRepository(db *sql.DB) repository.OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+func (r *OutboxRepository) Create(ctx context.Context, event *model.Event) error {
+	query := `
+		INSERT INTO outbox_events (type, entity, entity_id, mission_id, cat_id, payload, request_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+		RETURNING id, created_at`
+
+	return conn(ctx, r.db).QueryRowContext(
+		ctx, query,
+		event.Type,
+		event.Entity,
+		event.EntityID,
+		nullableID(event.MissionID),
+		nullableID(event.CatID),
+		[]byte(event.Data),
+		event.RequestID,
+	).Scan(&event.ID, &event.CreatedAt)
+}
+
+// Dispatch creates a delivery of each of the oldest undispatched events for every live webhook subscribed
+// to it, and marks the events dispatched, in a single statement
+func (r *OutboxRepository) Dispatch(ctx context.Context, limit int) (int64, error) {
+	query := `
+		WITH batch AS (
+			SELECT id, type
+			FROM outbox_events
+			WHERE dispatched_at IS NULL
+			ORDER BY id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		), fanout AS (
+			INSERT INTO webhook_deliveries (webhook_id, event_id)
+			SELECT w.id, b.id
+			FROM batch b
+			JOIN webhooks w ON w.deleted_at IS NULL AND (CARDINALITY(w.events) = 0 OR b.type = ANY(w.events))
+			ON CONFLICT (webhook_id, event_id) DO NOTHING
+		)
+		UPDATE outbox_events
+		SET dispatched_at = NOW()
+		WHERE id IN (SELECT id FROM batch)`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, limit)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (r *OutboxRepository) ListAfter(
+	ctx context.Context,
+	afterID uint64,
+	limit int,
+	settle time.Duration,
+) ([]model.Event, error) {
+	query := `
+		SELECT id, type, entity, entity_id, mission_id, cat_id, payload, request_id, created_at,
+			created_at < NOW() - $3 * INTERVAL '1 millisecond'
+		FROM outbox_events
+		WHERE id > $1
+		ORDER BY id
+		LIMIT $2`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, afterID, limit, settle.Milliseconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []model.Event{}
+	last := afterID
+	for rows.Next() {
+		var event model.Event
+		var missionID, catID sql.NullInt64
+		var payload []byte
+		var settled bool
+		if err := rows.Scan(
+			&event.ID,
+			&event.Type,
+			&event.Entity,
+			&event.EntityID,
+			&missionID,
+			&catID,
+			&payload,
+			&event.RequestID,
+			&event.CreatedAt,
+			&settled,
+		); err != nil {
+			return nil, err
+		}
+		if event.ID != last+1 && !settled {
+			break
+		}
+		event.MissionID = idFromNullable(missionID)
+		event.CatID = idFromNullable(catID)
+		event.Data = payload
+		events = append(events, event)
+		last = event.ID
+	}
+	return events, rows.Err()
+}
+
+// LastID returns the id of the newest event, or 0 if there are none
+func (r *OutboxRepository) LastID(ctx context.Context) (uint64, error) {
+	var id uint64
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT COALESCE(MAX(id), 0) FROM outbox_events`).Scan(&id)
+	return id, err
+}
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"SpyCatAgency/internal/apperror"
+	"SpyCatAgency/internal/model"
+	"SpyCatAgency/internal/repository"
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// payrollPeriodIndex allows a single run per month
+const payrollPeriodIndex = "payroll_runs_period_start_key"
+
+// payrollRunColumns are the columns scanned by scanPayrollRun, in order
+const payrollRunColumns = `id, TO_CHAR(period_start, 'YYYY-MM'), TO_CHAR(period_start, 'YYYY-MM-DD'),
+	TO_CHAR(period_end, 'YYYY-MM-DD'), total, created_by, created_at`
+
+type PayrollRepository struct {
+	db *sql.DB
+}
+
+func NewPayrollRepository(db *sql.DB) repository.PayrollRepository {
+	return &PayrollRepository{db: db}
+}
+
+// Create stores the run and its lines
+func (r *PayrollRepository) Create(ctx context.Context, run *model.PayrollRun) error {
+	query := `
+		INSERT INTO payroll_runs (period_start, period_end, total, created_by, created_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		RETURNING id, created_at`
+
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, run.PeriodStart, run.PeriodEnd, run.Total, run.CreatedBy).
+		Scan(&run.ID, &run.CreatedAt)
+	if isUniqueViolation(err, payrollPeriodIndex) {
+		return apperror.Conflict("payroll for %s has already been run", run.Period)
+	}
+	if err != nil {
+		return err
+	}
+
+	lineQuery := `
+		INSERT INTO payroll_lines (run_id, cat_id, cat_name, days_paid, amount)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	for _, line := range run.Lines {
+		if _, err := conn(ctx, r.db).ExecContext(ctx, lineQuery, run.ID, line.CatID, line.CatName, line.DaysPaid, line.Amount); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetByID returns the run with its lines
+func (r *PayrollRepository) GetByID(ctx context.Context, id uint) (*model.PayrollRun, error) {
+	query := `SELECT ` + payrollRunColumns + ` FROM payroll_runs WHERE id = $1`
+
+	run, err := scanPayrollRun(conn(ctx, r.db).QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apperror.NotFound("payroll run %d not found", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	lineQuery := `
+		SELECT cat_id, cat_name, days_paid, amount
+		FROM payroll_lines
+		WHERE run_id = $1
+		ORDER BY cat_id`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, lineQuery, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	run.Lines = []model.PayrollLine{}
+	for rows.Next() {
+		var line model.PayrollLine
+		if err := rows.Scan(&line.CatID, &line.CatName, &line.DaysPaid, &line.Amount); err != nil {
+			return nil, err
+		}
+		run.Lines = append(run.Lines, line)
+	}
+	return run, rows.Err()
+}
+
+// List returns the runs without their lines, latest period first
+func (r *PayrollRepository) List(ctx context.Context) ([]model.PayrollRun, error) {
+	query := `SELECT ` + payrollRunColumns + ` FROM payroll_runs ORDER BY period_start DESC`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	runs := []model.PayrollRun{}
+	for rows.Next() {
+		run, err := scanPayrollRun(rows)
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, *run)
+	}
+	return runs, rows.Err()
+}
+
+// LastPeriodEnd returns the last day paid by any run as YYYY-MM-DD, or "" if payroll was never run
+func (r *PayrollRepository) LastPeriodEnd(ctx context.Context) (string, error) {
+	query := `SELECT COALESCE(TO_CHAR(MAX(period_end), 'YYYY-MM-DD'), '') FROM payroll_runs`
+
+	var end string
+	err := conn(ctx, r.db).QueryRowContext(ctx, query).Scan(&end)
+	return end, err
+}
+
+func scanPayrollRun(row rowScanner) (*model.PayrollRun, error) {
+	run := &model.PayrollRun{}
+	err := row.Scan(
+		&run.ID,
+		&run.Period,
+		&run.PeriodStart,
+		&run.PeriodEnd,
+		&run.Total,
+		&run.CreatedBy,
+		&run.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return run, nil
+}
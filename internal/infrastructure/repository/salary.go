@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"SpyCatAgency/internal/model"
+	"SpyCatAgency/internal/repository"
+	"context"
+	"database/sql"
+)
+
+type SalaryRepository struct {
+	db *sql.DB
+}
+
+func NewSalaryRepository(db *sql.DB) repository.SalaryRepository {
+	return &SalaryRepository{db: db}
+}
+
+func (r *SalaryRepository) Create(ctx context.Context, change *model.SalaryChange) error {
+	query := `
+		INSERT INTO salary_changes (cat_id, salary, effective_from, changed_by, reason, created_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		RETURNING id, created_at`
+
+	return conn(ctx, r.db).QueryRowContext(
+		ctx, query,
+		change.CatID,
+		change.Salary,
+		change.EffectiveFrom,
+		change.ChangedBy,
+		change.Reason,
+	).Scan(&change.ID, &change.CreatedAt)
+}
+
+func (r *SalaryRepository) ListByCatID(ctx context.Context, catID uint) ([]model.SalaryChange, error) {
+	query := `
+		SELECT id, cat_id, salary, TO_CHAR(effective_from, 'YYYY-MM-DD'), changed_by, reason, created_at
+		FROM salary_changes
+		WHERE cat_id = $1
+		ORDER BY effective_from, id`
+
+	return r.list(ctx, query, catID)
+}
+
+// ListEffectiveBy returns every entry that took effect on or before the date, by cat and in effect order
+func (r *SalaryRepository) ListEffectiveBy(ctx context.Context, date string) ([]model.SalaryChange, error) {
+	query := `
+		SELECT id, cat_id, salary, TO_CHAR(effective_from, 'YYYY-MM-DD'), changed_by, reason, created_at
+		FROM salary_changes
+		WHERE effective_from <= $1
+		ORDER BY cat_id, effective_from, id`
+
+	return r.list(ctx, query, date)
+}
+
+func (r *SalaryRepository) list(ctx context.Context, query string, arg any) ([]model.SalaryChange, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	changes := []model.SalaryChange{}
+	for rows.Next() {
+		var change model.SalaryChange
+		if err := rows.Scan(
+			&change.ID,
+			&change.CatID,
+			&change.Salary,
+			&change.EffectiveFrom,
+			&change.ChangedBy,
+			&change.Reason,
+			&change.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+	return changes, rows.Err()
+}
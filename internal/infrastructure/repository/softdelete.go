@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"SpyCatAgency/internal/apperror"
+	"SpyCatAgency/internal/repository"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// liveOnly returns the condition hiding soft-deleted rows unless the context includes them
+func liveOnly(ctx context.Context) string {
+	if repository.DeletedIncluded(ctx) {
+		return "TRUE"
+	}
+	return "deleted_at IS NULL"
+}
+
+// softDelete marks a row as deleted. A non-zero version makes it conditional on the current version.
+func softDelete(ctx context.Context, db *sql.DB, table, entity string, id uint, version int) error {
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET deleted_at = NOW(), version = version + 1, updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL AND ($2 = 0 OR version = $2)`, table)
+
+	res, err := conn(ctx, db).ExecContext(ctx, query, id, version)
+	if err != nil {
+		return err
+	}
+	n, err := affected(res)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return missingOrStale(ctx, db, table, entity, id)
+	}
+	return nil
+}
+
+// restore clears the deletion mark of a soft-deleted row
+func restore(ctx context.Context, db *sql.DB, table, entity string, id uint) error {
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET deleted_at = NULL, version = version + 1, updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NOT NULL`, table)
+
+	res, err := conn(ctx, db).ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	n, err := affected(res)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return nil
+	}
+
+	var exists bool
+	query = fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM %s WHERE id = $1)`, table)
+	if err := conn(ctx, db).QueryRowContext(ctx, query, id).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return apperror.NotFound("%s %d not found", entity, id)
+	}
+	return apperror.Conflict("%s %d is not deleted", entity, id)
+}
+
+// purge permanently removes rows soft-deleted longer than the retention ago.
+// The extra condition keeps rows that other rows still reference.
+func purge(ctx context.Context, db *sql.DB, table, unreferenced string, retention time.Duration) (int64, error) {
+	query := fmt.Sprintf(`
+		DELETE FROM %s
+		WHERE deleted_at < NOW() - make_interval(secs => $1) AND %s`, table, unreferenced)
+
+	res, err := conn(ctx, db).ExecContext(ctx, query, retention.Seconds())
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge %s: %w", table, err)
+	}
+	return affected(res)
+}
@@ -1,11 +1,13 @@
 package repository
 
 import (
+	"SpyCatAgency/internal/apperror"
 	"SpyCatAgency/internal/model"
 	"SpyCatAgency/internal/repository"
 	"context"
 	"database/sql"
-	"fmt"
+	"errors"
+	"time"
 )
 
 type TargetRepository struct {
@@ -20,55 +22,100 @@ func (r *TargetRepository) Create(ctx context.Context, target *model.Target) err
 	query := `
 		INSERT INTO targets (name, country, notes, mission_id, completed, created_at, updated_at)
 		VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
-		RETURNING id, created_at, updated_at`
+		RETURNING id, version, created_at, updated_at`
 
-	return r.db.QueryRowContext(
+	return conn(ctx, r.db).QueryRowContext(
 		ctx, query,
 		target.Name,
 		target.Country,
 		target.Notes,
 		target.MissionID,
 		target.Completed,
-	).Scan(&target.ID, &target.CreatedAt, &target.UpdatedAt)
+	).Scan(&target.ID, &target.Version, &target.CreatedAt, &target.UpdatedAt)
 }
 
+// Update saves the target if it still has the version it was read with, and bumps the version
 func (r *TargetRepository) Update(ctx context.Context, target *model.Target) error {
 	query := `
 		UPDATE targets
-		SET name = $1, mission_id = $2, updated_at = NOW()
-		WHERE id = $3
-		RETURNING updated_at`
+		SET name = $1, mission_id = $2, notes = $3, completed = $4, version = version + 1, updated_at = NOW()
+		WHERE id = $5 AND version = $6 AND deleted_at IS NULL
+		RETURNING version, updated_at`
 
-	return r.db.QueryRowContext(
+	err := conn(ctx, r.db).QueryRowContext(
 		ctx, query,
 		target.Name,
 		target.MissionID,
+		target.Notes,
+		target.Completed,
 		target.ID,
-	).Scan(&target.UpdatedAt)
+		target.Version,
+	).Scan(&target.Version, &target.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return missingOrStale(ctx, r.db, "targets", "target", target.ID)
+	}
+	return err
+}
+
+// Delete soft-deletes the target. A non-zero version makes the delete conditional on the current version.
+func (r *TargetRepository) Delete(ctx context.Context, id uint, version int) error {
+	return softDelete(ctx, r.db, "targets", "target", id, version)
+}
+
+// DeleteByMissionID soft-deletes every remaining target of the mission
+func (r *TargetRepository) DeleteByMissionID(ctx context.Context, missionID uint) error {
+	query := `
+		UPDATE targets
+		SET deleted_at = NOW(), version = version + 1, updated_at = NOW()
+		WHERE mission_id = $1 AND deleted_at IS NULL`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, missionID)
+	return err
+}
+
+func (r *TargetRepository) Restore(ctx context.Context, id uint) error {
+	return restore(ctx, r.db, "targets", "target", id)
 }
 
-func (r *TargetRepository) Delete(ctx context.Context, id uint) error {
-	query := `DELETE FROM targets WHERE id = $1`
-	_, err := r.db.ExecContext(ctx, query, id)
+// RestoreByMissionID restores the targets that were deleted together with the mission.
+// It must run before the mission itself is restored.
+func (r *TargetRepository) RestoreByMissionID(ctx context.Context, missionID uint) error {
+	query := `
+		UPDATE targets
+		SET deleted_at = NULL, version = version + 1, updated_at = NOW()
+		WHERE mission_id = $1
+		  AND deleted_at = (SELECT deleted_at FROM missions WHERE id = $1)`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, missionID)
 	return err
 }
 
+// Purge removes targets deleted longer than the retention ago
+func (r *TargetRepository) Purge(ctx context.Context, retention time.Duration) (int64, error) {
+	return purge(ctx, r.db, "targets", "TRUE", retention)
+}
+
 func (r *TargetRepository) GetByID(ctx context.Context, id uint) (*model.Target, error) {
 	query := `
-		SELECT id, name, mission_id, created_at, updated_at
+		SELECT id, name, country, COALESCE(notes, ''), completed, mission_id, version, created_at, updated_at, deleted_at
 		FROM targets
-		WHERE id = $1`
+		WHERE id = $1 AND ` + liveOnly(ctx)
 
 	target := &model.Target{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, id).Scan(
 		&target.ID,
 		&target.Name,
+		&target.Country,
+		&target.Notes,
+		&target.Completed,
 		&target.MissionID,
+		&target.Version,
 		&target.CreatedAt,
 		&target.UpdatedAt,
+		&target.DeletedAt,
 	)
-	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("target not found")
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apperror.NotFound("target %d not found", id)
 	}
 	if err != nil {
 		return nil, err
@@ -78,12 +125,27 @@ func (r *TargetRepository) GetByID(ctx context.Context, id uint) (*model.Target,
 
 func (r *TargetRepository) ListByMissionID(ctx context.Context, missionID uint) ([]model.Target, error) {
 	query := `
-		SELECT id, name, mission_id, created_at, updated_at
+		SELECT id, name, country, COALESCE(notes, ''), completed, mission_id, version, created_at, updated_at, deleted_at
 		FROM targets
-		WHERE mission_id = $1
+		WHERE mission_id = $1 AND ` + liveOnly(ctx) + `
 		ORDER BY id`
 
-	rows, err := r.db.QueryContext(ctx, query, missionID)
+	return r.list(ctx, query, missionID)
+}
+
+// ListByMissionIDs returns the targets of several missions in one query, ordered by mission and id
+func (r *TargetRepository) ListByMissionIDs(ctx context.Context, missionIDs []uint) ([]model.Target, error) {
+	query := `
+		SELECT id, name, country, COALESCE(notes, ''), completed, mission_id, version, created_at, updated_at, deleted_at
+		FROM targets
+		WHERE mission_id = ANY($1) AND ` + liveOnly(ctx) + `
+		ORDER BY mission_id, id`
+
+	return r.list(ctx, query, idArray(missionIDs))
+}
+
+func (r *TargetRepository) list(ctx context.Context, query string, args ...any) ([]model.Target, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -95,9 +157,14 @@ func (r *TargetRepository) ListByMissionID(ctx context.Context, missionID uint)
 		if err := rows.Scan(
 			&target.ID,
 			&target.Name,
+			&target.Country,
+			&target.Notes,
+			&target.Completed,
 			&target.MissionID,
+			&target.Version,
 			&target.CreatedAt,
 			&target.UpdatedAt,
+			&target.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
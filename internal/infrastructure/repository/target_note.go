@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"SpyCatAgency/internal/apperror"
+	"SpyCatAgency/internal/model"
+	"SpyCatAgency/internal/repository"
+	"context"
+	"database/sql"
+	"errors"
+)
+
+type TargetNoteRepository struct {
+	db *sql.DB
+}
+
+func NewTargetNoteRepository(db *sql.DB) repository.TargetNoteRepository {
+	return &TargetNoteRepository{db: db}
+}
+
+// Append stores the note as the next revision of its target
+func (r *TargetNoteRepository) Append(ctx context.Context, note *model.TargetNote) error {
+	query := `
+		INSERT INTO target_notes (target_id, revision, content, author, created_at)
+		SELECT $1, COALESCE(MAX(revision), 0) + 1, $2, $3, NOW()
+		FROM target_notes
+		WHERE target_id = $1
+		RETURNING revision, created_at`
+
+	return conn(ctx, r.db).QueryRowContext(ctx, query, note.TargetID, note.Content, note.Author).
+		Scan(&note.Revision, &note.CreatedAt)
+}
+
+func (r *TargetNoteRepository) ListByTargetID(ctx context.Context, targetID uint) ([]model.TargetNote, error) {
+	query := `
+		SELECT target_id, revision, content, author, created_at
+		FROM target_notes
+		WHERE target_id = $1
+		ORDER BY revision`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, targetID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	notes := []model.TargetNote{}
+	for rows.Next() {
+		var note model.TargetNote
+		if err := rows.Scan(&note.TargetID, &note.Revision, &note.Content, &note.Author, &note.CreatedAt); err != nil {
+			return nil, err
+		}
+		notes = append(notes, note)
+	}
+	return notes, rows.Err()
+}
+
+func (r *TargetNoteRepository) GetRevision(ctx context.Context, targetID uint, revision int) (*model.TargetNote, error) {
+	query := `
+		SELECT target_id, revision, content, author, created_at
+		FROM target_notes
+		WHERE target_id = $1 AND revision = $2`
+
+	note := &model.TargetNote{}
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, targetID, revision).
+		Scan(&note.TargetID, &note.Revision, &note.Content, &note.Author, &note.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apperror.NotFound("revision %d of the notes of target %d not found", revision, targetID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return note, nil
+}
+
+// LatestRevision returns the number of the newest revision, 0 if the target has no notes history
+func (r *TargetNoteRepository) LatestRevision(ctx context.Context, targetID uint) (int, error) {
+	query := `SELECT COALESCE(MAX(revision), 0) FROM target_notes WHERE target_id = $1`
+
+	var revision int
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, targetID).Scan(&revision)
+	return revision, err
+}
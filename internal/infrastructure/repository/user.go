@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"SpyCatAgency/internal/apperror"
+	"SpyCatAgency/internal/model"
+	"SpyCatAgency/internal/repository"
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// usernameIndex keeps usernames unique
+const usernameIndex = "users_username_key"
+
+type UserRepository struct {
+	db *sql.DB
+}
+
+func NewUserRepository(db *sql.DB) repository.UserRepository {
+	return &UserRepository{db: db}
+}
+
+func (r *UserRepository) Create(ctx context.Context, user *model.User) error {
+	query := `
+		INSERT INTO users (username, password_hash, role, cat_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, NOW(), NOW())
+		RETURNING id, created_at, updated_at`
+
+	err := conn(ctx, r.db).QueryRowContext(
+		ctx, query,
+		user.Username,
+		user.PasswordHash,
+		user.Role,
+		nullableID(user.CatID),
+	).Scan(&user.ID, &user.CreatedAt, &user.UpdatedAt)
+	if isUniqueViolation(err, usernameIndex) {
+		return apperror.Conflict("user %q already exists", user.Username)
+	}
+	return err
+}
+
+func (r *UserRepository) GetByUsername(ctx context.Context, username string) (*model.User, error) {
+	query := `
+		SELECT id, username, password_hash, role, cat_id, created_at, updated_at
+		FROM users
+		WHERE username = $1`
+
+	user := &model.User{}
+	var catID sql.NullInt64
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, username).Scan(
+		&user.ID,
+		&user.Username,
+		&user.PasswordHash,
+		&user.Role,
+		&catID,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apperror.NotFound("user %q not found", username)
+	}
+	if err != nil {
+		return nil, err
+	}
+	user.CatID = idFromNullable(catID)
+	return user, nil
+}
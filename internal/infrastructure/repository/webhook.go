@@ -0,0 +1,309 @@
+package repository
+
+import (
+	"SpyCatAgency/internal/apperror"
+	"SpyCatAgency/internal/model"
+	"SpyCatAgency/internal/repository"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type WebhookRepository struct {
+	db *sql.DB
+}
+
+func NewWebhookRepository(db *sql.DB) repository.WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+func (r *WebhookRepository) Create(ctx context.Context, webhook *model.Webhook) error {
+	query := `
+		INSERT INTO webhooks (url, secret, events, created_by, created_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		RETURNING id, created_at`
+
+	return conn(ctx, r.db).QueryRowContext(
+		ctx, query,
+		webhook.URL,
+		webhook.Secret,
+		pq.Array(webhook.Events),
+		webhook.CreatedBy,
+	).Scan(&webhook.ID, &webhook.CreatedAt)
+}
+
+// List returns the live webhooks
+func (r *WebhookRepository) List(ctx context.Context) ([]model.Webhook, error) {
+	query := `
+		SELECT id, url, events, created_by, created_at, deleted_at
+		FROM webhooks
+		WHERE deleted_at IS NULL
+		ORDER BY id`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	webhooks := []model.Webhook{}
+	for rows.Next() {
+		var webhook model.Webhook
+		if err := rows.Scan(
+			&webhook.ID,
+			&webhook.URL,
+			pq.Array(&webhook.Events),
+			&webhook.CreatedBy,
+			&webhook.CreatedAt,
+			&webhook.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	return webhooks, rows.Err()
+}
+
+// GetByID returns a live webhook
+func (r *WebhookRepository) GetByID(ctx context.Context, id uint) (*model.Webhook, error) {
+	query := `
+		SELECT id, url, events, created_by, created_at, deleted_at
+		FROM webhooks
+		WHERE id = $1 AND deleted_at IS NULL`
+
+	var webhook model.Webhook
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, id).Scan(
+		&webhook.ID,
+		&webhook.URL,
+		pq.Array(&webhook.Events),
+		&webhook.CreatedBy,
+		&webhook.CreatedAt,
+		&webhook.DeletedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apperror.NotFound("webhook %d not found", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// Delete stops deliveries to the webhook. Its delivery history is kept.
+func (r *WebhookRepository) Delete(ctx context.Context, id uint) error {
+	query := `UPDATE webhooks SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return apperror.NotFound("webhook %d not found", id)
+	}
+	return nil
+}
+
+// ClaimDue leases the due pending deliveries of live webhooks by moving their next attempt past the lease,
+// and counts the attempt
+func (r *WebhookRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]model.DeliveryJob, error) {
+	query := `
+		WITH claimed AS (
+			UPDATE webhook_deliveries
+			SET attempts = attempts + 1,
+				next_attempt_at = NOW() + $2 * INTERVAL '1 millisecond',
+				updated_at = NOW()
+			WHERE id IN (
+				SELECT d.id
+				FROM webhook_deliveries d
+				JOIN webhooks w ON w.id = d.webhook_id AND w.deleted_at IS NULL
+				WHERE d.status = 'pending' AND d.next_attempt_at <= NOW()
+				ORDER BY d.next_attempt_at, d.id
+				LIMIT $1
+				FOR UPDATE OF d SKIP LOCKED
+			)
+			RETURNING id, webhook_id, event_id, attempts
+		)
+		SELECT c.id, c.attempts,
+			w.id, w.url, w.secret,
+			e.id, e.type, e.entity, e.entity_id, e.mission_id, e.cat_id, e.payload, e.request_id, e.created_at
+		FROM claimed c
+		JOIN webhooks w ON w.id = c.webhook_id
+		JOIN outbox_events e ON e.id = c.event_id
+		ORDER BY e.id`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, limit, lease.Milliseconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []model.DeliveryJob
+	for rows.Next() {
+		var job model.DeliveryJob
+		var payload []byte
+		var missionID, catID sql.NullInt64
+		if err := rows.Scan(
+			&job.DeliveryID,
+			&job.Attempt,
+			&job.Webhook.ID,
+			&job.Webhook.URL,
+			&job.Webhook.Secret,
+			&job.Event.ID,
+			&job.Event.Type,
+			&job.Event.Entity,
+			&job.Event.EntityID,
+			&missionID,
+			&catID,
+			&payload,
+			&job.Event.RequestID,
+			&job.Event.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		job.Event.Data = payload
+		job.Event.MissionID = idFromNullable(missionID)
+		job.Event.CatID = idFromNullable(catID)
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
+
+func (r *WebhookRepository) MarkDelivered(ctx context.Context, id uint64, statusCode int) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = 'delivered', last_status_code = $2, last_error = '', delivered_at = NOW(), updated_at = NOW()
+		WHERE id = $1`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, id, statusCode)
+	return err
+}
+
+// MarkFailed records a failed attempt. The delivery is retried at retryAt, or becomes dead if retryAt is nil.
+func (r *WebhookRepository) MarkFailed(ctx context.Context, id uint64, statusCode *int, reason string, retryAt *time.Time) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = CASE WHEN $4::timestamptz IS NULL THEN 'dead' ELSE 'pending' END,
+			next_attempt_at = COALESCE($4::timestamptz, next_attempt_at),
+			last_status_code = $2,
+			last_error = $3,
+			updated_at = NOW()
+		WHERE id = $1`
+
+	var code sql.NullInt64
+	if statusCode != nil {
+		code = sql.NullInt64{Int64: int64(*statusCode), Valid: true}
+	}
+	var retry sql.NullTime
+	if retryAt != nil {
+		retry = sql.NullTime{Time: *retryAt, Valid: true}
+	}
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, id, code, reason, retry)
+	return err
+}
+
+// deliverySortColumns are the columns GET /api/webhooks/deliveries can be sorted by
+var deliverySortColumns = map[string]sortColumn{
+	"id":              {name: "id", sqlType: "bigint"},
+	"next_attempt_at": {name: "next_attempt_at", sqlType: "timestamptz"},
+	"created_at":      {name: "created_at", sqlType: "timestamp"},
+}
+
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, filter model.WebhookDeliveryFilter) (*model.WebhookDeliveryPage, error) {
+	q, err := newListQuery(filter.ListParams, deliverySortColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	if filter.Status != "" {
+		q.where("status = " + q.arg(filter.Status))
+	}
+	if filter.WebhookID != nil {
+		q.where("webhook_id = " + q.arg(*filter.WebhookID))
+	}
+
+	page := &model.WebhookDeliveryPage{Items: []model.WebhookDelivery{}}
+
+	countQuery, countArgs := q.countSQL("webhook_deliveries")
+	if err := conn(ctx, r.db).QueryRowContext(ctx, countQuery, countArgs...).Scan(&page.Total); err != nil {
+		return nil, err
+	}
+
+	query, args := q.pageSQL("webhook_deliveries", `id, webhook_id, event_id,
+		(SELECT e.type FROM outbox_events e WHERE e.id = webhook_deliveries.event_id),
+		status, attempts, next_attempt_at, last_status_code, last_error, delivered_at, created_at`)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var delivery model.WebhookDelivery
+		var statusCode sql.NullInt64
+		var key string
+		if err := rows.Scan(
+			&delivery.ID,
+			&delivery.WebhookID,
+			&delivery.EventID,
+			&delivery.EventType,
+			&delivery.Status,
+			&delivery.Attempts,
+			&delivery.NextAttemptAt,
+			&statusCode,
+			&delivery.LastError,
+			&delivery.DeliveredAt,
+			&delivery.CreatedAt,
+			&key,
+		); err != nil {
+			return nil, err
+		}
+		if statusCode.Valid {
+			code := int(statusCode.Int64)
+			delivery.LastStatusCode = &code
+		}
+		page.Items = append(page.Items, delivery)
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	page.Items, page.NextCursor = nextCursor(q, page.Items, keys, func(d model.WebhookDelivery) uint { return uint(d.ID) })
+	return page, nil
+}
+
+// Retry puts a dead delivery back in the queue with a fresh set of attempts
+func (r *WebhookRepository) Retry(ctx context.Context, id uint64) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = 'pending', attempts = 0, next_attempt_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND status = 'dead'
+		RETURNING id`
+
+	var updated uint64
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, id).Scan(&updated)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	var status string
+	lookup := `SELECT status FROM webhook_deliveries WHERE id = $1`
+	if err := conn(ctx, r.db).QueryRowContext(ctx, lookup, id).Scan(&status); errors.Is(err, sql.ErrNoRows) {
+		return apperror.NotFound("webhook delivery %d not found", id)
+	} else if err != nil {
+		return err
+	}
+	return apperror.Conflict("webhook delivery %d is %s, only dead deliveries can be retried", id, status)
+}
@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"SpyCatAgency/internal/apperror"
+	"SpyCatAgency/internal/auth"
+	"SpyCatAgency/internal/logger"
+	"SpyCatAgency/internal/requestctx"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyAuthenticator resolves an API key into the principal it acts as
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, key string) (*auth.Principal, error)
+}
+
+// Authenticate rejects requests without a valid JWT or API key and puts the principal of the credential
+// into the request context and the logger attributes. API keys are sent as a bearer token or in X-API-Key.
+func Authenticate(tokens *auth.TokenManager, keys APIKeyAuthenticator) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		credential := strings.TrimSpace(ctx.GetHeader("X-API-Key"))
+		if credential == "" {
+			scheme, token, ok := strings.Cut(ctx.GetHeader("Authorization"), " ")
+			if ok && strings.EqualFold(scheme, "Bearer") {
+				credential = strings.TrimSpace(token)
+			}
+		}
+		if credential == "" {
+			unauthorized(ctx, apperror.Unauthorized("missing bearer token or API key"))
+			return
+		}
+
+		var principal *auth.Principal
+		var err error
+		if auth.IsAPIKey(credential) {
+			principal, err = keys.Authenticate(ctx.Request.Context(), credential)
+		} else {
+			principal, err = tokens.Verify(credential)
+			if err != nil {
+				logger.GinSetLoggerAttr(ctx, slog.String("auth_error", err.Error()))
+				err = apperror.Unauthorized("invalid or expired token")
+			}
+		}
+		if errors.Is(err, apperror.ErrUnauthorized) {
+			unauthorized(ctx, err)
+			return
+		}
+		if err != nil {
+			_ = ctx.Error(err)
+			ctx.Abort()
+			return
+		}
+
+		reqCtx := auth.WithPrincipal(ctx.Request.Context(), principal)
+		reqCtx = requestctx.WithActor(reqCtx, principal.Name)
+		ctx.Request = ctx.Request.WithContext(reqCtx)
+
+		logger.GinSetLoggerAttr(ctx, slog.String("user", principal.Name))
+
+		ctx.Next()
+	}
+}
+
+// RequirePermission rejects requests whose principal lacks the permission, for routes served without a service
+// that checks it. Must run after Authenticate.
+func RequirePermission(perm auth.Permission) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		p := auth.PrincipalFrom(ctx.Request.Context())
+		if p == nil || !p.Can(perm) {
+			_ = ctx.Error(apperror.Forbidden("not allowed to perform this operation").WithDetail("permission", perm))
+			ctx.Abort()
+			return
+		}
+		ctx.Next()
+	}
+}
+
+func unauthorized(ctx *gin.Context, err error) {
+	ctx.Header("WWW-Authenticate", `Bearer realm="spycat"`)
+	_ = ctx.Error(err)
+	ctx.Abort()
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"SpyCatAgency/internal/apperror"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorHandler translates the last error attached to the Gin context into a JSON response.
+// Handlers report failures with ctx.Error(err) and return without writing a body.
+func ErrorHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Next()
+
+		if len(ctx.Errors) == 0 || ctx.Writer.Written() {
+			return
+		}
+
+		err := ctx.Errors.Last().Err
+		status := errorStatus(err)
+
+		body := gin.H{"error": err.Error()}
+		if status == http.StatusInternalServerError {
+			body["error"] = http.StatusText(http.StatusInternalServerError)
+		}
+
+		var appErr *apperror.Error
+		if errors.As(err, &appErr) {
+			body["error"] = appErr.Message()
+			for k, v := range appErr.Details() {
+				body[k] = v
+			}
+		}
+
+		ctx.JSON(status, body)
+	}
+}
+
+// errorStatus maps an error kind to the HTTP status code of the response
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, apperror.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, apperror.ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, apperror.ErrValidation):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, apperror.ErrPreconditionFailed):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, apperror.ErrUnauthorized):
+		return http.StatusUnauthorized
+	case errors.Is(err, apperror.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, apperror.ErrRateLimited):
+		return http.StatusTooManyRequests
+	case errors.Is(err, apperror.ErrUpstream):
+		return http.StatusBadGateway
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,141 @@
+package middleware
+
+import (
+	"SpyCatAgency/internal/auth"
+	"SpyCatAgency/internal/logger"
+	"SpyCatAgency/internal/model"
+	"SpyCatAgency/internal/service"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxIdempotencyKeyLength matches the key column of the idempotency_keys table
+const maxIdempotencyKeyLength = 255
+
+// maxIdempotentBody is the largest body buffered for an idempotent request, the import limit of the handlers
+const maxIdempotentBody = 5 << 20
+
+// idempotencySaveTimeout bounds storing or releasing a key once the request is done
+const idempotencySaveTimeout = 5 * time.Second
+
+// replayedHeaders are the response headers stored with the body and sent again on replay
+var replayedHeaders = []string{"Content-Type", "ETag", "Location"}
+
+// Idempotency makes POST requests with an Idempotency-Key header safe to retry: the first successful
+// response per caller and key is stored and replayed to retries with the same payload. Failed requests
+// release the key so they can be retried. Must run after Authenticate, as keys are scoped per principal.
+func Idempotency(idempotency *service.IdempotencyService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		key := ctx.GetHeader("Idempotency-Key")
+		if key == "" || ctx.Request.Method != http.MethodPost {
+			ctx.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Idempotency-Key is too long"})
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxIdempotentBody))
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			ctx.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("request body exceeds %d bytes", tooLarge.Limit)})
+			return
+		}
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "failed to read request body"})
+			return
+		}
+		ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		principal := ""
+		if p := auth.PrincipalFrom(ctx.Request.Context()); p != nil {
+			principal = p.Subject
+		}
+		claim := &model.IdempotencyRecord{
+			Principal:   principal,
+			Key:         key,
+			RequestHash: requestHash(ctx.Request.Method, ctx.Request.URL.RequestURI(), body),
+		}
+
+		reqCtx := ctx.Request.Context()
+		stored, err := idempotency.Begin(reqCtx, claim)
+		if err != nil {
+			_ = ctx.Error(err)
+			ctx.Abort()
+			return
+		}
+		if stored != nil {
+			for name, value := range stored.Headers {
+				ctx.Header(name, value)
+			}
+			ctx.Header("Idempotent-Replayed", "true")
+			ctx.Data(stored.StatusCode, stored.Headers["Content-Type"], stored.Body)
+			ctx.Abort()
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: ctx.Writer}
+		ctx.Writer = recorder
+
+		ctx.Next()
+
+		// The request context is cancelled when the client goes away, but the outcome must still be saved:
+		// a key left claimed would let a retry run the request again once the claim is abandoned
+		saveCtx, cancel := context.WithTimeout(context.WithoutCancel(reqCtx), idempotencySaveTimeout)
+		defer cancel()
+
+		status := recorder.Status()
+		if len(ctx.Errors) > 0 || !recorder.Written() || status < 200 || status >= 300 {
+			if err := idempotency.Release(saveCtx, claim); err != nil {
+				logger.Error(saveCtx, fmt.Errorf("failed to release idempotency key: %w", err))
+			}
+			return
+		}
+
+		claim.StatusCode = status
+		claim.Headers = make(map[string]string)
+		claim.Body = recorder.body.Bytes()
+		for _, name := range replayedHeaders {
+			if value := recorder.Header().Get(name); value != "" {
+				claim.Headers[name] = value
+			}
+		}
+		if err := idempotency.Complete(saveCtx, claim); err != nil {
+			logger.Error(saveCtx, fmt.Errorf("failed to store idempotent response: %w", err))
+		}
+	}
+}
+
+// requestHash fingerprints a request so a reused key with a different payload can be detected
+func requestHash(method, uri string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method + " " + uri + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// responseRecorder keeps a copy of the response body while it is written to the client
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"SpyCatAgency/internal/logger"
+	"SpyCatAgency/internal/requestctx"
 	"github.com/gin-gonic/gin"
 	"github.com/oklog/ulid/v2"
 	"log/slog"
@@ -20,6 +21,10 @@ func Logger() gin.HandlerFunc {
 			slog.String("request_path", reqPath),
 			slog.String("method", reqMethod),
 		)
+		// The request id also reaches the services, e.g. for the audit log
+		ctx.Request = ctx.Request.WithContext(requestctx.WithRequestID(ctx.Request.Context(), reqID))
+		ctx.Header("X-Request-ID", reqID)
+
 		logger.Info(ctx.Request.Context(), "request start")
 
 		startedAt := time.Now()
@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"SpyCatAgency/internal/apperror"
+	"SpyCatAgency/internal/auth"
+	"SpyCatAgency/internal/ratelimit"
+	"expvar"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// rateLimitRejections counts rejected requests per route, and per IP under "ip", published at /debug/vars
+var rateLimitRejections = expvar.NewMap("rate_limit_rejections")
+
+// RateLimitConfig holds the default limit and overrides keyed by "METHOD /route/:pattern"
+type RateLimitConfig struct {
+	Default ratelimit.Limit
+	Routes  map[string]ratelimit.Limit
+}
+
+// RateLimit limits every caller separately on every route: authenticated callers by principal
+// (user or API key), anonymous ones by client IP. Must run after Authenticate to see the principal.
+func RateLimit(limiter *ratelimit.Limiter, cfg RateLimitConfig) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		route := ctx.Request.Method + " " + ctx.FullPath()
+
+		limit, ok := cfg.Routes[route]
+		if !ok {
+			limit = cfg.Default
+		}
+
+		if enforceLimit(ctx, limiter, rateLimitClient(ctx)+" "+route, limit, route) {
+			ctx.Next()
+		}
+	}
+}
+
+// RateLimitByIP limits all requests of a client IP together, whatever the route. It runs before Authenticate,
+// so requests with invalid tokens or guessed API keys are limited too.
+func RateLimitByIP(limiter *ratelimit.Limiter, limit ratelimit.Limit) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if enforceLimit(ctx, limiter, "ip:"+ctx.ClientIP(), limit, "ip") {
+			ctx.Next()
+		}
+	}
+}
+
+// enforceLimit counts the request against the bucket of key and sets the rate limit headers. A rejected
+// request is aborted with 429 and counted under metric; enforceLimit reports whether it was allowed.
+func enforceLimit(ctx *gin.Context, limiter *ratelimit.Limiter, key string, limit ratelimit.Limit, metric string) bool {
+	res := limiter.Allow(key, limit)
+
+	ctx.Header("X-RateLimit-Limit", strconv.Itoa(res.Limit))
+	ctx.Header("X-RateLimit-Remaining", strconv.Itoa(res.Remaining))
+	ctx.Header("X-RateLimit-Reset", ceilSeconds(res.Reset))
+
+	if !res.Allowed {
+		rateLimitRejections.Add(metric, 1)
+		ctx.Header("Retry-After", ceilSeconds(res.RetryAfter))
+		_ = ctx.Error(apperror.RateLimited("too many requests, retry in %s seconds", ceilSeconds(res.RetryAfter)))
+		ctx.Abort()
+		return false
+	}
+	return true
+}
+
+// rateLimitClient identifies whose bucket a request is counted against
+func rateLimitClient(ctx *gin.Context) string {
+	if p := auth.PrincipalFrom(ctx.Request.Context()); p != nil {
+		return "principal:" + p.Subject
+	}
+	return "ip:" + ctx.ClientIP()
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,16 @@
+package model
+
+import (
+	"time"
+)
+
+// Assignment is the tenure of a cat on a mission. ReleasedAt and Reason are set once the cat
+// is replaced or the mission is completed or aborted.
+type Assignment struct {
+	ID         uint       `json:"id"`
+	MissionID  uint       `json:"mission_id"`
+	CatID      uint       `json:"cat_id"`
+	AssignedAt time.Time  `json:"assigned_at"`
+	ReleasedAt *time.Time `json:"released_at,omitempty"`
+	Reason     string     `json:"reason,omitempty"`
+}
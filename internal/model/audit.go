@@ -0,0 +1,52 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditAction string
+
+const (
+	AuditActionCreate  AuditAction = "create"
+	AuditActionUpdate  AuditAction = "update"
+	AuditActionDelete  AuditAction = "delete"
+	AuditActionRestore AuditAction = "restore"
+	AuditActionAssign  AuditAction = "assign"
+)
+
+// AuditEntry records a single change made to a cat, mission or target
+type AuditEntry struct {
+	ID        uint                   `json:"id"`
+	RequestID string                 `json:"request_id"`
+	Actor     string                 `json:"actor"`
+	Action    AuditAction            `json:"action"`
+	Entity    string                 `json:"entity"`
+	EntityID  uint                   `json:"entity_id"`
+	Before    json.RawMessage        `json:"before,omitempty" swaggertype:"object"`
+	After     json.RawMessage        `json:"after,omitempty" swaggertype:"object"`
+	Changes   map[string]AuditChange `json:"changes,omitempty"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+// AuditChange is the old and new value of a field changed by an update
+type AuditChange struct {
+	From any `json:"from"`
+	To   any `json:"to"`
+}
+
+type AuditFilter struct {
+	ListParams
+	Entity    string      `form:"entity" binding:"omitempty,oneof=cat mission target api_key payroll_run webhook"`
+	EntityID  *uint       `form:"entity_id"`
+	Action    AuditAction `form:"action" binding:"omitempty,oneof=create update delete restore assign"`
+	Actor     string      `form:"actor"`
+	RequestID string      `form:"request_id"`
+	From      *time.Time  `form:"from"`
+	To        *time.Time  `form:"to"`
+}
+
+type AuditPage struct {
+	Items []AuditEntry `json:"items"`
+	PageInfo
+}
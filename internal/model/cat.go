@@ -5,13 +5,16 @@ import (
 )
 
 type Cat struct {
-	ID              uint      `json:"id" gorm:"primaryKey"`
-	Name            string    `json:"name" gorm:"not null"`
-	YearsExperience int       `json:"years_experience" gorm:"not null"`
-	Breed           string    `json:"breed" gorm:"not null"`
-	Salary          float64   `json:"salary" gorm:"not null"`
-	CreatedAt       time.Time `json:"created_at"`
-	UpdatedAt       time.Time `json:"updated_at"`
+	ID              uint       `json:"id" gorm:"primaryKey"`
+	Name            string     `json:"name" gorm:"not null"`
+	YearsExperience int        `json:"years_experience" gorm:"not null"`
+	Breed           string     `json:"breed" gorm:"not null"`
+	Salary          float64    `json:"salary" gorm:"not null"`
+	BreedVerified   bool       `json:"breed_verified" gorm:"default:true"`
+	Version         int        `json:"version"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	DeletedAt       *time.Time `json:"deleted_at,omitempty"`
 }
 
 type CatCreate struct {
@@ -23,4 +26,21 @@ type CatCreate struct {
 
 type CatUpdate struct {
 	Salary float64 `json:"salary" binding:"required,min=0"`
+	// EffectiveFrom is the first day of the new salary as YYYY-MM-DD, today by default
+	EffectiveFrom string `json:"effective_from" binding:"omitempty,datetime=2006-01-02"`
+	Reason        string `json:"reason" binding:"max=255"`
+}
+
+type CatFilter struct {
+	ListParams
+	Breed         string   `form:"breed"`
+	MinExperience *int     `form:"min_experience" binding:"omitempty,min=0"`
+	MaxExperience *int     `form:"max_experience" binding:"omitempty,min=0"`
+	MinSalary     *float64 `form:"min_salary" binding:"omitempty,min=0"`
+	MaxSalary     *float64 `form:"max_salary" binding:"omitempty,min=0"`
+}
+
+type CatPage struct {
+	Items []Cat `json:"items"`
+	PageInfo
 }
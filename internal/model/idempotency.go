@@ -0,0 +1,17 @@
+package model
+
+import (
+	"time"
+)
+
+// IdempotencyRecord is the first request made with an Idempotency-Key and, once it finished, its response
+type IdempotencyRecord struct {
+	Principal   string
+	Key         string
+	RequestHash string
+	StatusCode  int
+	Headers     map[string]string
+	Body        []byte
+	CreatedAt   time.Time
+	CompletedAt *time.Time
+}
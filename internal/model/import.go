@@ -0,0 +1,24 @@
+package model
+
+// MaxImportItems is the largest number of cats or missions accepted by one import
+const MaxImportItems = 1000
+
+// ImportRow is one record of a bulk import together with the problems found while decoding it
+type ImportRow[T any] struct {
+	Value  T
+	Errors []string
+}
+
+// ImportReport tells which records of a bulk import were created and why the others were not
+type ImportReport struct {
+	Created int                `json:"created"`
+	Failed  int                `json:"failed"`
+	Items   []ImportItemResult `json:"items"`
+}
+
+// ImportItemResult is the outcome of one record; Item is its 1-based position in the input
+type ImportItemResult struct {
+	Item   int      `json:"item"`
+	ID     uint     `json:"id,omitempty"`
+	Errors []string `json:"errors,omitempty"`
+}
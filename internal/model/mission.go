@@ -4,27 +4,65 @@ import (
 	"time"
 )
 
+type MissionStatus string
+
+const (
+	MissionStatusDraft      MissionStatus = "draft"
+	MissionStatusAssigned   MissionStatus = "assigned"
+	MissionStatusInProgress MissionStatus = "in_progress"
+	MissionStatusCompleted  MissionStatus = "completed"
+	MissionStatusAborted    MissionStatus = "aborted"
+)
+
+// IsFinal reports whether no further changes are allowed in this status
+func (s MissionStatus) IsFinal() bool {
+	return s == MissionStatusCompleted || s == MissionStatusAborted
+}
+
 type Mission struct {
-	ID        uint      `json:"id" gorm:"primaryKey"`
-	Name      string    `json:"name" gorm:"not null"`
-	CatID     uint      `json:"cat_id" gorm:"not null"`
-	Cat       Cat       `json:"cat" gorm:"foreignKey:CatID"`
-	Targets   []Target  `json:"targets" gorm:"foreignKey:MissionID"`
-	Completed bool      `json:"completed" gorm:"default:false"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        uint          `json:"id" gorm:"primaryKey"`
+	Name      string        `json:"name" gorm:"not null"`
+	CatID     *uint         `json:"cat_id"`
+	Cat       *Cat          `json:"cat,omitempty" gorm:"foreignKey:CatID"`
+	Targets   []Target      `json:"targets,omitempty" gorm:"foreignKey:MissionID"`
+	Status    MissionStatus `json:"status" gorm:"default:draft"`
+	Version   int           `json:"version"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+	DeletedAt *time.Time    `json:"deleted_at,omitempty"`
+}
+
+// MissionInclude selects the relations loaded into Mission.Cat and Mission.Targets
+type MissionInclude struct {
+	Cat     bool
+	Targets bool
 }
 
 type MissionCreate struct {
 	Name    string         `json:"name" binding:"required"`
-	CatID   uint           `json:"cat_id" binding:"required"`
+	CatID   *uint          `json:"cat_id"`
 	Targets []TargetCreate `json:"targets" binding:"required,min=1,max=3,dive"`
 }
 
 type MissionUpdate struct {
-	Completed bool `json:"completed"`
+	Status MissionStatus `json:"status" binding:"required,oneof=draft assigned in_progress completed aborted"`
+}
+
+type MissionFilter struct {
+	ListParams
+	Status    MissionStatus `form:"status" binding:"omitempty,oneof=draft assigned in_progress completed aborted"`
+	Completed *bool         `form:"completed"`
+	CatID     *uint         `form:"cat_id"`
+	Country   string        `form:"country"`
+}
+
+type MissionPage struct {
+	Items []Mission `json:"items"`
+	PageInfo
 }
 
 type CatAssign struct {
 	CatID uint `json:"cat_id" binding:"required"`
+	// Reason is recorded on the assignment of the cat being replaced
+	Reason string `json:"reason" binding:"max=255"`
 }
@@ -0,0 +1,19 @@
+package model
+
+// DefaultPageLimit is the page size used when a list request does not specify one
+const DefaultPageLimit = 20
+
+// ListParams are the pagination and sorting query parameters shared by list endpoints.
+// Sort names a column, prefixed with "-" for descending order.
+type ListParams struct {
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Cursor string `form:"cursor"`
+	Sort   string `form:"sort"`
+}
+
+// PageInfo is embedded into the response envelope of list endpoints
+type PageInfo struct {
+	Total      int    `json:"total"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	Next       string `json:"next,omitempty"`
+}
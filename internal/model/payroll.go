@@ -0,0 +1,41 @@
+package model
+
+import (
+	"time"
+)
+
+// SalaryChange is an entry of the salary ledger of a cat. The salary applies from EffectiveFrom
+// (YYYY-MM-DD) until the next entry; cats are not paid before their first entry.
+type SalaryChange struct {
+	ID            uint      `json:"id"`
+	CatID         uint      `json:"cat_id"`
+	Salary        float64   `json:"salary"`
+	EffectiveFrom string    `json:"effective_from"`
+	ChangedBy     string    `json:"changed_by"`
+	Reason        string    `json:"reason,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// PayrollRun is the pay of every cat for one month. Salaries are monthly and prorated by day.
+type PayrollRun struct {
+	ID          uint          `json:"id"`
+	Period      string        `json:"period"`
+	PeriodStart string        `json:"period_start"`
+	PeriodEnd   string        `json:"period_end"`
+	Total       float64       `json:"total"`
+	CreatedBy   string        `json:"created_by"`
+	CreatedAt   time.Time     `json:"created_at"`
+	Lines       []PayrollLine `json:"lines,omitempty"`
+}
+
+type PayrollLine struct {
+	CatID    uint    `json:"cat_id"`
+	CatName  string  `json:"cat_name"`
+	DaysPaid int     `json:"days_paid"`
+	Amount   float64 `json:"amount"`
+}
+
+type PayrollRunCreate struct {
+	// Period is the month to pay, as YYYY-MM
+	Period string `json:"period" binding:"required,datetime=2006-01"`
+}
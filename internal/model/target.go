@@ -5,14 +5,16 @@ import (
 )
 
 type Target struct {
-	ID        uint      `json:"id" gorm:"primaryKey"`
-	MissionID uint      `json:"mission_id" gorm:"not null"`
-	Name      string    `json:"name" gorm:"not null"`
-	Country   string    `json:"country" gorm:"not null"`
-	Notes     string    `json:"notes"`
-	Completed bool      `json:"completed" gorm:"default:false"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	MissionID uint       `json:"mission_id" gorm:"not null"`
+	Name      string     `json:"name" gorm:"not null"`
+	Country   string     `json:"country" gorm:"not null"`
+	Notes     string     `json:"notes"`
+	Completed bool       `json:"completed" gorm:"default:false"`
+	Version   int        `json:"version"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
 
 type TargetCreate struct {
@@ -0,0 +1,41 @@
+package model
+
+import (
+	"time"
+)
+
+// TargetNote is one revision of the notes of a target. Revisions are numbered from 1 and never change.
+type TargetNote struct {
+	TargetID  uint      `json:"target_id"`
+	Revision  int       `json:"revision"`
+	Content   string    `json:"content"`
+	Author    string    `json:"author"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// DiffOp tells whether a line of a diff is kept, removed or added
+type DiffOp string
+
+const (
+	DiffOpEqual  DiffOp = "equal"
+	DiffOpDelete DiffOp = "delete"
+	DiffOpInsert DiffOp = "insert"
+)
+
+type DiffLine struct {
+	Op   DiffOp `json:"op"`
+	Text string `json:"text"`
+}
+
+// TargetNotesDiff compares two revisions of the notes of a target line by line. Revision 0 is the empty note.
+type TargetNotesDiff struct {
+	TargetID uint       `json:"target_id"`
+	From     int        `json:"from"`
+	To       int        `json:"to"`
+	Lines    []DiffLine `json:"lines"`
+}
+
+type TargetNotesDiffQuery struct {
+	From *int `form:"from" binding:"omitempty,min=0"`
+	To   *int `form:"to" binding:"omitempty,min=0"`
+}
@@ -0,0 +1,35 @@
+package model
+
+import (
+	"time"
+)
+
+type User struct {
+	ID           uint      `json:"id"`
+	Username     string    `json:"username"`
+	PasswordHash string    `json:"-"`
+	Role         string    `json:"role"`
+	CatID        *uint     `json:"cat_id,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// UserCreate creates an account. Accounts with the cat role act as the cat given by cat_id.
+type UserCreate struct {
+	Username string `json:"username" binding:"required,max=255"`
+	Password string `json:"password" binding:"required,min=8,max=72"`
+	Role     string `json:"role" binding:"required,oneof=admin handler cat"`
+	CatID    *uint  `json:"cat_id"`
+}
+
+type Credentials struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// Token is an access token issued by POST /api/auth/token
+type Token struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+}
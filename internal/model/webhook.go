@@ -0,0 +1,133 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// EventType names a domain event delivered to webhooks
+type EventType string
+
+const (
+	EventMissionCreated   EventType = "mission.created"
+	EventMissionAssigned  EventType = "mission.assigned"
+	EventMissionStarted   EventType = "mission.started"
+	EventMissionCompleted EventType = "mission.completed"
+	EventMissionAborted   EventType = "mission.aborted"
+	EventMissionDeleted   EventType = "mission.deleted"
+	EventTargetCreated    EventType = "target.created"
+	EventTargetUpdated    EventType = "target.updated"
+	EventTargetDeleted    EventType = "target.deleted"
+	EventCatCreated       EventType = "cat.created"
+	EventCatSalaryChanged EventType = "cat.salary_changed"
+	EventCatDeleted       EventType = "cat.deleted"
+)
+
+// EventTypes lists every event type, in the order they are documented
+var EventTypes = []EventType{
+	EventMissionCreated, EventMissionAssigned, EventMissionStarted, EventMissionCompleted, EventMissionAborted,
+	EventMissionDeleted,
+	EventTargetCreated, EventTargetUpdated, EventTargetDeleted,
+	EventCatCreated, EventCatSalaryChanged, EventCatDeleted,
+}
+
+// EventScope names the mission and cat an event concerns, so that event streams can be filtered by them
+type EventScope struct {
+	MissionID *uint `json:"mission_id,omitempty"`
+	CatID     *uint `json:"cat_id,omitempty"`
+}
+
+// Event is a domain event stored in the outbox by the transaction of the change it describes
+type Event struct {
+	ID       uint64    `json:"id"`
+	Type     EventType `json:"type"`
+	Entity   string    `json:"entity"`
+	EntityID uint      `json:"entity_id"`
+	EventScope
+	Data      json.RawMessage `json:"data" swaggertype:"object"`
+	RequestID string          `json:"request_id,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// Webhook receives the events it subscribed to, or every event when Events is empty
+type Webhook struct {
+	ID        uint       `json:"id"`
+	URL       string     `json:"url"`
+	Secret    string     `json:"-"`
+	Events    []string   `json:"events"`
+	CreatedBy string     `json:"created_by"`
+	CreatedAt time.Time  `json:"created_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+type WebhookCreate struct {
+	URL    string   `json:"url" binding:"required,url,max=2048"`
+	Events []string `json:"events"`
+}
+
+// WebhookSecret is returned once, when the webhook is registered. Deliveries are signed with Secret.
+type WebhookSecret struct {
+	Webhook
+	Secret string `json:"secret"`
+}
+
+// DeliveryStatus is the state of the delivery of an event to a webhook
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	// DeliveryDead is a delivery that failed every attempt; it stays in the dead-letter list until retried
+	DeliveryDead DeliveryStatus = "dead"
+)
+
+type WebhookDelivery struct {
+	ID             uint64         `json:"id"`
+	WebhookID      uint           `json:"webhook_id"`
+	EventID        uint64         `json:"event_id"`
+	EventType      EventType      `json:"event_type"`
+	Status         DeliveryStatus `json:"status"`
+	Attempts       int            `json:"attempts"`
+	NextAttemptAt  time.Time      `json:"next_attempt_at"`
+	LastStatusCode *int           `json:"last_status_code,omitempty"`
+	LastError      string         `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time     `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time      `json:"created_at"`
+}
+
+type WebhookDeliveryFilter struct {
+	ListParams
+	Status    DeliveryStatus `form:"status" binding:"omitempty,oneof=pending delivered dead"`
+	WebhookID *uint          `form:"webhook_id"`
+}
+
+type WebhookDeliveryPage struct {
+	Items []WebhookDelivery `json:"items"`
+	PageInfo
+}
+
+// DeliveryJob is a delivery claimed by the webhook worker with everything needed to send it
+type DeliveryJob struct {
+	DeliveryID uint64
+	Attempt    int
+	Webhook    Webhook
+	Event      Event
+}
+
+// EventStreamFilter selects the events of a live stream. LastEventID resumes the stream after that event.
+type EventStreamFilter struct {
+	MissionID   *uint   `form:"mission_id"`
+	CatID       *uint   `form:"cat_id"`
+	LastEventID *uint64 `form:"last_event_id"`
+}
+
+// Matches reports whether the event is within the mission and cat of the filter
+func (f EventStreamFilter) Matches(event Event) bool {
+	if f.MissionID != nil && (event.MissionID == nil || *event.MissionID != *f.MissionID) {
+		return false
+	}
+	if f.CatID != nil && (event.CatID == nil || *event.CatID != *f.CatID) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,17 @@
+package repository
+
+import "context"
+
+// includeDeletedKey is a private struct used as the key for the include-deleted read option in context
+type includeDeletedKey struct{}
+
+// WithDeleted makes reads performed with the returned context also return soft-deleted rows
+func WithDeleted(ctx context.Context) context.Context {
+	return context.WithValue(ctx, includeDeletedKey{}, true)
+}
+
+// DeletedIncluded reports whether reads with this context should return soft-deleted rows
+func DeletedIncluded(ctx context.Context) bool {
+	included, _ := ctx.Value(includeDeletedKey{}).(bool)
+	return included
+}
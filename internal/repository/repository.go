@@ -3,29 +3,145 @@ package repository
 import (
 	"SpyCatAgency/internal/model"
 	"context"
+	"errors"
+	"time"
 )
 
+// ErrCatUnavailable is returned when saving a mission would give a cat a second active mission
+var ErrCatUnavailable = errors.New("cat already has an active mission")
+
+// TxManager runs a unit of work atomically. Repository calls made with the context
+// passed to fn share a single transaction that is committed only if fn returns nil.
+type TxManager interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// Delete methods soft-delete rows: they are hidden from reads unless the context was built with
+// WithDeleted, can be brought back with Restore and are removed for good by Purge once they are
+// older than the retention window.
+
 type CatRepository interface {
 	Create(ctx context.Context, cat *model.Cat) error
 	Update(ctx context.Context, cat *model.Cat) error
-	Delete(ctx context.Context, id uint) error
+	Delete(ctx context.Context, id uint, version int) error
+	Restore(ctx context.Context, id uint) error
+	Purge(ctx context.Context, retention time.Duration) (int64, error)
 	GetByID(ctx context.Context, id uint) (*model.Cat, error)
-	List(ctx context.Context) ([]model.Cat, error)
+	ListByIDs(ctx context.Context, ids []uint) ([]model.Cat, error)
+	List(ctx context.Context, filter model.CatFilter) (*model.CatPage, error)
 }
 
 type MissionRepository interface {
 	Create(ctx context.Context, mission *model.Mission) error
 	Update(ctx context.Context, mission *model.Mission) error
-	Delete(ctx context.Context, id uint) error
+	Delete(ctx context.Context, id uint, version int) error
+	Restore(ctx context.Context, id uint) error
+	Purge(ctx context.Context, retention time.Duration) (int64, error)
 	GetByID(ctx context.Context, id uint) (*model.Mission, error)
-	List(ctx context.Context) ([]model.Mission, error)
-	AssignCat(ctx context.Context, missionID, catID uint) error
+	GetByIDForUpdate(ctx context.Context, id uint) (*model.Mission, error)
+	List(ctx context.Context, filter model.MissionFilter) (*model.MissionPage, error)
+	FindActiveByCatID(ctx context.Context, catID uint) (*model.Mission, error)
+	ListByCatIDs(ctx context.Context, catIDs []uint) ([]model.Mission, error)
 }
 
 type TargetRepository interface {
 	Create(ctx context.Context, target *model.Target) error
 	Update(ctx context.Context, target *model.Target) error
-	Delete(ctx context.Context, id uint) error
+	Delete(ctx context.Context, id uint, version int) error
+	DeleteByMissionID(ctx context.Context, missionID uint) error
+	Restore(ctx context.Context, id uint) error
+	RestoreByMissionID(ctx context.Context, missionID uint) error
+	Purge(ctx context.Context, retention time.Duration) (int64, error)
 	GetByID(ctx context.Context, id uint) (*model.Target, error)
 	ListByMissionID(ctx context.Context, missionID uint) ([]model.Target, error)
+	ListByMissionIDs(ctx context.Context, missionIDs []uint) ([]model.Target, error)
+}
+
+// TargetNoteRepository stores the notes history of targets. It is append-only: revisions are never changed,
+// and they are removed only together with their target.
+type TargetNoteRepository interface {
+	Append(ctx context.Context, note *model.TargetNote) error
+	ListByTargetID(ctx context.Context, targetID uint) ([]model.TargetNote, error)
+	GetRevision(ctx context.Context, targetID uint, revision int) (*model.TargetNote, error)
+	LatestRevision(ctx context.Context, targetID uint) (int, error)
+}
+
+// AssignmentRepository stores the history of cats assigned to missions. A mission has at most one open assignment.
+type AssignmentRepository interface {
+	Open(ctx context.Context, assignment *model.Assignment) error
+	Release(ctx context.Context, missionID uint, reason string) error
+	ListByMissionID(ctx context.Context, missionID uint) ([]model.Assignment, error)
+	ListByCatID(ctx context.Context, catID uint) ([]model.Assignment, error)
+}
+
+// SalaryRepository stores the salary ledger of cats. Entries are never changed.
+type SalaryRepository interface {
+	Create(ctx context.Context, change *model.SalaryChange) error
+	ListByCatID(ctx context.Context, catID uint) ([]model.SalaryChange, error)
+	ListEffectiveBy(ctx context.Context, date string) ([]model.SalaryChange, error)
+}
+
+// PayrollRepository stores payroll runs with their lines. There is at most one run per month.
+type PayrollRepository interface {
+	Create(ctx context.Context, run *model.PayrollRun) error
+	GetByID(ctx context.Context, id uint) (*model.PayrollRun, error)
+	List(ctx context.Context) ([]model.PayrollRun, error)
+	LastPeriodEnd(ctx context.Context) (string, error)
+}
+
+// OutboxRepository stores domain events. Create takes part in the transaction of the change the event describes.
+// Dispatch turns a batch of new events into deliveries for the subscribed webhooks; concurrent callers skip
+// each other's batches.
+type OutboxRepository interface {
+	Create(ctx context.Context, event *model.Event) error
+	Dispatch(ctx context.Context, limit int) (int64, error)
+	// ListAfter returns events with an id above afterID in id order. It stops before a gap in the ids
+	// younger than settle, since the missing event may belong to a transaction that has not committed yet.
+	ListAfter(ctx context.Context, afterID uint64, limit int, settle time.Duration) ([]model.Event, error)
+	LastID(ctx context.Context) (uint64, error)
+}
+
+// WebhookRepository stores webhooks and the deliveries of events to them. ClaimDue leases due deliveries to
+// one worker: they are not claimed again until the lease ends, so a crashed worker's deliveries are retried.
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook *model.Webhook) error
+	List(ctx context.Context) ([]model.Webhook, error)
+	GetByID(ctx context.Context, id uint) (*model.Webhook, error)
+	Delete(ctx context.Context, id uint) error
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]model.DeliveryJob, error)
+	MarkDelivered(ctx context.Context, id uint64, statusCode int) error
+	MarkFailed(ctx context.Context, id uint64, statusCode *int, reason string, retryAt *time.Time) error
+	ListDeliveries(ctx context.Context, filter model.WebhookDeliveryFilter) (*model.WebhookDeliveryPage, error)
+	Retry(ctx context.Context, id uint64) error
+}
+
+// AuditRepository stores the audit log. Create takes part in the transaction of the audited change.
+type AuditRepository interface {
+	Create(ctx context.Context, entry *model.AuditEntry) error
+	List(ctx context.Context, filter model.AuditFilter) (*model.AuditPage, error)
+}
+
+type UserRepository interface {
+	Create(ctx context.Context, user *model.User) error
+	GetByUsername(ctx context.Context, username string) (*model.User, error)
+}
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *model.APIKey) error
+	GetByID(ctx context.Context, id uint) (*model.APIKey, error)
+	GetByPrefix(ctx context.Context, prefix string) (*model.APIKey, error)
+	List(ctx context.Context) ([]model.APIKey, error)
+	Rotate(ctx context.Context, key *model.APIKey) error
+	Revoke(ctx context.Context, id uint) error
+	TouchLastUsed(ctx context.Context, id uint) error
+}
+
+// IdempotencyRepository stores responses by idempotency key. Claim atomically takes a key that is new,
+// expired or abandoned by a request that never completed, and otherwise returns the existing record.
+// The CreatedAt set by Claim identifies the claim: Complete and Release only apply to the claim still holding the key.
+type IdempotencyRepository interface {
+	Claim(ctx context.Context, record *model.IdempotencyRecord, window, abandonAfter time.Duration) (*model.IdempotencyRecord, error)
+	Complete(ctx context.Context, record *model.IdempotencyRecord) error
+	Release(ctx context.Context, claim *model.IdempotencyRecord) error
+	DeleteExpired(ctx context.Context, window time.Duration) (int64, error)
 }
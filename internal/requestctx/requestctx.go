@@ -0,0 +1,47 @@
+package requestctx
+
+import "context"
+
+// requestIDKey is a private struct used as the key for the request id in context
+type requestIDKey struct{}
+
+// actorKey is a private struct used as the key for the acting user in context
+type actorKey struct{}
+
+// WithRequestID returns a context carrying the id generated for the current request
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the id of the current request, or an empty string outside a request
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// WithActor returns a context carrying the name of whoever performs the request
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// Actor returns the name of whoever performs the request, or an empty string if unknown
+func Actor(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
+
+// shutdownKey is a private struct used as the key for the server shutdown signal in context
+type shutdownKey struct{}
+
+// WithShutdown returns a context carrying a channel closed when the server starts shutting down
+func WithShutdown(ctx context.Context, shuttingDown <-chan struct{}) context.Context {
+	return context.WithValue(ctx, shutdownKey{}, shuttingDown)
+}
+
+// ShuttingDown returns a channel closed when the server starts shutting down. Long-lived requests such as event
+// streams must end on it, as shutdown waits for running requests without cancelling them. Outside a server the
+// channel is nil and never ready.
+func ShuttingDown(ctx context.Context) <-chan struct{} {
+	ch, _ := ctx.Value(shutdownKey{}).(<-chan struct{})
+	return ch
+}
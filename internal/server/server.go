@@ -4,12 +4,11 @@ import (
 	"SpyCatAgency/internal/config"
 	"SpyCatAgency/internal/logger"
 	"SpyCatAgency/internal/middleware"
+	"SpyCatAgency/internal/requestctx"
 	"context"
 	"fmt"
+	"net"
 	"net/http"
-	"os"
-	"os/signal"
-	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -22,7 +21,14 @@ type Server struct {
 
 func NewServer(cfg *config.Config) *Server {
 	router := gin.New()
+
+	// Client IPs are taken from X-Forwarded-For only when the request comes through a trusted proxy
+	if err := router.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		logger.Fatal(context.Background(), fmt.Errorf("invalid TRUSTED_PROXIES: %w", err))
+	}
+
 	router.Use(middleware.Logger())
+	router.Use(middleware.ErrorHandler())
 	router.Use(gin.Recovery())
 
 	return &Server{
@@ -31,12 +37,18 @@ func NewServer(cfg *config.Config) *Server {
 	}
 }
 
+// Run serves requests until ctx is cancelled, then shuts the server down gracefully
 func (s *Server) Run(ctx context.Context) {
-
+	// Shutdown waits for running requests without cancelling them; event streams end on this signal instead
+	shuttingDown := make(chan struct{})
 	server := &http.Server{
 		Addr:    s.cfg.AppPort,
 		Handler: s.Router,
+		BaseContext: func(net.Listener) context.Context {
+			return requestctx.WithShutdown(context.Background(), shuttingDown)
+		},
 	}
+	server.RegisterOnShutdown(func() { close(shuttingDown) })
 
 	go func() {
 		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -44,21 +56,19 @@ func (s *Server) Run(ctx context.Context) {
 		}
 	}()
 
-	waitForSignal(ctx, server)
+	<-ctx.Done()
+	shutdown(ctx, server)
 }
 
-func waitForSignal(ctx context.Context, srv *http.Server) {
-
-	quit := make(chan os.Signal, 1)
-
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
+// shutdown stops accepting requests and waits up to 5 seconds for running ones before closing their connections
+func shutdown(ctx context.Context, srv *http.Server) {
 	logger.Info(ctx, "Shutdown Server ...")
 
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
 	defer cancel()
 	if err := srv.Shutdown(ctx); err != nil {
-		logger.Fatal(ctx, fmt.Errorf("Server forced to shutdown: %w", err))
+		logger.Error(ctx, fmt.Errorf("server forced to shutdown: %w", err))
+		_ = srv.Close()
 	}
 
 	logger.Info(ctx, "Server exiting")
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"SpyCatAgency/internal/apperror"
+	"SpyCatAgency/internal/auth"
+	"SpyCatAgency/internal/model"
+	"SpyCatAgency/internal/repository"
+	"context"
+)
+
+// authorize returns the caller of the request if it has the permission.
+// Reads that include soft-deleted rows additionally need auth.PermDeletedRead.
+func authorize(ctx context.Context, perm auth.Permission) (*auth.Principal, error) {
+	p := auth.PrincipalFrom(ctx)
+	if p == nil {
+		return nil, apperror.Unauthorized("authentication required")
+	}
+
+	if !p.Can(perm) {
+		return nil, apperror.Forbidden("%s is not allowed to perform this operation", p.Name).
+			WithDetail("permission", perm)
+	}
+
+	if repository.DeletedIncluded(ctx) && !p.Can(auth.PermDeletedRead) {
+		return nil, apperror.Forbidden("%s is not allowed to see deleted records", p.Name).
+			WithDetail("permission", auth.PermDeletedRead)
+	}
+
+	return p, nil
+}
+
+// ensureOwnsMission fails unless the principal may act on the mission: cats only on their own
+func ensureOwnsMission(p *auth.Principal, mission *model.Mission) error {
+	if p.Role != auth.RoleCat {
+		return nil
+	}
+	if p.CatID != nil && mission.CatID != nil && *p.CatID == *mission.CatID {
+		return nil
+	}
+	return apperror.Forbidden("mission %d is not assigned to %s", mission.ID, p.Name)
+}
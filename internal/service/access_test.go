@@ -0,0 +1,303 @@
+package service
+
+import (
+	"SpyCatAgency/internal/apperror"
+	"SpyCatAgency/internal/auth"
+	"SpyCatAgency/internal/model"
+	"SpyCatAgency/internal/repository"
+	"context"
+	"errors"
+	"testing"
+)
+
+func catPrincipal(catID uint) *auth.Principal {
+	return &auth.Principal{Subject: "3", Name: "tom", Role: auth.RoleCat, CatID: &catID}
+}
+
+var (
+	adminPrincipal   = &auth.Principal{Subject: "1", Name: "admin", Role: auth.RoleAdmin}
+	handlerPrincipal = &auth.Principal{Subject: "2", Name: "handler", Role: auth.RoleHandler}
+	unknownPrincipal = &auth.Principal{Subject: "4", Name: "spy", Role: auth.Role("spy")}
+)
+
+// checkErr fails the test unless err is of the wanted kind, or nil when want is nil
+func checkErr(t *testing.T, err, want error) {
+	t.Helper()
+	if want == nil {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return
+	}
+	if !errors.Is(err, want) {
+		t.Fatalf("got error %v, want %v", err, want)
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal *auth.Principal
+		perm      auth.Permission
+		deleted   bool
+		want      error
+	}{
+		{name: "anonymous", perm: auth.PermMissionsRead, want: apperror.ErrUnauthorized},
+		{name: "unknown role", principal: unknownPrincipal, perm: auth.PermMissionsRead, want: apperror.ErrForbidden},
+		{name: "admin writes cats", principal: adminPrincipal, perm: auth.PermCatsWrite},
+		{name: "admin reads deleted", principal: adminPrincipal, perm: auth.PermCatsRead, deleted: true},
+		{name: "admin manages webhooks", principal: adminPrincipal, perm: auth.PermWebhooksWrite},
+		{name: "admin reads metrics", principal: adminPrincipal, perm: auth.PermMetricsRead},
+		{name: "handler writes missions", principal: handlerPrincipal, perm: auth.PermMissionsWrite},
+		{name: "handler reads cats", principal: handlerPrincipal, perm: auth.PermCatsRead},
+		{name: "handler writes cats", principal: handlerPrincipal, perm: auth.PermCatsWrite, want: apperror.ErrForbidden},
+		{name: "handler reads deleted", principal: handlerPrincipal, perm: auth.PermMissionsRead, deleted: true, want: apperror.ErrForbidden},
+		{name: "handler reads payroll", principal: handlerPrincipal, perm: auth.PermPayrollRead, want: apperror.ErrForbidden},
+		{name: "cat reads missions", principal: catPrincipal(1), perm: auth.PermMissionsRead},
+		{name: "cat updates targets", principal: catPrincipal(1), perm: auth.PermTargetsWrite},
+		{name: "cat writes missions", principal: catPrincipal(1), perm: auth.PermMissionsWrite, want: apperror.ErrForbidden},
+		{name: "cat reads cats", principal: catPrincipal(1), perm: auth.PermCatsRead, want: apperror.ErrForbidden},
+		{name: "cat reads audit log", principal: catPrincipal(1), perm: auth.PermAuditRead, want: apperror.ErrForbidden},
+		{name: "cat reads metrics", principal: catPrincipal(1), perm: auth.PermMetricsRead, want: apperror.ErrForbidden},
+		{
+			name:      "API key limited to its scopes",
+			principal: &auth.Principal{Name: "ci", Role: auth.RoleAdmin, Scopes: []auth.Permission{auth.PermCatsRead}},
+			perm:      auth.PermCatsWrite,
+			want:      apperror.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.principal != nil {
+				ctx = auth.WithPrincipal(ctx, tt.principal)
+			}
+			if tt.deleted {
+				ctx = repository.WithDeleted(ctx)
+			}
+
+			p, err := authorize(ctx, tt.perm)
+			checkErr(t, err, tt.want)
+			if err == nil && p != tt.principal {
+				t.Fatalf("got principal %v, want %v", p, tt.principal)
+			}
+		})
+	}
+}
+
+func TestEnsureOwnsMission(t *testing.T) {
+	catID := uint(1)
+
+	tests := []struct {
+		name      string
+		principal *auth.Principal
+		mission   *model.Mission
+		want      error
+	}{
+		{name: "admin", principal: adminPrincipal, mission: &model.Mission{ID: 1, CatID: &catID}},
+		{name: "handler", principal: handlerPrincipal, mission: &model.Mission{ID: 1, CatID: &catID}},
+		{name: "handler on draft", principal: handlerPrincipal, mission: &model.Mission{ID: 1}},
+		{name: "own cat", principal: catPrincipal(1), mission: &model.Mission{ID: 1, CatID: &catID}},
+		{name: "other cat", principal: catPrincipal(2), mission: &model.Mission{ID: 1, CatID: &catID}, want: apperror.ErrForbidden},
+		{name: "cat on draft", principal: catPrincipal(1), mission: &model.Mission{ID: 1}, want: apperror.ErrForbidden},
+		{
+			name:      "cat account without cat",
+			principal: &auth.Principal{Name: "stray", Role: auth.RoleCat},
+			mission:   &model.Mission{ID: 1, CatID: &catID},
+			want:      apperror.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkErr(t, ensureOwnsMission(tt.principal, tt.mission), tt.want)
+		})
+	}
+}
+
+// accessTx runs the unit of work without a database
+type accessTx struct{}
+
+func (accessTx) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// accessMissions serves a single mission of cat 1; other methods are not used by the tests
+type accessMissions struct {
+	repository.MissionRepository
+	mission model.Mission
+}
+
+func (r *accessMissions) GetByID(_ context.Context, id uint) (*model.Mission, error) {
+	if id != r.mission.ID {
+		return nil, apperror.NotFound("mission %d not found", id)
+	}
+	mission := r.mission
+	return &mission, nil
+}
+
+func (r *accessMissions) GetByIDForUpdate(ctx context.Context, id uint) (*model.Mission, error) {
+	return r.GetByID(ctx, id)
+}
+
+// accessTargets serves a single target of the mission
+type accessTargets struct {
+	repository.TargetRepository
+	target model.Target
+}
+
+func (r *accessTargets) GetByID(_ context.Context, id uint) (*model.Target, error) {
+	if id != r.target.ID {
+		return nil, apperror.NotFound("target %d not found", id)
+	}
+	target := r.target
+	return &target, nil
+}
+
+func newAccessMissionService() *MissionService {
+	catID := uint(1)
+	missions := &accessMissions{mission: model.Mission{ID: 10, CatID: &catID, Status: model.MissionStatusInProgress, Version: 1}}
+	targets := &accessTargets{target: model.Target{ID: 20, MissionID: 10, Version: 1}}
+	return NewMissionService(accessTx{}, missions, targets, nil, nil, nil, nil, nil)
+}
+
+func TestMissionServiceGetByIDAccess(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal *auth.Principal
+		want      error
+	}{
+		{name: "anonymous", want: apperror.ErrUnauthorized},
+		{name: "unknown role", principal: unknownPrincipal, want: apperror.ErrForbidden},
+		{name: "admin", principal: adminPrincipal},
+		{name: "handler", principal: handlerPrincipal},
+		{name: "own cat", principal: catPrincipal(1)},
+		{name: "other cat", principal: catPrincipal(2), want: apperror.ErrForbidden},
+	}
+
+	s := newAccessMissionService()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.principal != nil {
+				ctx = auth.WithPrincipal(ctx, tt.principal)
+			}
+
+			mission, err := s.GetByID(ctx, 10, &model.MissionInclude{})
+			checkErr(t, err, tt.want)
+			if err == nil && mission.ID != 10 {
+				t.Fatalf("got mission %d, want 10", mission.ID)
+			}
+		})
+	}
+}
+
+func TestMissionServiceUpdateTargetDenied(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal *auth.Principal
+		want      error
+	}{
+		{name: "anonymous", want: apperror.ErrUnauthorized},
+		{name: "unknown role", principal: unknownPrincipal, want: apperror.ErrForbidden},
+		{name: "other cat", principal: catPrincipal(2), want: apperror.ErrForbidden},
+		{name: "cat account without cat", principal: &auth.Principal{Name: "stray", Role: auth.RoleCat}, want: apperror.ErrForbidden},
+	}
+
+	s := newAccessMissionService()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.principal != nil {
+				ctx = auth.WithPrincipal(ctx, tt.principal)
+			}
+
+			_, err := s.UpdateTarget(ctx, 20, 0, model.TargetUpdate{Notes: "compromised"})
+			checkErr(t, err, tt.want)
+		})
+	}
+}
+
+// accessAPIKeys stores created keys without a database
+type accessAPIKeys struct {
+	repository.APIKeyRepository
+}
+
+func (accessAPIKeys) Create(_ context.Context, key *model.APIKey) error {
+	key.ID = 1
+	return nil
+}
+
+// accessAudit discards audit entries
+type accessAudit struct {
+	repository.AuditRepository
+}
+
+func (accessAudit) Create(context.Context, *model.AuditEntry) error {
+	return nil
+}
+
+func TestAPIKeyServiceCreateScopes(t *testing.T) {
+	keyCreator := &auth.Principal{Name: "apikey:ops", Scopes: []auth.Permission{auth.PermAPIKeysWrite, auth.PermCatsRead}}
+
+	tests := []struct {
+		name      string
+		principal *auth.Principal
+		scopes    []string
+		want      error
+	}{
+		{name: "anonymous", scopes: []string{string(auth.PermCatsRead)}, want: apperror.ErrUnauthorized},
+		{name: "handler", principal: handlerPrincipal, scopes: []string{string(auth.PermCatsRead)}, want: apperror.ErrForbidden},
+		{name: "admin grants any scope", principal: adminPrincipal, scopes: []string{string(auth.PermUsersWrite), string(auth.PermCatsWrite)}},
+		{name: "admin grants unknown scope", principal: adminPrincipal, scopes: []string{"cats:eat"}, want: apperror.ErrValidation},
+		{name: "key grants its own scopes", principal: keyCreator, scopes: []string{string(auth.PermCatsRead), string(auth.PermAPIKeysWrite)}},
+		{name: "key grants a scope it lacks", principal: keyCreator, scopes: []string{string(auth.PermCatsRead), string(auth.PermUsersWrite)}, want: apperror.ErrForbidden},
+	}
+
+	s := NewAPIKeyService(accessTx{}, accessAPIKeys{}, NewAuditService(accessAudit{}))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.principal != nil {
+				ctx = auth.WithPrincipal(ctx, tt.principal)
+			}
+
+			key, err := s.Create(ctx, model.APIKeyCreate{Name: "ci", Scopes: tt.scopes})
+			checkErr(t, err, tt.want)
+			if err == nil && key.Key == "" {
+				t.Fatal("got no secret for the created key")
+			}
+		})
+	}
+}
+
+func TestCatServiceListBreedsDenied(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal *auth.Principal
+		want      error
+	}{
+		{name: "anonymous", want: apperror.ErrUnauthorized},
+		{name: "unknown role", principal: unknownPrincipal, want: apperror.ErrForbidden},
+		{name: "cat", principal: catPrincipal(1), want: apperror.ErrForbidden},
+		{
+			name:      "API key without cats:read",
+			principal: &auth.Principal{Name: "apikey:ci", Scopes: []auth.Permission{auth.PermMissionsRead}},
+			want:      apperror.ErrForbidden,
+		},
+	}
+
+	s := NewCatService(accessTx{}, nil, nil, nil, nil, nil, nil, BreedPolicyCache)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.principal != nil {
+				ctx = auth.WithPrincipal(ctx, tt.principal)
+			}
+
+			_, err := s.ListBreeds(ctx)
+			checkErr(t, err, tt.want)
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"SpyCatAgency/internal/apperror"
+	"SpyCatAgency/internal/auth"
+	"SpyCatAgency/internal/logger"
+	"SpyCatAgency/internal/model"
+	"SpyCatAgency/internal/repository"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type APIKeyService struct {
+	txManager repository.TxManager
+	repo      repository.APIKeyRepository
+	audit     *AuditService
+}
+
+func NewAPIKeyService(txManager repository.TxManager, repo repository.APIKeyRepository, audit *AuditService) *APIKeyService {
+	return &APIKeyService{
+		txManager: txManager,
+		repo:      repo,
+		audit:     audit,
+	}
+}
+
+// Create issues a new key. The returned secret is the only time the key is shown.
+func (s *APIKeyService) Create(ctx context.Context, create model.APIKeyCreate) (*model.APIKeySecret, error) {
+	p, err := authorize(ctx, auth.PermAPIKeysWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, scope := range create.Scopes {
+		if !auth.ValidPermission(scope) {
+			return nil, apperror.Validation("unknown scope %q", scope).WithDetail("scopes", auth.Permissions)
+		}
+		// A key must not grant more than its creator holds
+		if !p.Can(auth.Permission(scope)) {
+			return nil, apperror.Forbidden("%s cannot grant the %s scope", p.Name, scope).WithDetail("permission", scope)
+		}
+	}
+	if create.ExpiresAt != nil && !create.ExpiresAt.After(time.Now()) {
+		return nil, apperror.Validation("expires_at must be in the future")
+	}
+
+	secret, err := newAPIKeySecret()
+	if err != nil {
+		return nil, err
+	}
+
+	key := &model.APIKey{
+		Name:      create.Name,
+		Prefix:    secret.prefix,
+		KeyHash:   secret.hash,
+		Scopes:    create.Scopes,
+		CreatedBy: p.Name,
+		ExpiresAt: create.ExpiresAt,
+	}
+
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, key); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, model.AuditActionCreate, "api_key", key.ID, nil, key)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.APIKeySecret{APIKey: *key, Key: secret.key}, nil
+}
+
+func (s *APIKeyService) List(ctx context.Context) ([]model.APIKey, error) {
+	if _, err := authorize(ctx, auth.PermAPIKeysWrite); err != nil {
+		return nil, err
+	}
+	return s.repo.List(ctx)
+}
+
+// Rotate replaces the secret of a key, keeping its name, scopes and expiry. The old secret stops working at once.
+func (s *APIKeyService) Rotate(ctx context.Context, id uint) (*model.APIKeySecret, error) {
+	if _, err := authorize(ctx, auth.PermAPIKeysWrite); err != nil {
+		return nil, err
+	}
+
+	secret, err := newAPIKeySecret()
+	if err != nil {
+		return nil, err
+	}
+
+	var key *model.APIKey
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		key, err = s.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		before := *key
+		key.Prefix = secret.prefix
+		key.KeyHash = secret.hash
+
+		if err := s.repo.Rotate(ctx, key); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, model.AuditActionUpdate, "api_key", id, &before, key)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.APIKeySecret{APIKey: *key, Key: secret.key}, nil
+}
+
+func (s *APIKeyService) Revoke(ctx context.Context, id uint) error {
+	if _, err := authorize(ctx, auth.PermAPIKeysWrite); err != nil {
+		return err
+	}
+
+	return s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		key, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.repo.Revoke(ctx, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, model.AuditActionDelete, "api_key", id, key, nil)
+	})
+}
+
+// Authenticate resolves an API key into a principal limited to the scopes of the key
+func (s *APIKeyService) Authenticate(ctx context.Context, raw string) (*auth.Principal, error) {
+	invalid := apperror.Unauthorized("invalid API key")
+
+	prefix, ok := apiKeyPrefixOf(raw)
+	if !ok {
+		return nil, invalid
+	}
+
+	key, err := s.repo.GetByPrefix(ctx, prefix)
+	if errors.Is(err, apperror.ErrNotFound) {
+		return nil, invalid
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashAPIKey(raw)), []byte(key.KeyHash)) != 1 {
+		return nil, invalid
+	}
+	if key.RevokedAt != nil {
+		return nil, apperror.Unauthorized("API key is revoked")
+	}
+	if key.ExpiresAt != nil && !key.ExpiresAt.After(time.Now()) {
+		return nil, apperror.Unauthorized("API key is expired")
+	}
+
+	// A failure to record usage must not fail the request
+	if err := s.repo.TouchLastUsed(ctx, key.ID); err != nil {
+		logger.Error(ctx, fmt.Errorf("failed to record API key usage: %w", err))
+	}
+
+	scopes := make([]auth.Permission, 0, len(key.Scopes))
+	for _, scope := range key.Scopes {
+		scopes = append(scopes, auth.Permission(scope))
+	}
+
+	return &auth.Principal{
+		Subject: "apikey:" + strconv.FormatUint(uint64(key.ID), 10),
+		Name:    "apikey:" + key.Name,
+		Scopes:  scopes,
+	}, nil
+}
+
+// apiKeySecret is a freshly generated key with the parts that are stored
+type apiKeySecret struct {
+	key    string
+	prefix string
+	hash   string
+}
+
+// newAPIKeySecret generates a key of the form sca_<prefix>_<secret>. The prefix identifies the
+// key in storage; the key itself is random enough that a plain SHA-256 hash protects it.
+func newAPIKeySecret() (*apiKeySecret, error) {
+	prefix := make([]byte, 8)
+	secret := make([]byte, 32)
+	if _, err := rand.Read(prefix); err != nil {
+		return nil, fmt.Errorf("failed to generate API key: %w", err)
+	}
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("failed to generate API key: %w", err)
+	}
+
+	k := &apiKeySecret{prefix: hex.EncodeToString(prefix)}
+	k.key = auth.APIKeyPrefix + k.prefix + "_" + base64.RawURLEncoding.EncodeToString(secret)
+	k.hash = hashAPIKey(k.key)
+	return k, nil
+}
+
+func apiKeyPrefixOf(raw string) (string, bool) {
+	rest, ok := strings.CutPrefix(raw, auth.APIKeyPrefix)
+	if !ok {
+		return "", false
+	}
+	prefix, _, ok := strings.Cut(rest, "_")
+	return prefix, ok && prefix != ""
+}
+
+func hashAPIKey(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
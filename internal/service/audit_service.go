@@ -0,0 +1,122 @@
+package service
+
+import (
+	"SpyCatAgency/internal/auth"
+	"SpyCatAgency/internal/model"
+	"SpyCatAgency/internal/repository"
+	"SpyCatAgency/internal/requestctx"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// anonymousActor is recorded for changes made by callers that are not identified
+const anonymousActor = "anonymous"
+
+// auditIgnoredFields change on every write and are left out of the recorded changes
+var auditIgnoredFields = map[string]bool{
+	"version":    true,
+	"updated_at": true,
+}
+
+type AuditService struct {
+	repo repository.AuditRepository
+}
+
+func NewAuditService(repo repository.AuditRepository) *AuditService {
+	return &AuditService{repo: repo}
+}
+
+// actor names the caller of the request for audit records and histories
+func actor(ctx context.Context) string {
+	if name := requestctx.Actor(ctx); name != "" {
+		return name
+	}
+	return anonymousActor
+}
+
+// Record writes an audit entry for a change to an entity. before is nil for creations and after is nil
+// for deletions. It must be called within the transaction of the change so both are saved or neither is.
+func (s *AuditService) Record(
+	ctx context.Context,
+	action model.AuditAction,
+	entity string,
+	entityID uint,
+	before, after any,
+) error {
+	entry := &model.AuditEntry{
+		RequestID: requestctx.RequestID(ctx),
+		Actor:     actor(ctx),
+		Action:    action,
+		Entity:    entity,
+		EntityID:  entityID,
+	}
+
+	var err error
+	if entry.Before, err = auditSnapshot(before); err != nil {
+		return err
+	}
+	if entry.After, err = auditSnapshot(after); err != nil {
+		return err
+	}
+	if entry.Changes, err = auditChanges(entry.Before, entry.After); err != nil {
+		return err
+	}
+
+	return s.repo.Create(ctx, entry)
+}
+
+func (s *AuditService) List(ctx context.Context, filter model.AuditFilter) (*model.AuditPage, error) {
+	if _, err := authorize(ctx, auth.PermAuditRead); err != nil {
+		return nil, err
+	}
+
+	return s.repo.List(ctx, filter)
+}
+
+// auditSnapshot encodes the state of an entity as it is returned by the API
+func auditSnapshot(v any) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return nil, nil
+	}
+
+	doc, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode audit snapshot: %w", err)
+	}
+	return doc, nil
+}
+
+// auditChanges returns the top-level fields that differ between two snapshots
+func auditChanges(before, after json.RawMessage) (map[string]model.AuditChange, error) {
+	if before == nil || after == nil {
+		return nil, nil
+	}
+
+	var from, to map[string]any
+	if err := json.Unmarshal(before, &from); err != nil {
+		return nil, fmt.Errorf("failed to decode audit snapshot: %w", err)
+	}
+	if err := json.Unmarshal(after, &to); err != nil {
+		return nil, fmt.Errorf("failed to decode audit snapshot: %w", err)
+	}
+
+	changes := make(map[string]model.AuditChange)
+	for field := range from {
+		if _, ok := to[field]; !ok {
+			to[field] = nil
+		}
+	}
+	for field, value := range to {
+		if auditIgnoredFields[field] || reflect.DeepEqual(from[field], value) {
+			continue
+		}
+		changes[field] = model.AuditChange{From: from[field], To: value}
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"SpyCatAgency/internal/apperror"
+	"SpyCatAgency/internal/auth"
+	"SpyCatAgency/internal/model"
+	"SpyCatAgency/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// dummyPasswordHash is compared against when the user does not exist, so unknown
+// usernames take as long to reject as wrong passwords
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("spycat"), bcrypt.DefaultCost)
+
+type AuthService struct {
+	users  repository.UserRepository
+	cats   repository.CatRepository
+	tokens *auth.TokenManager
+}
+
+func NewAuthService(users repository.UserRepository, cats repository.CatRepository, tokens *auth.TokenManager) *AuthService {
+	return &AuthService{
+		users:  users,
+		cats:   cats,
+		tokens: tokens,
+	}
+}
+
+// Login checks the credentials and issues an access token for the user
+func (s *AuthService) Login(ctx context.Context, credentials model.Credentials) (*model.Token, error) {
+	user, err := s.users.GetByUsername(ctx, credentials.Username)
+	if err != nil && !errors.Is(err, apperror.ErrNotFound) {
+		return nil, err
+	}
+
+	hash := dummyPasswordHash
+	if user != nil {
+		hash = []byte(user.PasswordHash)
+	}
+	if err := bcrypt.CompareHashAndPassword(hash, []byte(credentials.Password)); err != nil || user == nil {
+		return nil, apperror.Unauthorized("invalid username or password")
+	}
+
+	token, err := s.tokens.Issue(&auth.Principal{
+		Subject: strconv.FormatUint(uint64(user.ID), 10),
+		Name:    user.Username,
+		Role:    auth.Role(user.Role),
+		CatID:   user.CatID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Token{
+		AccessToken: token,
+		TokenType:   "Bearer",
+		ExpiresIn:   int(s.tokens.TTL().Seconds()),
+	}, nil
+}
+
+// CreateUser creates an account. Only admins can create accounts.
+func (s *AuthService) CreateUser(ctx context.Context, create model.UserCreate) (*model.User, error) {
+	if _, err := authorize(ctx, auth.PermUsersWrite); err != nil {
+		return nil, err
+	}
+
+	role := auth.Role(create.Role)
+	if !role.Valid() {
+		return nil, apperror.Validation("unknown role %q", create.Role)
+	}
+	if role == auth.RoleCat && create.CatID == nil {
+		return nil, apperror.Validation("cat_id is required for the cat role")
+	}
+	if role != auth.RoleCat && create.CatID != nil {
+		return nil, apperror.Validation("cat_id is only allowed for the cat role")
+	}
+
+	if create.CatID != nil {
+		if _, err := s.cats.GetByID(ctx, *create.CatID); err != nil {
+			return nil, err
+		}
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(create.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	user := &model.User{
+		Username:     create.Username,
+		PasswordHash: string(hash),
+		Role:         create.Role,
+		CatID:        create.CatID,
+	}
+	if err := s.users.Create(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// EnsureAdmin creates an admin account unless a user with that name already exists.
+// The password and role of an existing user are kept.
+func (s *AuthService) EnsureAdmin(ctx context.Context, username, password string) error {
+	_, err := s.users.GetByUsername(ctx, username)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, apperror.ErrNotFound) {
+		return err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	err = s.users.Create(ctx, &model.User{
+		Username:     username,
+		PasswordHash: string(hash),
+		Role:         string(auth.RoleAdmin),
+	})
+	if errors.Is(err, apperror.ErrConflict) {
+		return nil
+	}
+	return err
+}
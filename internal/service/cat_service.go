@@ -1,74 +1,406 @@
 package service
 
 import (
+	"SpyCatAgency/internal/apperror"
+	"SpyCatAgency/internal/auth"
 	"SpyCatAgency/internal/client"
+	"SpyCatAgency/internal/logger"
 	"SpyCatAgency/internal/model"
 	"SpyCatAgency/internal/repository"
 	"context"
 	"errors"
+	"log/slog"
+)
+
+// BreedPolicy decides what happens to a new cat when its breed cannot be validated
+type BreedPolicy string
+
+const (
+	// BreedPolicyReject fails the request unless a fresh breed catalog is available
+	BreedPolicyReject BreedPolicy = "reject"
+	// BreedPolicyAccept creates the cat with breed_verified=false
+	BreedPolicyAccept BreedPolicy = "accept"
+	// BreedPolicyCache validates against the last good catalog snapshot, however old
+	BreedPolicyCache BreedPolicy = "cache"
 )
 
 type CatService struct {
-	repo   repository.CatRepository
-	catAPI *client.CatAPI
+	txManager   repository.TxManager
+	repo        repository.CatRepository
+	salaries    repository.SalaryRepository
+	payrolls    repository.PayrollRepository
+	audit       *AuditService
+	events      *EventService
+	breeds      *client.BreedCatalog
+	breedPolicy BreedPolicy
 }
 
-func NewCatService(repo repository.CatRepository, catAPI *client.CatAPI) *CatService {
+func NewCatService(
+	txManager repository.TxManager,
+	repo repository.CatRepository,
+	salaries repository.SalaryRepository,
+	payrolls repository.PayrollRepository,
+	audit *AuditService,
+	events *EventService,
+	breeds *client.BreedCatalog,
+	breedPolicy BreedPolicy,
+) *CatService {
 	return &CatService{
-		repo:   repo,
-		catAPI: catAPI,
+		txManager:   txManager,
+		repo:        repo,
+		salaries:    salaries,
+		payrolls:    payrolls,
+		audit:       audit,
+		events:      events,
+		breeds:      breeds,
+		breedPolicy: breedPolicy,
 	}
 }
 
 func (s *CatService) Create(ctx context.Context, catCreate model.CatCreate) (*model.Cat, error) {
+	if _, err := authorize(ctx, auth.PermCatsWrite); err != nil {
+		return nil, err
+	}
 
-	// Validate breed using CatAPI
-	valid, err := s.catAPI.ValidateBreed(ctx, catCreate.Breed)
+	verified, err := s.validateBreed(ctx, catCreate.Breed)
 	if err != nil {
 		return nil, err
 	}
-	if !valid {
-		return nil, errors.New("invalid cat breed")
+
+	cat := newCat(catCreate, verified)
+	if err := s.insert(ctx, cat); err != nil {
+		return nil, err
+	}
+
+	return cat, nil
+}
+
+// Import creates cats in bulk. Every item is validated first, including its breed. In atomic mode any
+// invalid item fails the whole import and the valid ones are created in a single transaction;
+// otherwise each valid item is created on its own and failures are only reported.
+func (s *CatService) Import(
+	ctx context.Context,
+	rows []model.ImportRow[model.CatCreate],
+	atomic bool,
+) (*model.ImportReport, error) {
+	if _, err := authorize(ctx, auth.PermCatsWrite); err != nil {
+		return nil, err
+	}
+	if err := checkImportSize(len(rows)); err != nil {
+		return nil, err
+	}
+
+	report := newImportReport(len(rows))
+	cats := make([]*model.Cat, len(rows))
+	for i, row := range rows {
+		if problems := validateImportRow(row); len(problems) > 0 {
+			report.fail(i, problems...)
+			continue
+		}
+
+		verified, err := s.validateBreed(ctx, row.Value.Breed)
+		if err != nil {
+			problem, err := itemProblem(err)
+			if err != nil {
+				return nil, err
+			}
+			report.fail(i, problem)
+			continue
+		}
+		cats[i] = newCat(row.Value, verified)
+	}
+
+	if atomic {
+		if err := rejectInvalid(report.finish()); err != nil {
+			return nil, err
+		}
+
+		err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+			for _, cat := range cats {
+				if err := s.insert(ctx, cat); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for i, cat := range cats {
+			report.created(i, cat.ID)
+		}
+		return report.finish(), nil
+	}
+
+	for i, cat := range cats {
+		if !report.valid(i) {
+			continue
+		}
+		if err := s.insert(ctx, cat); err != nil {
+			problem, err := itemProblem(err)
+			if err != nil {
+				return nil, err
+			}
+			report.fail(i, problem)
+			continue
+		}
+		report.created(i, cat.ID)
+	}
+
+	return report.finish(), nil
+}
+
+// Export returns all cats matching the filter in the sort order of the filter
+func (s *CatService) Export(ctx context.Context, filter model.CatFilter) ([]model.Cat, error) {
+	if _, err := authorize(ctx, auth.PermCatsRead); err != nil {
+		return nil, err
 	}
 
-	cat := &model.Cat{
+	cats := []model.Cat{}
+	filter.Limit = exportPageSize
+	filter.Cursor = ""
+	for {
+		page, err := s.repo.List(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+
+		cats = append(cats, page.Items...)
+		if page.NextCursor == "" {
+			return cats, nil
+		}
+		filter.Cursor = page.NextCursor
+	}
+}
+
+func newCat(catCreate model.CatCreate, breedVerified bool) *model.Cat {
+	return &model.Cat{
 		Name:            catCreate.Name,
 		YearsExperience: catCreate.YearsExperience,
 		Breed:           catCreate.Breed,
 		Salary:          catCreate.Salary,
+		BreedVerified:   breedVerified,
+	}
+}
+
+// insert stores a new cat, opens its salary ledger, records it in the audit log and publishes cat.created
+func (s *CatService) insert(ctx context.Context, cat *model.Cat) error {
+	return s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, cat); err != nil {
+			return err
+		}
+
+		err := s.salaries.Create(ctx, &model.SalaryChange{
+			CatID:         cat.ID,
+			Salary:        cat.Salary,
+			EffectiveFrom: today(),
+			ChangedBy:     actor(ctx),
+			Reason:        salaryReasonHired,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := s.audit.Record(ctx, model.AuditActionCreate, "cat", cat.ID, nil, cat); err != nil {
+			return err
+		}
+
+		return s.events.Publish(ctx, model.EventCatCreated, "cat", cat.ID, catScope(cat.ID), cat)
+	})
+}
+
+// validateBreed checks the breed against the CatAPI breed catalog and applies the breed policy
+// when the catalog is unavailable. It reports whether the breed was actually verified.
+func (s *CatService) validateBreed(ctx context.Context, breed string) (bool, error) {
+	valid, err := s.breeds.Contains(ctx, breed, s.breedPolicy == BreedPolicyCache)
+	if errors.Is(err, apperror.ErrUpstream) && s.breedPolicy == BreedPolicyAccept {
+		logger.Error(ctx, err, slog.String("breed", breed))
+		return false, nil
+	}
+	if err != nil {
+		return false, err
 	}
 
-	if err = s.repo.Create(ctx, cat); err != nil {
+	if !valid {
+		return false, apperror.Validation("invalid cat breed %q", breed)
+	}
+
+	return true, nil
+}
+
+// Update changes the salary of a cat from the given date on and records the change in the salary ledger.
+// The date cannot be in the future, before the previous change or within a month payroll was already run for.
+// A non-zero expectedVersion must match the current version.
+func (s *CatService) Update(ctx context.Context, id uint, expectedVersion int, update model.CatUpdate) (*model.Cat, error) {
+	if _, err := authorize(ctx, auth.PermCatsWrite); err != nil {
+		return nil, err
+	}
+
+	var cat *model.Cat
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		cat, err = s.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := checkVersion("cat", id, expectedVersion, cat.Version); err != nil {
+			return err
+		}
+
+		change := &model.SalaryChange{
+			CatID:         id,
+			Salary:        update.Salary,
+			EffectiveFrom: update.EffectiveFrom,
+			ChangedBy:     actor(ctx),
+			Reason:        update.Reason,
+		}
+		if err := s.checkEffectiveDate(ctx, change); err != nil {
+			return err
+		}
+
+		before := *cat
+		cat.Salary = update.Salary
+
+		if err := s.repo.Update(ctx, cat); err != nil {
+			return err
+		}
+
+		if err := s.salaries.Create(ctx, change); err != nil {
+			return err
+		}
+
+		if err := s.audit.Record(ctx, model.AuditActionUpdate, "cat", id, &before, cat); err != nil {
+			return err
+		}
+
+		return s.events.Publish(ctx, model.EventCatSalaryChanged, "cat", id, catScope(id), change)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return cat, nil
 }
 
-func (s *CatService) Update(ctx context.Context, id uint, update model.CatUpdate) (*model.Cat, error) {
+// checkEffectiveDate defaults the effective date of a salary change to today and keeps the ledger consistent
+// with the past: changes are recorded in effect order and cannot alter a month that was already paid.
+func (s *CatService) checkEffectiveDate(ctx context.Context, change *model.SalaryChange) error {
+	if change.EffectiveFrom == "" {
+		change.EffectiveFrom = today()
+	}
+	if change.EffectiveFrom > today() {
+		return apperror.Validation("effective_from cannot be in the future")
+	}
 
-	cat, err := s.repo.GetByID(ctx, id)
+	history, err := s.salaries.ListByCatID(ctx, change.CatID)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	if n := len(history); n > 0 && change.EffectiveFrom < history[n-1].EffectiveFrom {
+		return apperror.Validation("effective_from cannot be before the previous salary change on %s",
+			history[n-1].EffectiveFrom)
 	}
 
-	cat.Salary = update.Salary
+	paidUntil, err := s.payrolls.LastPeriodEnd(ctx)
+	if err != nil {
+		return err
+	}
+	if change.EffectiveFrom <= paidUntil {
+		return apperror.Conflict("payroll has already been run until %s", paidUntil).
+			WithDetail("paid_until", paidUntil)
+	}
 
-	if err = s.repo.Update(ctx, cat); err != nil {
+	return nil
+}
+
+// SalaryHistory returns the salary ledger of the cat, oldest change first
+func (s *CatService) SalaryHistory(ctx context.Context, id uint) ([]model.SalaryChange, error) {
+	if _, err := authorize(ctx, auth.PermCatsRead); err != nil {
 		return nil, err
 	}
 
-	return cat, nil
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.salaries.ListByCatID(ctx, id)
 }
 
-func (s *CatService) Delete(ctx context.Context, id uint) error {
-	return s.repo.Delete(ctx, id)
+func (s *CatService) Delete(ctx context.Context, id uint, expectedVersion int) error {
+	if _, err := authorize(ctx, auth.PermCatsWrite); err != nil {
+		return err
+	}
+
+	return s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		cat, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.repo.Delete(ctx, id, expectedVersion); err != nil {
+			return err
+		}
+
+		if err := s.audit.Record(ctx, model.AuditActionDelete, "cat", id, cat, nil); err != nil {
+			return err
+		}
+
+		return s.events.Publish(ctx, model.EventCatDeleted, "cat", id, catScope(id), cat)
+	})
+}
+
+func (s *CatService) Restore(ctx context.Context, id uint) (*model.Cat, error) {
+	if _, err := authorize(ctx, auth.PermCatsWrite); err != nil {
+		return nil, err
+	}
+
+	var cat *model.Cat
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		before, err := s.repo.GetByID(repository.WithDeleted(ctx), id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.repo.Restore(ctx, id); err != nil {
+			return err
+		}
+
+		if cat, err = s.repo.GetByID(ctx, id); err != nil {
+			return err
+		}
+
+		return s.audit.Record(ctx, model.AuditActionRestore, "cat", id, before, cat)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return cat, nil
 }
 
 func (s *CatService) GetByID(ctx context.Context, id uint) (*model.Cat, error) {
+	if _, err := authorize(ctx, auth.PermCatsRead); err != nil {
+		return nil, err
+	}
+
 	return s.repo.GetByID(ctx, id)
 }
 
-func (s *CatService) List(ctx context.Context) ([]model.Cat, error) {
-	return s.repo.List(ctx)
+func (s *CatService) List(ctx context.Context, filter model.CatFilter) (*model.CatPage, error) {
+	if _, err := authorize(ctx, auth.PermCatsRead); err != nil {
+		return nil, err
+	}
+
+	return s.repo.List(ctx, filter)
+}
+
+func (s *CatService) ListBreeds(ctx context.Context) ([]client.CatBreed, error) {
+	if _, err := authorize(ctx, auth.PermCatsRead); err != nil {
+		return nil, err
+	}
+	return s.breeds.Breeds(ctx)
 }
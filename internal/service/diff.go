@@ -0,0 +1,94 @@
+package service
+
+import (
+	"SpyCatAgency/internal/model"
+	"strings"
+)
+
+// maxDiffCells bounds the memory of the line diff. Longer texts are diffed as a full replacement
+// of the part that differs.
+const maxDiffCells = 1 << 22
+
+// diffLines compares two texts line by line using their longest common subsequence
+func diffLines(from, to string) []model.DiffLine {
+	a, b := splitLines(from), splitLines(to)
+
+	// The common prefix and suffix are kept as they are and left out of the table
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	lines := make([]model.DiffLine, 0, len(a)+len(b))
+	for _, line := range a[:prefix] {
+		lines = append(lines, model.DiffLine{Op: model.DiffOpEqual, Text: line})
+	}
+	lines = append(lines, diffMiddle(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, line := range a[len(a)-suffix:] {
+		lines = append(lines, model.DiffLine{Op: model.DiffOpEqual, Text: line})
+	}
+	return lines
+}
+
+func diffMiddle(a, b []string) []model.DiffLine {
+	var lines []model.DiffLine
+	if (len(a)+1)*(len(b)+1) > maxDiffCells {
+		for _, line := range a {
+			lines = append(lines, model.DiffLine{Op: model.DiffOpDelete, Text: line})
+		}
+		for _, line := range b {
+			lines = append(lines, model.DiffLine{Op: model.DiffOpInsert, Text: line})
+		}
+		return lines
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, model.DiffLine{Op: model.DiffOpEqual, Text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, model.DiffLine{Op: model.DiffOpDelete, Text: a[i]})
+			i++
+		default:
+			lines = append(lines, model.DiffLine{Op: model.DiffOpInsert, Text: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, model.DiffLine{Op: model.DiffOpDelete, Text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, model.DiffLine{Op: model.DiffOpInsert, Text: b[j]})
+	}
+	return lines
+}
+
+// splitLines splits a text into lines; the empty text has none
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(strings.ReplaceAll(text, "\r\n", "\n"), "\n"), "\n")
+}
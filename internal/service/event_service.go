@@ -0,0 +1,54 @@
+package service
+
+import (
+	"SpyCatAgency/internal/model"
+	"SpyCatAgency/internal/repository"
+	"SpyCatAgency/internal/requestctx"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// EventService records domain events in the outbox, from where the webhook worker delivers them
+type EventService struct {
+	repo repository.OutboxRepository
+}
+
+func NewEventService(repo repository.OutboxRepository) *EventService {
+	return &EventService{repo: repo}
+}
+
+// Publish stores an event about an entity with its state as data. It must be called within the
+// transaction of the change so the event is only delivered if the change is saved.
+func (s *EventService) Publish(
+	ctx context.Context,
+	eventType model.EventType,
+	entity string,
+	entityID uint,
+	scope model.EventScope,
+	data any,
+) error {
+	doc, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", eventType, err)
+	}
+
+	return s.repo.Create(ctx, &model.Event{
+		Type:       eventType,
+		Entity:     entity,
+		EntityID:   entityID,
+		EventScope: scope,
+		Data:       doc,
+		RequestID:  requestctx.RequestID(ctx),
+	})
+}
+
+// missionScope is the scope of events about a mission or one of its targets
+func missionScope(mission *model.Mission) model.EventScope {
+	return model.EventScope{MissionID: &mission.ID, CatID: mission.CatID}
+}
+
+// catScope is the scope of events about a cat
+func catScope(catID uint) model.EventScope {
+	return model.EventScope{CatID: &catID}
+}
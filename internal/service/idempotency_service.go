@@ -0,0 +1,79 @@
+package service
+
+import (
+	"SpyCatAgency/internal/apperror"
+	"SpyCatAgency/internal/logger"
+	"SpyCatAgency/internal/model"
+	"SpyCatAgency/internal/repository"
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// idempotencyAbandonAfter is how long a request may hold a key without completing before a retry takes it over
+const idempotencyAbandonAfter = time.Minute
+
+type IdempotencyService struct {
+	repo   repository.IdempotencyRepository
+	window time.Duration
+}
+
+func NewIdempotencyService(repo repository.IdempotencyRepository, window time.Duration) *IdempotencyService {
+	return &IdempotencyService{
+		repo:   repo,
+		window: window,
+	}
+}
+
+// Begin claims the key for a request. It returns nil if the request should run, with the claim time set
+// on claim, or the stored response of the first request made with the key if it should be replayed.
+func (s *IdempotencyService) Begin(ctx context.Context, claim *model.IdempotencyRecord) (*model.IdempotencyRecord, error) {
+	existing, err := s.repo.Claim(ctx, claim, s.window, idempotencyAbandonAfter)
+	if err != nil || existing == nil {
+		return nil, err
+	}
+
+	if existing.RequestHash != claim.RequestHash {
+		return nil, apperror.Validation("Idempotency-Key %q was already used with a different request", claim.Key)
+	}
+	if existing.CompletedAt == nil {
+		return nil, apperror.Conflict("a request with Idempotency-Key %q is still being processed", claim.Key)
+	}
+
+	return existing, nil
+}
+
+// Complete stores the response of a request that claimed the key, unless its claim was taken over
+func (s *IdempotencyService) Complete(ctx context.Context, record *model.IdempotencyRecord) error {
+	return s.repo.Complete(ctx, record)
+}
+
+// Release frees the key of a request that failed, so that a retry runs it again. A claim taken over by
+// another request once this one was abandoned is left alone.
+func (s *IdempotencyService) Release(ctx context.Context, claim *model.IdempotencyRecord) error {
+	return s.repo.Release(ctx, claim)
+}
+
+// Run removes expired keys every interval until the context is cancelled
+func (s *IdempotencyService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		n, err := s.repo.DeleteExpired(ctx, s.window)
+		if err != nil {
+			logger.Error(ctx, fmt.Errorf("failed to delete expired idempotency keys: %w", err))
+			continue
+		}
+		if n > 0 {
+			logger.Info(ctx, "Deleted expired idempotency keys", slog.Int64("count", n))
+		}
+	}
+}
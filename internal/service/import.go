@@ -0,0 +1,167 @@
+package service
+
+import (
+	"SpyCatAgency/internal/apperror"
+	"SpyCatAgency/internal/model"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// exportPageSize is the page size used to walk a list for an export
+const exportPageSize = 100
+
+// importValidator checks imported records against the same binding rules as the single-item endpoints
+var importValidator = newImportValidator()
+
+func newImportValidator() *validator.Validate {
+	v := validator.New()
+	v.SetTagName("binding")
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	return v
+}
+
+// validateImportRow returns the decoding problems of the row followed by its binding rule violations
+func validateImportRow[T any](row model.ImportRow[T]) []string {
+	problems := append([]string(nil), row.Errors...)
+
+	var invalid validator.ValidationErrors
+	if err := importValidator.Struct(row.Value); errors.As(err, &invalid) {
+		for _, fieldErr := range invalid {
+			problems = append(problems, describeFieldError(fieldErr))
+		}
+	} else if err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	return problems
+}
+
+// ValidateInput checks a request decoded outside of Gin, e.g. by the GraphQL endpoint, against the binding
+// rules of its type
+func ValidateInput(v any) error {
+	var invalid validator.ValidationErrors
+	err := importValidator.Struct(v)
+	if !errors.As(err, &invalid) {
+		return err
+	}
+
+	problems := make([]string, len(invalid))
+	for i, fieldErr := range invalid {
+		problems[i] = describeFieldError(fieldErr)
+	}
+	return apperror.Validation("%s", strings.Join(problems, "; ")).WithDetail("errors", problems)
+}
+
+// describeFieldError turns a failed binding rule into a message naming the JSON field, e.g. "targets[0].name is required"
+func describeFieldError(err validator.FieldError) string {
+	field := err.Namespace()
+	if _, rest, ok := strings.Cut(field, "."); ok {
+		field = rest
+	}
+
+	switch err.Tag() {
+	case "required":
+		return field + " is required"
+	case "min":
+		if err.Kind() == reflect.Slice {
+			return fmt.Sprintf("%s must have at least %s items", field, err.Param())
+		}
+		return fmt.Sprintf("%s must be at least %s", field, err.Param())
+	case "max":
+		if err.Kind() == reflect.Slice {
+			return fmt.Sprintf("%s must have at most %s items", field, err.Param())
+		}
+		return fmt.Sprintf("%s must be at most %s", field, err.Param())
+	default:
+		return fmt.Sprintf("%s fails the %s rule", field, err.Tag())
+	}
+}
+
+// checkImportSize rejects imports that are empty or larger than model.MaxImportItems
+func checkImportSize(n int) error {
+	if n == 0 {
+		return apperror.Validation("import contains no items")
+	}
+	if n > model.MaxImportItems {
+		return apperror.Validation("import contains %d items, at most %d are allowed", n, model.MaxImportItems)
+	}
+	return nil
+}
+
+// itemProblem converts a business rule violation for a single item into its report message.
+// Other errors are returned as they are and abort the import.
+func itemProblem(err error) (string, error) {
+	var appErr *apperror.Error
+	if errors.As(err, &appErr) &&
+		(errors.Is(err, apperror.ErrValidation) || errors.Is(err, apperror.ErrConflict) || errors.Is(err, apperror.ErrNotFound)) {
+		return appErr.Message(), nil
+	}
+	return "", err
+}
+
+// rejectInvalid fails an all-or-nothing import listing every item that did not pass validation
+func rejectInvalid(report *model.ImportReport) error {
+	if report.Failed == 0 {
+		return nil
+	}
+
+	var failed []model.ImportItemResult
+	for _, item := range report.Items {
+		if len(item.Errors) > 0 {
+			failed = append(failed, item)
+		}
+	}
+	return apperror.Validation("%d of %d items are invalid, nothing was imported", report.Failed, len(report.Items)).
+		WithDetail("items", failed)
+}
+
+// importReport builds a model.ImportReport while items are validated and created
+type importReport struct {
+	*model.ImportReport
+}
+
+func newImportReport(n int) importReport {
+	report := importReport{&model.ImportReport{Items: make([]model.ImportItemResult, n)}}
+	for i := range report.Items {
+		report.Items[i].Item = i + 1
+	}
+	return report
+}
+
+// fail records problems of an item
+func (r importReport) fail(i int, problems ...string) {
+	r.Items[i].Errors = append(r.Items[i].Errors, problems...)
+}
+
+// valid reports whether no problems were recorded for the item so far
+func (r importReport) valid(i int) bool {
+	return len(r.Items[i].Errors) == 0
+}
+
+// created records the id of a created item
+func (r importReport) created(i int, id uint) {
+	r.Items[i].ID = id
+}
+
+// finish counts the created and failed items
+func (r importReport) finish() *model.ImportReport {
+	r.Created, r.Failed = 0, 0
+	for _, item := range r.Items {
+		if len(item.Errors) > 0 {
+			r.Failed++
+		} else if item.ID != 0 {
+			r.Created++
+		}
+	}
+	return r.ImportReport
+}
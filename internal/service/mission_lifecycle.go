@@ -0,0 +1,45 @@
+package service
+
+import (
+	"SpyCatAgency/internal/apperror"
+	"SpyCatAgency/internal/model"
+	"slices"
+)
+
+// missionTransitions lists the statuses a mission may move to from each status.
+// Completed and aborted missions are final and have no outgoing transitions.
+var missionTransitions = map[model.MissionStatus][]model.MissionStatus{
+	model.MissionStatusDraft:      {model.MissionStatusAssigned, model.MissionStatusAborted},
+	model.MissionStatusAssigned:   {model.MissionStatusInProgress, model.MissionStatusAborted},
+	model.MissionStatusInProgress: {model.MissionStatusCompleted, model.MissionStatusAborted},
+}
+
+// transition moves the mission to the given status if the lifecycle allows it
+func transition(mission *model.Mission, to model.MissionStatus) error {
+	if !slices.Contains(missionTransitions[mission.Status], to) {
+		return apperror.Conflict("mission %d cannot move from %s to %s", mission.ID, mission.Status, to)
+	}
+	mission.Status = to
+	return nil
+}
+
+// ensureNotFinal rejects changes to completed or aborted missions
+func ensureNotFinal(mission *model.Mission) error {
+	if mission.Status.IsFinal() {
+		return apperror.Conflict("mission %d is %s", mission.ID, mission.Status)
+	}
+	return nil
+}
+
+// allCompleted reports whether the mission has targets and every one of them is completed
+func allCompleted(targets []model.Target) bool {
+	if len(targets) == 0 {
+		return false
+	}
+	for _, t := range targets {
+		if !t.Completed {
+			return false
+		}
+	}
+	return true
+}
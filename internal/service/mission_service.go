@@ -1,127 +1,695 @@
 package service
 
 import (
+	"SpyCatAgency/internal/apperror"
+	"SpyCatAgency/internal/auth"
 	"SpyCatAgency/internal/model"
 	"SpyCatAgency/internal/repository"
 	"context"
 	"errors"
+	"fmt"
+	"slices"
 )
 
+// releaseReasonReassigned is recorded when a cat is replaced without a reason
+const releaseReasonReassigned = "reassigned"
+
 type MissionService struct {
+	txManager   repository.TxManager
 	missionRepo repository.MissionRepository
 	targetRepo  repository.TargetRepository
+	noteRepo    repository.TargetNoteRepository
+	assignments repository.AssignmentRepository
 	catRepo     repository.CatRepository
+	audit       *AuditService
+	events      *EventService
 }
 
 func NewMissionService(
+	txManager repository.TxManager,
 	missionRepo repository.MissionRepository,
 	targetRepo repository.TargetRepository,
+	noteRepo repository.TargetNoteRepository,
+	assignments repository.AssignmentRepository,
 	catRepo repository.CatRepository,
+	audit *AuditService,
+	events *EventService,
 ) *MissionService {
 	return &MissionService{
+		txManager:   txManager,
 		missionRepo: missionRepo,
 		targetRepo:  targetRepo,
+		noteRepo:    noteRepo,
+		assignments: assignments,
 		catRepo:     catRepo,
+		audit:       audit,
+		events:      events,
 	}
 }
 
 func (s *MissionService) Create(ctx context.Context, create model.MissionCreate) (*model.Mission, error) {
+	if _, err := authorize(ctx, auth.PermMissionsWrite); err != nil {
+		return nil, err
+	}
 
-	// Check if cat exists
-	cat, err := s.catRepo.GetByID(ctx, create.CatID)
-	if err != nil {
+	return s.create(ctx, create)
+}
+
+// Import creates missions in bulk. Every item is validated first, including that its cat exists and is
+// not already busy, here or in another item of the import. In atomic mode any invalid item fails the
+// whole import and the valid ones are created in a single transaction; otherwise each valid item is
+// created on its own and failures are only reported.
+func (s *MissionService) Import(
+	ctx context.Context,
+	rows []model.ImportRow[model.MissionCreate],
+	atomic bool,
+) (*model.ImportReport, error) {
+	if _, err := authorize(ctx, auth.PermMissionsWrite); err != nil {
 		return nil, err
 	}
+	if err := checkImportSize(len(rows)); err != nil {
+		return nil, err
+	}
+
+	report := newImportReport(len(rows))
+	assigned := make(map[uint]int)
+	for i, row := range rows {
+		if problems := validateImportRow(row); len(problems) > 0 {
+			report.fail(i, problems...)
+			continue
+		}
+		if row.Value.CatID == nil {
+			continue
+		}
+
+		catID := *row.Value.CatID
+		if other, ok := assigned[catID]; ok {
+			report.fail(i, fmt.Sprintf("cat %d is already assigned by item %d", catID, other+1))
+			continue
+		}
+
+		err := s.checkCatForImport(ctx, catID)
+		if err != nil {
+			problem, err := itemProblem(err)
+			if err != nil {
+				return nil, err
+			}
+			report.fail(i, problem)
+			continue
+		}
+		assigned[catID] = i
+	}
+
+	if atomic {
+		if err := rejectInvalid(report.finish()); err != nil {
+			return nil, err
+		}
+
+		ids := make([]uint, len(rows))
+		err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+			for i, row := range rows {
+				mission, err := s.create(ctx, row.Value)
+				if err != nil {
+					return err
+				}
+				ids[i] = mission.ID
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for i, id := range ids {
+			report.created(i, id)
+		}
+		return report.finish(), nil
+	}
 
+	for i, row := range rows {
+		if !report.valid(i) {
+			continue
+		}
+		mission, err := s.create(ctx, row.Value)
+		if err != nil {
+			problem, err := itemProblem(err)
+			if err != nil {
+				return nil, err
+			}
+			report.fail(i, problem)
+			continue
+		}
+		report.created(i, mission.ID)
+	}
+
+	return report.finish(), nil
+}
+
+// checkCatForImport fails if the cat does not exist or already has an active mission
+func (s *MissionService) checkCatForImport(ctx context.Context, catID uint) error {
+	if _, err := s.catRepo.GetByID(ctx, catID); err != nil {
+		return err
+	}
+	return s.ensureCatAvailable(ctx, catID, 0)
+}
+
+// Export returns all missions matching the filter together with their targets. Cats only get their own missions.
+func (s *MissionService) Export(ctx context.Context, filter model.MissionFilter) ([]model.Mission, error) {
+	include := &model.MissionInclude{Targets: true}
+
+	missions := []model.Mission{}
+	filter.Limit = exportPageSize
+	filter.Cursor = ""
+	for {
+		page, err := s.List(ctx, filter, include)
+		if err != nil {
+			return nil, err
+		}
+
+		missions = append(missions, page.Items...)
+		if page.NextCursor == "" {
+			return missions, nil
+		}
+		filter.Cursor = page.NextCursor
+	}
+}
+
+// create stores a mission with its targets. Missions created with a cat skip the draft status.
+func (s *MissionService) create(ctx context.Context, create model.MissionCreate) (*model.Mission, error) {
 	mission := &model.Mission{
-		CatID: create.CatID,
-		Cat:   *cat,
+		Name:   create.Name,
+		Status: model.MissionStatusDraft,
+	}
+
+	if create.CatID != nil {
+		cat, err := s.catRepo.GetByID(ctx, *create.CatID)
+		if err != nil {
+			return nil, err
+		}
+		mission.CatID = create.CatID
+		mission.Cat = cat
+		mission.Status = model.MissionStatusAssigned
+	}
+
+	// Mission and its targets are created atomically
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if mission.CatID != nil {
+			if err := s.ensureCatAvailable(ctx, *mission.CatID, 0); err != nil {
+				return err
+			}
+		}
+
+		if err := s.missionRepo.Create(ctx, mission); err != nil {
+			return err
+		}
+
+		if mission.CatID != nil {
+			if err := s.assignments.Open(ctx, &model.Assignment{MissionID: mission.ID, CatID: *mission.CatID}); err != nil {
+				return err
+			}
+		}
+
+		targets := make([]model.Target, 0, len(create.Targets))
+		for _, targetCreate := range create.Targets {
+			target := &model.Target{
+				MissionID: mission.ID,
+				Name:      targetCreate.Name,
+				Country:   targetCreate.Country,
+				Notes:     targetCreate.Notes,
+			}
+			if err := s.targetRepo.Create(ctx, target); err != nil {
+				return err
+			}
+			if target.Notes != "" {
+				if err := s.recordNotes(ctx, target); err != nil {
+					return err
+				}
+			}
+			if err := s.audit.Record(ctx, model.AuditActionCreate, "target", target.ID, nil, target); err != nil {
+				return err
+			}
+			targets = append(targets, *target)
+		}
+		mission.Targets = targets
+
+		if err := s.audit.Record(ctx, model.AuditActionCreate, "mission", mission.ID, nil, mission); err != nil {
+			return err
+		}
+
+		return s.events.Publish(ctx, model.EventMissionCreated, "mission", mission.ID, missionScope(mission), mission)
+	})
+	if err != nil {
+		return nil, s.catBusyError(ctx, err, mission.CatID)
 	}
 
-	if err := s.missionRepo.Create(ctx, mission); err != nil {
+	return mission, nil
+}
+
+func (s *MissionService) Update(
+	ctx context.Context,
+	id uint,
+	expectedVersion int,
+	update model.MissionUpdate,
+) (*model.Mission, error) {
+	if _, err := authorize(ctx, auth.PermMissionsWrite); err != nil {
 		return nil, err
 	}
 
-	// Create targets
-	for _, targetCreate := range create.Targets {
-		target := &model.Target{
-			MissionID: mission.ID,
-			Name:      targetCreate.Name,
-			Country:   targetCreate.Country,
-			Notes:     targetCreate.Notes,
+	var mission *model.Mission
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		mission, err = s.missionRepo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
 		}
-		if err := s.targetRepo.Create(ctx, target); err != nil {
-			return nil, err
+
+		if err := checkVersion("mission", id, expectedVersion, mission.Version); err != nil {
+			return err
 		}
-		mission.Targets = append(mission.Targets, *target)
+
+		switch update.Status {
+		case model.MissionStatusAssigned:
+			if mission.CatID == nil {
+				return apperror.Conflict("mission %d has no cat assigned", id)
+			}
+		case model.MissionStatusCompleted:
+			targets, err := s.targetRepo.ListByMissionID(ctx, id)
+			if err != nil {
+				return err
+			}
+			if !allCompleted(targets) {
+				return apperror.Conflict("mission %d still has targets that are not completed", id)
+			}
+		}
+
+		before := *mission
+		if err := transition(mission, update.Status); err != nil {
+			return err
+		}
+
+		if err := s.missionRepo.Update(ctx, mission); err != nil {
+			return err
+		}
+
+		if err := s.releaseIfFinal(ctx, mission); err != nil {
+			return err
+		}
+
+		if err := s.audit.Record(ctx, model.AuditActionUpdate, "mission", id, &before, mission); err != nil {
+			return err
+		}
+
+		return s.publishStatus(ctx, mission)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return mission, nil
 }
 
-func (s *MissionService) Update(ctx context.Context, id uint, update model.MissionUpdate) (*model.Mission, error) {
+// Delete soft-deletes the mission together with its targets
+func (s *MissionService) Delete(ctx context.Context, id uint, expectedVersion int) error {
+	if _, err := authorize(ctx, auth.PermMissionsWrite); err != nil {
+		return err
+	}
+
+	return s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		mission, err := s.missionRepo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := checkVersion("mission", id, expectedVersion, mission.Version); err != nil {
+			return err
+		}
+
+		if mission.CatID != nil {
+			return apperror.Conflict("cannot delete mission that is assigned to a cat")
+		}
+
+		targets, err := s.targetRepo.ListByMissionID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.targetRepo.DeleteByMissionID(ctx, id); err != nil {
+			return err
+		}
+
+		if err := s.missionRepo.Delete(ctx, id, mission.Version); err != nil {
+			return err
+		}
+
+		for i := range targets {
+			if err := s.audit.Record(ctx, model.AuditActionDelete, "target", targets[i].ID, &targets[i], nil); err != nil {
+				return err
+			}
+		}
+
+		if err := s.audit.Record(ctx, model.AuditActionDelete, "mission", id, mission, nil); err != nil {
+			return err
+		}
+
+		return s.events.Publish(ctx, model.EventMissionDeleted, "mission", id, missionScope(mission), mission)
+	})
+}
+
+// Restore brings back a deleted mission and the targets that were deleted with it
+func (s *MissionService) Restore(ctx context.Context, id uint) (*model.Mission, error) {
+	if _, err := authorize(ctx, auth.PermMissionsWrite); err != nil {
+		return nil, err
+	}
+
+	var catID *uint
+	var restored *model.Mission
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		mission, err := s.missionRepo.GetByID(repository.WithDeleted(ctx), id)
+		if err != nil {
+			return err
+		}
+		catID = mission.CatID
+
+		if mission.DeletedAt == nil {
+			return apperror.Conflict("mission %d is not deleted", id)
+		}
+
+		targets, err := s.targetRepo.ListByMissionID(repository.WithDeleted(ctx), id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.targetRepo.RestoreByMissionID(ctx, id); err != nil {
+			return err
+		}
+
+		if err := s.missionRepo.Restore(ctx, id); err != nil {
+			return err
+		}
+
+		// Only the targets deleted together with the mission come back
+		for i := range targets {
+			if targets[i].DeletedAt == nil || !targets[i].DeletedAt.Equal(*mission.DeletedAt) {
+				continue
+			}
+			target, err := s.targetRepo.GetByID(ctx, targets[i].ID)
+			if err != nil {
+				return err
+			}
+			if err := s.audit.Record(ctx, model.AuditActionRestore, "target", target.ID, &targets[i], target); err != nil {
+				return err
+			}
+		}
+
+		if restored, err = s.missionRepo.GetByID(ctx, id); err != nil {
+			return err
+		}
+
+		return s.audit.Record(ctx, model.AuditActionRestore, "mission", id, mission, restored)
+	})
+	if err != nil {
+		return nil, s.catBusyError(ctx, err, catID)
+	}
+
+	return restored, nil
+}
+
+// GetByID returns the mission with the relations selected by include, or the default ones when it is nil.
+// Cats can only see their own missions.
+func (s *MissionService) GetByID(ctx context.Context, id uint, include *model.MissionInclude) (*model.Mission, error) {
+	p, err := authorize(ctx, auth.PermMissionsRead)
+	if err != nil {
+		return nil, err
+	}
+
+	expand, err := resolveInclude(p, include)
+	if err != nil {
+		return nil, err
+	}
 
 	mission, err := s.missionRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	mission.Completed = update.Completed
+	if err := ensureOwnsMission(p, mission); err != nil {
+		return nil, err
+	}
 
-	if err := s.missionRepo.Update(ctx, mission); err != nil {
+	missions := []model.Mission{*mission}
+	if err := s.expand(ctx, missions, expand); err != nil {
 		return nil, err
 	}
 
-	return mission, nil
+	return &missions[0], nil
 }
 
-func (s *MissionService) Delete(ctx context.Context, id uint) error {
+// List returns a page of missions with the relations selected by include, or the default ones when it is nil.
+// Cats only get their own missions.
+func (s *MissionService) List(
+	ctx context.Context,
+	filter model.MissionFilter,
+	include *model.MissionInclude,
+) (*model.MissionPage, error) {
+	p, err := authorize(ctx, auth.PermMissionsRead)
+	if err != nil {
+		return nil, err
+	}
 
-	mission, err := s.missionRepo.GetByID(ctx, id)
+	expand, err := resolveInclude(p, include)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	if p.Role == auth.RoleCat {
+		if filter.CatID != nil && (p.CatID == nil || *filter.CatID != *p.CatID) {
+			return nil, apperror.Forbidden("%s can only list its own missions", p.Name)
+		}
+		filter.CatID = p.CatID
+	}
+
+	page, err := s.missionRepo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.expand(ctx, page.Items, expand); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// resolveInclude picks the relations to load. By default the targets are loaded, and the cat for callers
+// allowed to see it: those with cats:read and cats, who only get their own missions.
+func resolveInclude(p *auth.Principal, include *model.MissionInclude) (model.MissionInclude, error) {
+	canReadCat := p.Can(auth.PermCatsRead) || p.Role == auth.RoleCat
+	if include == nil {
+		return model.MissionInclude{Cat: canReadCat, Targets: true}, nil
+	}
+
+	if include.Cat && !canReadCat {
+		return model.MissionInclude{}, apperror.Forbidden("%s is not allowed to see cats", p.Name).
+			WithDetail("permission", auth.PermCatsRead)
+	}
+	return *include, nil
+}
+
+// expand loads the selected relations of the missions with a single query per relation
+func (s *MissionService) expand(ctx context.Context, missions []model.Mission, include model.MissionInclude) error {
+	if len(missions) == 0 {
+		return nil
+	}
+
+	if include.Cat {
+		var catIDs []uint
+		for _, mission := range missions {
+			if mission.CatID != nil {
+				catIDs = append(catIDs, *mission.CatID)
+			}
+		}
+
+		byID, err := s.catsByID(ctx, catIDs)
+		if err != nil {
+			return err
+		}
+		for i := range missions {
+			if missions[i].CatID != nil {
+				missions[i].Cat = byID[*missions[i].CatID]
+			}
+		}
 	}
 
-	if mission.CatID != 0 {
-		return errors.New("cannot delete mission that is assigned to a cat")
+	if include.Targets {
+		missionIDs := make([]uint, len(missions))
+		for i, mission := range missions {
+			missionIDs[i] = mission.ID
+		}
+
+		byMission, err := s.targetsByMission(ctx, missionIDs)
+		if err != nil {
+			return err
+		}
+		for i := range missions {
+			missions[i].Targets = byMission[missions[i].ID]
+		}
 	}
 
-	return s.missionRepo.Delete(ctx, id)
+	return nil
 }
 
-func (s *MissionService) GetByID(ctx context.Context, id uint) (*model.Mission, error) {
-	return s.missionRepo.GetByID(ctx, id)
+// catsByID loads cats with a single query, keyed by id
+func (s *MissionService) catsByID(ctx context.Context, ids []uint) (map[uint]*model.Cat, error) {
+	byID := make(map[uint]*model.Cat, len(ids))
+	if len(ids) == 0 {
+		return byID, nil
+	}
+
+	cats, err := s.catRepo.ListByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range cats {
+		byID[cats[i].ID] = &cats[i]
+	}
+	return byID, nil
 }
 
-func (s *MissionService) List(ctx context.Context) ([]model.Mission, error) {
-	return s.missionRepo.List(ctx)
+// targetsByMission loads the targets of missions with a single query, keyed by mission id
+func (s *MissionService) targetsByMission(ctx context.Context, missionIDs []uint) (map[uint][]model.Target, error) {
+	byMission := make(map[uint][]model.Target, len(missionIDs))
+	if len(missionIDs) == 0 {
+		return byMission, nil
+	}
+
+	targets, err := s.targetRepo.ListByMissionIDs(ctx, missionIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, target := range targets {
+		byMission[target.MissionID] = append(byMission[target.MissionID], target)
+	}
+	return byMission, nil
 }
 
-func (s *MissionService) AssignCat(ctx context.Context, missionID, catID uint) error {
-	return s.missionRepo.AssignCat(ctx, missionID, catID)
+// CatsByIDs loads the cats of missions in one batch, for callers that resolve the cats of many missions
+// separately. The same callers can see them as through ?include=cat.
+func (s *MissionService) CatsByIDs(ctx context.Context, ids []uint) (map[uint]*model.Cat, error) {
+	p, err := authorize(ctx, auth.PermMissionsRead)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := resolveInclude(p, &model.MissionInclude{Cat: true}); err != nil {
+		return nil, err
+	}
+
+	return s.catsByID(ctx, ids)
 }
 
-func (s *MissionService) AddTarget(
-	ctx context.Context,
-	missionID uint,
-	targetCreate model.TargetCreate,
-) (*model.Target, error) {
-	mission, err := s.missionRepo.GetByID(ctx, missionID)
+// TargetsByMissionIDs loads the targets of missions the caller has already read in one batch
+func (s *MissionService) TargetsByMissionIDs(ctx context.Context, missionIDs []uint) (map[uint][]model.Target, error) {
+	if _, err := authorize(ctx, auth.PermMissionsRead); err != nil {
+		return nil, err
+	}
+
+	return s.targetsByMission(ctx, missionIDs)
+}
+
+// MissionsByCatIDs loads the missions of cats in one batch, keyed by cat id. Cats only get their own.
+func (s *MissionService) MissionsByCatIDs(ctx context.Context, catIDs []uint) (map[uint][]model.Mission, error) {
+	p, err := authorize(ctx, auth.PermMissionsRead)
 	if err != nil {
 		return nil, err
 	}
+	if p.Role == auth.RoleCat {
+		catIDs = slices.DeleteFunc(slices.Clone(catIDs), func(id uint) bool {
+			return p.CatID == nil || id != *p.CatID
+		})
+	}
 
-	if mission.Completed {
-		return nil, errors.New("cannot add target to completed mission")
+	byCat := make(map[uint][]model.Mission, len(catIDs))
+	if len(catIDs) == 0 {
+		return byCat, nil
 	}
 
-	targets, err := s.targetRepo.ListByMissionID(ctx, missionID)
+	missions, err := s.missionRepo.ListByCatIDs(ctx, catIDs)
 	if err != nil {
 		return nil, err
 	}
+	for _, mission := range missions {
+		byCat[*mission.CatID] = append(byCat[*mission.CatID], mission)
+	}
+	return byCat, nil
+}
+
+// AssignCat gives the mission to a cat. A cat that is replaced is released with the reason of the request.
+func (s *MissionService) AssignCat(ctx context.Context, missionID uint, assign model.CatAssign) error {
+	if _, err := authorize(ctx, auth.PermMissionsWrite); err != nil {
+		return err
+	}
+
+	catID := assign.CatID
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		mission, err := s.missionRepo.GetByIDForUpdate(ctx, missionID)
+		if err != nil {
+			return err
+		}
+
+		if err := ensureNotFinal(mission); err != nil {
+			return err
+		}
+
+		if _, err := s.catRepo.GetByID(ctx, catID); err != nil {
+			return err
+		}
+
+		if err := s.ensureCatAvailable(ctx, catID, missionID); err != nil {
+			return err
+		}
+
+		before := *mission
+		mission.CatID = &catID
+		if mission.Status == model.MissionStatusDraft {
+			if err := transition(mission, model.MissionStatusAssigned); err != nil {
+				return err
+			}
+		}
+
+		if err := s.missionRepo.Update(ctx, mission); err != nil {
+			return err
+		}
 
-	if len(targets) >= 3 {
-		return nil, errors.New("mission already has maximum number of targets")
+		if before.CatID == nil || *before.CatID != catID {
+			reason := assign.Reason
+			if reason == "" {
+				reason = releaseReasonReassigned
+			}
+			if err := s.assignments.Release(ctx, missionID, reason); err != nil {
+				return err
+			}
+			if err := s.assignments.Open(ctx, &model.Assignment{MissionID: missionID, CatID: catID}); err != nil {
+				return err
+			}
+			if err := s.events.Publish(ctx, model.EventMissionAssigned, "mission", missionID, missionScope(mission), mission); err != nil {
+				return err
+			}
+		}
+
+		return s.audit.Record(ctx, model.AuditActionAssign, "mission", missionID, &before, mission)
+	})
+
+	return s.catBusyError(ctx, err, &catID)
+}
+
+func (s *MissionService) AddTarget(
+	ctx context.Context,
+	missionID uint,
+	targetCreate model.TargetCreate,
+) (*model.Target, error) {
+	if _, err := authorize(ctx, auth.PermMissionsWrite); err != nil {
+		return nil, err
 	}
 
 	target := &model.Target{
@@ -131,51 +699,486 @@ func (s *MissionService) AddTarget(
 		Notes:     targetCreate.Notes,
 	}
 
-	if err := s.targetRepo.Create(ctx, target); err != nil {
+	// The mission row stays locked so concurrent requests cannot exceed the target limit
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		mission, err := s.missionRepo.GetByIDForUpdate(ctx, missionID)
+		if err != nil {
+			return err
+		}
+
+		if err := ensureNotFinal(mission); err != nil {
+			return err
+		}
+
+		targets, err := s.targetRepo.ListByMissionID(ctx, missionID)
+		if err != nil {
+			return err
+		}
+
+		if len(targets) >= 3 {
+			return apperror.Validation("mission already has maximum number of targets")
+		}
+
+		if err := s.targetRepo.Create(ctx, target); err != nil {
+			return err
+		}
+
+		if target.Notes != "" {
+			if err := s.recordNotes(ctx, target); err != nil {
+				return err
+			}
+		}
+
+		if err := s.audit.Record(ctx, model.AuditActionCreate, "target", target.ID, nil, target); err != nil {
+			return err
+		}
+
+		return s.events.Publish(ctx, model.EventTargetCreated, "target", target.ID, missionScope(mission), target)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return target, nil
 }
 
-func (s *MissionService) DeleteTarget(ctx context.Context, targetID uint) error {
-	target, err := s.targetRepo.GetByID(ctx, targetID)
-	if err != nil {
+func (s *MissionService) DeleteTarget(ctx context.Context, targetID uint, expectedVersion int) error {
+	if _, err := authorize(ctx, auth.PermMissionsWrite); err != nil {
 		return err
 	}
 
-	if target.Completed {
-		return errors.New("cannot delete completed target")
+	return s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		target, mission, err := s.lockTarget(ctx, targetID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkVersion("target", targetID, expectedVersion, target.Version); err != nil {
+			return err
+		}
+
+		if err := ensureNotFinal(mission); err != nil {
+			return err
+		}
+
+		if target.Completed {
+			return apperror.Conflict("cannot delete completed target")
+		}
+
+		// A mission keeps at least one target, as when it is created
+		targets, err := s.targetRepo.ListByMissionID(ctx, mission.ID)
+		if err != nil {
+			return err
+		}
+		if len(targets) <= 1 {
+			return apperror.Conflict("cannot delete the last target of mission %d", mission.ID)
+		}
+
+		if err := s.targetRepo.Delete(ctx, targetID, target.Version); err != nil {
+			return err
+		}
+
+		if err := s.audit.Record(ctx, model.AuditActionDelete, "target", targetID, target, nil); err != nil {
+			return err
+		}
+		if err := s.events.Publish(ctx, model.EventTargetDeleted, "target", targetID, missionScope(mission), target); err != nil {
+			return err
+		}
+
+		// Removing the last open target finishes the mission
+		return s.completeIfDone(ctx, mission)
+	})
+}
+
+// RestoreTarget brings back a deleted target of a mission that is still open
+func (s *MissionService) RestoreTarget(ctx context.Context, targetID uint) (*model.Target, error) {
+	if _, err := authorize(ctx, auth.PermMissionsWrite); err != nil {
+		return nil, err
+	}
+
+	var restored *model.Target
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		target, err := s.targetRepo.GetByID(repository.WithDeleted(ctx), targetID)
+		if err != nil {
+			return err
+		}
+
+		mission, err := s.missionRepo.GetByID(repository.WithDeleted(ctx), target.MissionID)
+		if err != nil {
+			return err
+		}
+
+		if mission.DeletedAt != nil {
+			return apperror.Conflict("mission %d of target %d is deleted", mission.ID, targetID)
+		}
+
+		// Lock the mission so the target limit holds against concurrent changes
+		mission, err = s.missionRepo.GetByIDForUpdate(ctx, target.MissionID)
+		if err != nil {
+			return err
+		}
+
+		if err := ensureNotFinal(mission); err != nil {
+			return err
+		}
+
+		targets, err := s.targetRepo.ListByMissionID(ctx, mission.ID)
+		if err != nil {
+			return err
+		}
+
+		if len(targets) >= 3 && target.DeletedAt != nil {
+			return apperror.Validation("mission already has maximum number of targets")
+		}
+
+		if err := s.targetRepo.Restore(ctx, targetID); err != nil {
+			return err
+		}
+
+		if restored, err = s.targetRepo.GetByID(ctx, targetID); err != nil {
+			return err
+		}
+
+		return s.audit.Record(ctx, model.AuditActionRestore, "target", targetID, target, restored)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return s.targetRepo.Delete(ctx, targetID)
+	return restored, nil
 }
 
 func (s *MissionService) UpdateTarget(
 	ctx context.Context,
 	targetID uint,
+	expectedVersion int,
 	update model.TargetUpdate,
 ) (*model.Target, error) {
+	p, err := authorize(ctx, auth.PermTargetsWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	var target *model.Target
+
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var mission *model.Mission
+		var err error
+		target, mission, err = s.lockTarget(ctx, targetID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkVersion("target", targetID, expectedVersion, target.Version); err != nil {
+			return err
+		}
+
+		// Cats can only work on targets of their own missions
+		if err := ensureOwnsMission(p, mission); err != nil {
+			return err
+		}
+
+		if err := ensureNotFinal(mission); err != nil {
+			return err
+		}
+
+		if target.Completed {
+			return apperror.Conflict("target %d is completed", targetID)
+		}
+
+		if update.Completed && mission.CatID == nil {
+			return apperror.Conflict("cannot complete a target before a cat is assigned to mission %d", mission.ID)
+		}
+
+		before := *target
+		target.Notes = update.Notes
+		target.Completed = update.Completed
+
+		if err := s.targetRepo.Update(ctx, target); err != nil {
+			return err
+		}
+
+		// The history only grows while the target can still be edited, so it is frozen together with it
+		if target.Notes != before.Notes {
+			if err := s.recordNotes(ctx, target); err != nil {
+				return err
+			}
+		}
+
+		if err := s.audit.Record(ctx, model.AuditActionUpdate, "target", targetID, &before, target); err != nil {
+			return err
+		}
+		if err := s.events.Publish(ctx, model.EventTargetUpdated, "target", targetID, missionScope(mission), target); err != nil {
+			return err
+		}
+
+		// The first progress on a target starts the mission
+		if mission.Status == model.MissionStatusAssigned {
+			missionBefore := *mission
+			if err := transition(mission, model.MissionStatusInProgress); err != nil {
+				return err
+			}
+			if err := s.missionRepo.Update(ctx, mission); err != nil {
+				return err
+			}
+			if err := s.audit.Record(ctx, model.AuditActionUpdate, "mission", mission.ID, &missionBefore, mission); err != nil {
+				return err
+			}
+			if err := s.publishStatus(ctx, mission); err != nil {
+				return err
+			}
+		}
+
+		return s.completeIfDone(ctx, mission)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return target, nil
+}
+
+// TargetNotes returns the notes history of the target, oldest revision first
+func (s *MissionService) TargetNotes(ctx context.Context, targetID uint) ([]model.TargetNote, error) {
+	if err := s.authorizeTargetRead(ctx, targetID); err != nil {
+		return nil, err
+	}
+
+	return s.noteRepo.ListByTargetID(ctx, targetID)
+}
+
+// DiffTargetNotes compares two revisions of the notes of the target. By default the latest revision is
+// compared with the one before it.
+func (s *MissionService) DiffTargetNotes(
+	ctx context.Context,
+	targetID uint,
+	query model.TargetNotesDiffQuery,
+) (*model.TargetNotesDiff, error) {
+	if err := s.authorizeTargetRead(ctx, targetID); err != nil {
+		return nil, err
+	}
+
+	diff := &model.TargetNotesDiff{TargetID: targetID}
+	if query.To != nil {
+		diff.To = *query.To
+	} else {
+		latest, err := s.noteRepo.LatestRevision(ctx, targetID)
+		if err != nil {
+			return nil, err
+		}
+		diff.To = latest
+	}
+	if query.From != nil {
+		diff.From = *query.From
+	} else {
+		diff.From = max(diff.To-1, 0)
+	}
+
+	from, err := s.noteContent(ctx, targetID, diff.From)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.noteContent(ctx, targetID, diff.To)
+	if err != nil {
+		return nil, err
+	}
+
+	diff.Lines = diffLines(from, to)
+	return diff, nil
+}
+
+// noteContent returns the notes of a revision; revision 0 is the empty note before the first one
+func (s *MissionService) noteContent(ctx context.Context, targetID uint, revision int) (string, error) {
+	if revision == 0 {
+		return "", nil
+	}
+
+	note, err := s.noteRepo.GetRevision(ctx, targetID, revision)
+	if err != nil {
+		return "", err
+	}
+	return note.Content, nil
+}
+
+// authorizeTargetRead checks that the caller may see the target: cats only on their own missions
+func (s *MissionService) authorizeTargetRead(ctx context.Context, targetID uint) error {
+	p, err := authorize(ctx, auth.PermMissionsRead)
+	if err != nil {
+		return err
+	}
+
 	target, err := s.targetRepo.GetByID(ctx, targetID)
+	if err != nil {
+		return err
+	}
+
+	// The target being visible is what counts, so its mission is looked up even if deleted
+	mission, err := s.missionRepo.GetByID(repository.WithDeleted(ctx), target.MissionID)
+	if err != nil {
+		return err
+	}
+
+	return ensureOwnsMission(p, mission)
+}
+
+// recordNotes appends the current notes of the target to its history
+func (s *MissionService) recordNotes(ctx context.Context, target *model.Target) error {
+	return s.noteRepo.Append(ctx, &model.TargetNote{
+		TargetID: target.ID,
+		Content:  target.Notes,
+		Author:   actor(ctx),
+	})
+}
+
+// MissionAssignments returns the cats that worked on the mission, earliest first
+func (s *MissionService) MissionAssignments(ctx context.Context, missionID uint) ([]model.Assignment, error) {
+	p, err := authorize(ctx, auth.PermMissionsRead)
 	if err != nil {
 		return nil, err
 	}
 
-	mission, err := s.missionRepo.GetByID(ctx, target.MissionID)
+	mission, err := s.missionRepo.GetByID(ctx, missionID)
 	if err != nil {
 		return nil, err
 	}
 
-	if mission.Completed || target.Completed {
-		return nil, errors.New("cannot update target in completed mission or completed target")
+	if err := ensureOwnsMission(p, mission); err != nil {
+		return nil, err
 	}
 
-	target.Notes = update.Notes
-	target.Completed = update.Completed
+	return s.assignments.ListByMissionID(ctx, missionID)
+}
 
-	if err := s.targetRepo.Update(ctx, target); err != nil {
+// CatAssignments returns the missions the cat worked on, earliest first. Cats can only see their own.
+func (s *MissionService) CatAssignments(ctx context.Context, catID uint) ([]model.Assignment, error) {
+	p, err := authorize(ctx, auth.PermMissionsRead)
+	if err != nil {
 		return nil, err
 	}
 
-	return target, nil
+	if p.Role == auth.RoleCat && (p.CatID == nil || *p.CatID != catID) {
+		return nil, apperror.Forbidden("%s can only see its own assignments", p.Name)
+	}
+
+	if _, err := s.catRepo.GetByID(ctx, catID); err != nil {
+		return nil, err
+	}
+
+	return s.assignments.ListByCatID(ctx, catID)
+}
+
+// releaseIfFinal ends the assignment of a mission that has just been completed or aborted
+func (s *MissionService) releaseIfFinal(ctx context.Context, mission *model.Mission) error {
+	if !mission.Status.IsFinal() || mission.CatID == nil {
+		return nil
+	}
+	return s.assignments.Release(ctx, mission.ID, string(mission.Status))
+}
+
+// statusEvents are the events published when a mission moves to a status
+var statusEvents = map[model.MissionStatus]model.EventType{
+	model.MissionStatusInProgress: model.EventMissionStarted,
+	model.MissionStatusCompleted:  model.EventMissionCompleted,
+	model.MissionStatusAborted:    model.EventMissionAborted,
+}
+
+// publishStatus publishes the start, completion or abort of a mission that has just moved to that status
+func (s *MissionService) publishStatus(ctx context.Context, mission *model.Mission) error {
+	eventType, ok := statusEvents[mission.Status]
+	if !ok {
+		return nil
+	}
+	return s.events.Publish(ctx, eventType, "mission", mission.ID, missionScope(mission), mission)
+}
+
+// ensureCatAvailable fails if the cat has an active mission other than the given one
+func (s *MissionService) ensureCatAvailable(ctx context.Context, catID, missionID uint) error {
+	active, err := s.missionRepo.FindActiveByCatID(ctx, catID)
+	if err != nil {
+		return err
+	}
+
+	if active != nil && active.ID != missionID {
+		return catBusy(catID, active.ID)
+	}
+
+	return nil
+}
+
+// catBusyError resolves a lost race on the active mission index into a conflict naming the mission.
+// The lookup runs after the failed transaction has been rolled back.
+func (s *MissionService) catBusyError(ctx context.Context, err error, catID *uint) error {
+	if !errors.Is(err, repository.ErrCatUnavailable) || catID == nil {
+		return err
+	}
+
+	active, lookupErr := s.missionRepo.FindActiveByCatID(ctx, *catID)
+	if lookupErr != nil || active == nil {
+		return apperror.Conflict("cat %d already has an active mission", *catID)
+	}
+
+	return catBusy(*catID, active.ID)
+}
+
+// catBusy reports that the cat is already working on another active mission
+func catBusy(catID, missionID uint) error {
+	return apperror.Conflict("cat %d is already assigned to active mission %d", catID, missionID).
+		WithDetail("cat_id", catID).
+		WithDetail("mission_id", missionID)
+}
+
+// lockTarget locks the mission of a target, then reads the target again so that changes committed while
+// waiting for the lock are seen by the version check and the state checks that follow
+func (s *MissionService) lockTarget(ctx context.Context, targetID uint) (*model.Target, *model.Mission, error) {
+	target, err := s.targetRepo.GetByID(ctx, targetID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mission, err := s.missionRepo.GetByIDForUpdate(ctx, target.MissionID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if target, err = s.targetRepo.GetByID(ctx, targetID); err != nil {
+		return nil, nil, err
+	}
+
+	return target, mission, nil
+}
+
+// completeIfDone completes an in-progress mission once all of its targets are completed
+func (s *MissionService) completeIfDone(ctx context.Context, mission *model.Mission) error {
+	if mission.Status != model.MissionStatusInProgress {
+		return nil
+	}
+
+	targets, err := s.targetRepo.ListByMissionID(ctx, mission.ID)
+	if err != nil {
+		return err
+	}
+
+	if !allCompleted(targets) {
+		return nil
+	}
+
+	before := *mission
+	if err := transition(mission, model.MissionStatusCompleted); err != nil {
+		return err
+	}
+
+	if err := s.missionRepo.Update(ctx, mission); err != nil {
+		return err
+	}
+
+	if err := s.releaseIfFinal(ctx, mission); err != nil {
+		return err
+	}
+
+	if err := s.audit.Record(ctx, model.AuditActionUpdate, "mission", mission.ID, &before, mission); err != nil {
+		return err
+	}
+
+	return s.publishStatus(ctx, mission)
 }
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"SpyCatAgency/internal/apperror"
+	"SpyCatAgency/internal/auth"
+	"SpyCatAgency/internal/model"
+	"SpyCatAgency/internal/repository"
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// salaryReasonHired is the reason of the first salary ledger entry of a cat
+const salaryReasonHired = "hired"
+
+// today returns the current UTC date as YYYY-MM-DD, the format of ledger and payroll dates
+func today() string {
+	return time.Now().UTC().Format(time.DateOnly)
+}
+
+type PayrollService struct {
+	txManager repository.TxManager
+	cats      repository.CatRepository
+	salaries  repository.SalaryRepository
+	payrolls  repository.PayrollRepository
+	audit     *AuditService
+}
+
+func NewPayrollService(
+	txManager repository.TxManager,
+	cats repository.CatRepository,
+	salaries repository.SalaryRepository,
+	payrolls repository.PayrollRepository,
+	audit *AuditService,
+) *PayrollService {
+	return &PayrollService{
+		txManager: txManager,
+		cats:      cats,
+		salaries:  salaries,
+		payrolls:  payrolls,
+		audit:     audit,
+	}
+}
+
+// Run computes and stores the pay of every cat for a month that has ended. Monthly salaries are prorated by
+// day from the salary ledger, and deleted cats are paid until the day before they were deleted.
+func (s *PayrollService) Run(ctx context.Context, create model.PayrollRunCreate) (*model.PayrollRun, error) {
+	if _, err := authorize(ctx, auth.PermPayrollWrite); err != nil {
+		return nil, err
+	}
+
+	start, err := time.Parse("2006-01", create.Period)
+	if err != nil {
+		return nil, apperror.Validation("period must be a month as YYYY-MM")
+	}
+	end := start.AddDate(0, 1, -1)
+
+	run := &model.PayrollRun{
+		Period:      create.Period,
+		PeriodStart: start.Format(time.DateOnly),
+		PeriodEnd:   end.Format(time.DateOnly),
+		CreatedBy:   actor(ctx),
+	}
+	if run.PeriodEnd >= today() {
+		return nil, apperror.Validation("payroll for %s can only be run once the month has ended", run.Period)
+	}
+
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		changes, err := s.salaries.ListEffectiveBy(ctx, run.PeriodEnd)
+		if err != nil {
+			return err
+		}
+
+		byCat := make(map[uint][]model.SalaryChange)
+		var catIDs []uint
+		for _, change := range changes {
+			if _, ok := byCat[change.CatID]; !ok {
+				catIDs = append(catIDs, change.CatID)
+			}
+			byCat[change.CatID] = append(byCat[change.CatID], change)
+		}
+
+		cats := []model.Cat{}
+		if len(catIDs) > 0 {
+			if cats, err = s.cats.ListByIDs(repository.WithDeleted(ctx), catIDs); err != nil {
+				return err
+			}
+		}
+
+		run.Lines = []model.PayrollLine{}
+		cents := 0.0
+		for _, cat := range cats {
+			days, amount, err := prorate(byCat[cat.ID], start, end, cat.DeletedAt)
+			if err != nil {
+				return err
+			}
+			if days == 0 {
+				continue
+			}
+			run.Lines = append(run.Lines, model.PayrollLine{
+				CatID:    cat.ID,
+				CatName:  cat.Name,
+				DaysPaid: days,
+				Amount:   amount,
+			})
+			cents += math.Round(amount * 100)
+		}
+		run.Total = cents / 100
+
+		if err := s.payrolls.Create(ctx, run); err != nil {
+			return err
+		}
+
+		summary := *run
+		summary.Lines = nil
+		return s.audit.Record(ctx, model.AuditActionCreate, "payroll_run", run.ID, nil, &summary)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return run, nil
+}
+
+// List returns the payroll runs without their lines, latest month first
+func (s *PayrollService) List(ctx context.Context) ([]model.PayrollRun, error) {
+	if _, err := authorize(ctx, auth.PermPayrollRead); err != nil {
+		return nil, err
+	}
+	return s.payrolls.List(ctx)
+}
+
+func (s *PayrollService) GetByID(ctx context.Context, id uint) (*model.PayrollRun, error) {
+	if _, err := authorize(ctx, auth.PermPayrollRead); err != nil {
+		return nil, err
+	}
+	return s.payrolls.GetByID(ctx, id)
+}
+
+// prorate computes the pay of one cat from start to end, days of the same month. Every day is paid
+// 1/n of the monthly salary in effect that day, n being the number of days in the month. Changes must
+// be in effect order; days before the first change and from the day of deletion on are not paid.
+func prorate(changes []model.SalaryChange, start, end time.Time, deletedAt *time.Time) (int, float64, error) {
+	effective := make([]time.Time, len(changes))
+	for i, change := range changes {
+		date, err := time.Parse(time.DateOnly, change.EffectiveFrom)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid effective date of salary change %d: %w", change.ID, err)
+		}
+		effective[i] = date
+	}
+
+	if deletedAt != nil {
+		deleted := deletedAt.UTC().Truncate(24 * time.Hour)
+		if lastDay := deleted.AddDate(0, 0, -1); lastDay.Before(end) {
+			end = lastDay
+		}
+	}
+
+	daysInMonth := float64(start.AddDate(0, 1, -1).Day())
+	days, amount := 0, 0.0
+	current := -1
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		for current+1 < len(changes) && !effective[current+1].After(day) {
+			current++
+		}
+		if current < 0 {
+			continue
+		}
+		days++
+		amount += changes[current].Salary / daysInMonth
+	}
+
+	return days, math.Round(amount*100) / 100, nil
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"SpyCatAgency/internal/model"
+	"testing"
+	"time"
+)
+
+func TestProrate(t *testing.T) {
+	month := func(period string) (time.Time, time.Time) {
+		start, err := time.Parse("2006-01", period)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return start, start.AddDate(0, 1, -1)
+	}
+	at := func(value string) *time.Time {
+		deleted, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &deleted
+	}
+	change := func(id uint, salary float64, effectiveFrom string) model.SalaryChange {
+		return model.SalaryChange{ID: id, CatID: 1, Salary: salary, EffectiveFrom: effectiveFrom}
+	}
+
+	tests := []struct {
+		name       string
+		period     string
+		changes    []model.SalaryChange
+		deletedAt  *time.Time
+		wantDays   int
+		wantAmount float64
+		wantErr    bool
+	}{
+		{
+			name:       "31-day month",
+			period:     "2026-01",
+			changes:    []model.SalaryChange{change(1, 3100, "2025-06-01")},
+			wantDays:   31,
+			wantAmount: 3100,
+		},
+		{
+			name:       "30-day month",
+			period:     "2026-04",
+			changes:    []model.SalaryChange{change(1, 3000, "2025-06-01")},
+			wantDays:   30,
+			wantAmount: 3000,
+		},
+		{
+			name:       "28-day month",
+			period:     "2026-02",
+			changes:    []model.SalaryChange{change(1, 2800, "2025-06-01")},
+			wantDays:   28,
+			wantAmount: 2800,
+		},
+		{
+			name:       "leap February",
+			period:     "2028-02",
+			changes:    []model.SalaryChange{change(1, 2900, "2025-06-01")},
+			wantDays:   29,
+			wantAmount: 2900,
+		},
+		{
+			name:       "mid-month change",
+			period:     "2026-01",
+			changes:    []model.SalaryChange{change(1, 3100, "2025-06-01"), change(2, 6200, "2026-01-16")},
+			wantDays:   31,
+			wantAmount: 15*100 + 16*200,
+		},
+		{
+			name:       "change on the first day",
+			period:     "2026-04",
+			changes:    []model.SalaryChange{change(1, 3000, "2025-06-01"), change(2, 6000, "2026-04-01")},
+			wantDays:   30,
+			wantAmount: 6000,
+		},
+		{
+			name:       "days before the first change are not paid",
+			period:     "2026-04",
+			changes:    []model.SalaryChange{change(1, 3000, "2026-04-11")},
+			wantDays:   20,
+			wantAmount: 2000,
+		},
+		{
+			name:       "hired after the month",
+			period:     "2026-04",
+			changes:    []model.SalaryChange{change(1, 3000, "2026-05-01")},
+			wantDays:   0,
+			wantAmount: 0,
+		},
+		{
+			name:     "no salary",
+			period:   "2026-04",
+			wantDays: 0,
+		},
+		{
+			name:       "deleted on the 1st",
+			period:     "2026-04",
+			changes:    []model.SalaryChange{change(1, 3000, "2025-06-01")},
+			deletedAt:  at("2026-04-01T09:30:00Z"),
+			wantDays:   0,
+			wantAmount: 0,
+		},
+		{
+			name:       "deleted mid-month",
+			period:     "2026-04",
+			changes:    []model.SalaryChange{change(1, 3000, "2025-06-01")},
+			deletedAt:  at("2026-04-16T18:00:00Z"),
+			wantDays:   15,
+			wantAmount: 1500,
+		},
+		{
+			name:       "deletion time is taken in UTC",
+			period:     "2026-04",
+			changes:    []model.SalaryChange{change(1, 3000, "2025-06-01")},
+			deletedAt:  at("2026-04-16T01:00:00+03:00"),
+			wantDays:   14,
+			wantAmount: 1400,
+		},
+		{
+			name:       "deleted after the month",
+			period:     "2026-04",
+			changes:    []model.SalaryChange{change(1, 3000, "2025-06-01")},
+			deletedAt:  at("2026-05-02T00:00:00Z"),
+			wantDays:   30,
+			wantAmount: 3000,
+		},
+		{
+			name:       "deleted before the month",
+			period:     "2026-04",
+			changes:    []model.SalaryChange{change(1, 3000, "2025-06-01")},
+			deletedAt:  at("2026-03-10T00:00:00Z"),
+			wantDays:   0,
+			wantAmount: 0,
+		},
+		{
+			name:       "amount rounded to cents",
+			period:     "2026-01",
+			changes:    []model.SalaryChange{change(1, 1000, "2026-01-31")},
+			wantDays:   1,
+			wantAmount: 32.26,
+		},
+		{
+			name:    "invalid effective date",
+			period:  "2026-04",
+			changes: []model.SalaryChange{change(1, 3000, "2026-04")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := month(tt.period)
+
+			days, amount, err := prorate(tt.changes, start, end, tt.deletedAt)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if days != tt.wantDays || amount != tt.wantAmount {
+				t.Fatalf("got %d days for %.2f, want %d days for %.2f", days, amount, tt.wantDays, tt.wantAmount)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"SpyCatAgency/internal/logger"
+	"SpyCatAgency/internal/repository"
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// PurgeService permanently removes rows that have been soft-deleted for longer than the retention window
+type PurgeService struct {
+	txManager   repository.TxManager
+	catRepo     repository.CatRepository
+	missionRepo repository.MissionRepository
+	targetRepo  repository.TargetRepository
+	retention   time.Duration
+	interval    time.Duration
+}
+
+func NewPurgeService(
+	txManager repository.TxManager,
+	catRepo repository.CatRepository,
+	missionRepo repository.MissionRepository,
+	targetRepo repository.TargetRepository,
+	retention, interval time.Duration,
+) *PurgeService {
+	return &PurgeService{
+		txManager:   txManager,
+		catRepo:     catRepo,
+		missionRepo: missionRepo,
+		targetRepo:  targetRepo,
+		retention:   retention,
+		interval:    interval,
+	}
+}
+
+// Run purges on every interval until the context is cancelled
+func (s *PurgeService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Purge(ctx); err != nil {
+			logger.Error(ctx, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Purge removes expired targets first, then missions and cats no longer referenced by them
+func (s *PurgeService) Purge(ctx context.Context) error {
+	var targets, missions, cats int64
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if targets, err = s.targetRepo.Purge(ctx, s.retention); err != nil {
+			return fmt.Errorf("failed to purge targets: %w", err)
+		}
+		if missions, err = s.missionRepo.Purge(ctx, s.retention); err != nil {
+			return fmt.Errorf("failed to purge missions: %w", err)
+		}
+		if cats, err = s.catRepo.Purge(ctx, s.retention); err != nil {
+			return fmt.Errorf("failed to purge cats: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if targets+missions+cats > 0 {
+		logger.Info(ctx, "Purged soft-deleted rows",
+			slog.Int64("targets", targets),
+			slog.Int64("missions", missions),
+			slog.Int64("cats", cats),
+		)
+	}
+	return nil
+}
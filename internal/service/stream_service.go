@@ -0,0 +1,231 @@
+package service
+
+import (
+	"SpyCatAgency/internal/auth"
+	"SpyCatAgency/internal/logger"
+	"SpyCatAgency/internal/model"
+	"SpyCatAgency/internal/repository"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// streamBatchSize bounds the events read from the outbox per query
+	streamBatchSize = 500
+	// streamSettleDelay is how long a gap in event ids is waited out before the events after it are streamed.
+	// Ids are taken when a transaction writes its event but become visible when it commits, so a younger
+	// gap is usually a transaction that is still running.
+	streamSettleDelay = 5 * time.Second
+)
+
+// streamEntities are the entities whose events are streamed
+var streamEntities = map[string]bool{
+	"mission": true,
+	"target":  true,
+}
+
+// StreamService streams mission and target events from the outbox to live subscribers. It polls the
+// outbox, so it sees the changes made through every instance of the API.
+type StreamService struct {
+	repo     repository.OutboxRepository
+	interval time.Duration
+	buffer   int
+
+	mu      sync.Mutex
+	started bool
+	// last is the id of the newest event handed to subscribers
+	last uint64
+	subs map[*Subscription]struct{}
+}
+
+// Subscription receives the events matching its filter as they are published. A subscriber that falls
+// more than the buffer behind is dropped and its channel closed; it can resume from its last event.
+type Subscription struct {
+	filter model.EventStreamFilter
+	events chan model.Event
+	// from is the id of the newest event published before the subscription; later events are sent on events
+	from    uint64
+	dropped bool
+}
+
+func NewStreamService(repo repository.OutboxRepository, interval time.Duration, buffer int) *StreamService {
+	return &StreamService{
+		repo:     repo,
+		interval: interval,
+		buffer:   buffer,
+		subs:     make(map[*Subscription]struct{}),
+	}
+}
+
+// Events returns the channel live events are sent on. It is closed when the subscriber is dropped.
+func (sub *Subscription) Events() <-chan model.Event {
+	return sub.events
+}
+
+// Dropped reports whether the subscription was closed because the subscriber fell behind
+func (sub *Subscription) Dropped() bool {
+	return sub.dropped
+}
+
+// Subscribe starts receiving the events matching the filter. Cats only receive events of their own missions.
+func (s *StreamService) Subscribe(ctx context.Context, filter model.EventStreamFilter) (*Subscription, error) {
+	p, err := authorize(ctx, auth.PermMissionsRead)
+	if err != nil {
+		return nil, err
+	}
+	if p.Role == auth.RoleCat {
+		// A cat account without a cat matches no events
+		filter.CatID = p.CatID
+		if filter.CatID == nil {
+			filter.CatID = new(uint)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.start(ctx); err != nil {
+		return nil, err
+	}
+
+	sub := &Subscription{
+		filter: filter,
+		events: make(chan model.Event, s.buffer),
+		from:   s.last,
+	}
+	s.subs[sub] = struct{}{}
+	return sub, nil
+}
+
+// Unsubscribe stops sending events to the subscription
+func (s *StreamService) Unsubscribe(sub *Subscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.subs[sub]; ok {
+		delete(s.subs, sub)
+		close(sub.events)
+	}
+}
+
+// Replay sends the stored events after afterID that were published before the subscription started, so a
+// subscriber resuming from its last event misses nothing between the two
+func (s *StreamService) Replay(
+	ctx context.Context,
+	sub *Subscription,
+	afterID uint64,
+	send func(model.Event) error,
+) error {
+	for afterID < sub.from {
+		events, err := s.repo.ListAfter(ctx, afterID, streamBatchSize, 0)
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+
+		for _, event := range events {
+			if event.ID > sub.from {
+				return nil
+			}
+			if sub.filter.Matches(event) && streamEntities[event.Entity] {
+				if err := send(event); err != nil {
+					return err
+				}
+			}
+			afterID = event.ID
+		}
+	}
+	return nil
+}
+
+// Run polls the outbox on every interval until the context is cancelled
+func (s *StreamService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Poll(ctx); err != nil {
+			logger.Error(ctx, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll hands the events published since the last poll to the subscribers
+func (s *StreamService) Poll(ctx context.Context) error {
+	s.mu.Lock()
+	err := s.start(ctx)
+	last := s.last
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	for {
+		events, err := s.repo.ListAfter(ctx, last, streamBatchSize, streamSettleDelay)
+		if err != nil {
+			return fmt.Errorf("failed to read outbox events: %w", err)
+		}
+		if len(events) == 0 {
+			return nil
+		}
+
+		s.publish(events)
+		last = events[len(events)-1].ID
+
+		if len(events) < streamBatchSize {
+			return nil
+		}
+	}
+}
+
+// start positions the stream after the newest stored event. It must be called with the lock held.
+func (s *StreamService) start(ctx context.Context) error {
+	if s.started {
+		return nil
+	}
+
+	last, err := s.repo.LastID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to position event stream: %w", err)
+	}
+	s.last = last
+	s.started = true
+	return nil
+}
+
+// publish sends events to the matching subscribers without blocking. Subscribers whose buffer is full are
+// dropped rather than slowing down the others.
+func (s *StreamService) publish(events []model.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, event := range events {
+		s.last = event.ID
+		if !streamEntities[event.Entity] {
+			continue
+		}
+
+		for sub := range s.subs {
+			if !sub.filter.Matches(event) {
+				continue
+			}
+			select {
+			case sub.events <- event:
+			default:
+				sub.dropped = true
+				delete(s.subs, sub)
+				close(sub.events)
+			}
+		}
+	}
+}
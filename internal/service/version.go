@@ -0,0 +1,11 @@
+package service
+
+import "SpyCatAgency/internal/apperror"
+
+// checkVersion enforces the version a client expects a resource to have. Zero means any version.
+func checkVersion(entity string, id uint, expected, actual int) error {
+	if expected != 0 && expected != actual {
+		return apperror.PreconditionFailed("%s %d is at version %d, not %d", entity, id, actual, expected)
+	}
+	return nil
+}
@@ -0,0 +1,309 @@
+package service
+
+import (
+	"SpyCatAgency/internal/apperror"
+	"SpyCatAgency/internal/auth"
+	"SpyCatAgency/internal/logger"
+	"SpyCatAgency/internal/model"
+	"SpyCatAgency/internal/repository"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	mathrand "math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// webhookSecretPrefix marks webhook signing secrets
+	webhookSecretPrefix = "whsec_"
+	// webhookBatchSize bounds the events dispatched and the deliveries sent per poll
+	webhookBatchSize = 50
+	// webhookRetryBaseDelay is the backoff ceiling after the first failed attempt; it doubles with every attempt
+	webhookRetryBaseDelay = 30 * time.Second
+	webhookRetryMaxDelay  = time.Hour
+	// maxDeliveryError bounds the error recorded for a failed attempt
+	maxDeliveryError = 512
+)
+
+// Headers sent with every delivery. The signature is "t=<unix seconds>,v1=<hex HMAC-SHA256>" computed
+// with the webhook secret over "<unix seconds>.<body>", so receivers can reject replayed deliveries.
+const (
+	HeaderWebhookEvent     = "X-SpyCat-Event"
+	HeaderWebhookDelivery  = "X-SpyCat-Delivery"
+	HeaderWebhookSignature = "X-SpyCat-Signature"
+)
+
+// WebhookService manages webhooks and runs the worker that delivers outbox events to them
+type WebhookService struct {
+	txManager   repository.TxManager
+	repo        repository.WebhookRepository
+	outbox      repository.OutboxRepository
+	audit       *AuditService
+	client      *http.Client
+	interval    time.Duration
+	maxAttempts int
+}
+
+func NewWebhookService(
+	txManager repository.TxManager,
+	repo repository.WebhookRepository,
+	outbox repository.OutboxRepository,
+	audit *AuditService,
+	interval, timeout time.Duration,
+	maxAttempts int,
+) *WebhookService {
+	return &WebhookService{
+		txManager: txManager,
+		repo:      repo,
+		outbox:    outbox,
+		audit:     audit,
+		client: &http.Client{
+			Timeout: timeout,
+			// A redirect is a failed delivery; following it would send signed events to another host
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		interval:    interval,
+		maxAttempts: maxAttempts,
+	}
+}
+
+// Create registers a webhook. The returned secret is the only time the signing secret is shown.
+func (s *WebhookService) Create(ctx context.Context, create model.WebhookCreate) (*model.WebhookSecret, error) {
+	p, err := authorize(ctx, auth.PermWebhooksWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range create.Events {
+		if !slices.Contains(model.EventTypes, model.EventType(name)) {
+			return nil, apperror.Validation("unknown event %q", name).WithDetail("events", model.EventTypes)
+		}
+	}
+
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	events := slices.Compact(slices.Sorted(slices.Values(create.Events)))
+	if events == nil {
+		events = []string{}
+	}
+
+	webhook := &model.Webhook{
+		URL:       create.URL,
+		Secret:    secret,
+		Events:    events,
+		CreatedBy: p.Name,
+	}
+
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, webhook); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, model.AuditActionCreate, "webhook", webhook.ID, nil, webhook)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.WebhookSecret{Webhook: *webhook, Secret: secret}, nil
+}
+
+func (s *WebhookService) List(ctx context.Context) ([]model.Webhook, error) {
+	if _, err := authorize(ctx, auth.PermWebhooksWrite); err != nil {
+		return nil, err
+	}
+	return s.repo.List(ctx)
+}
+
+// Delete stops all deliveries to the webhook, including pending retries
+func (s *WebhookService) Delete(ctx context.Context, id uint) error {
+	if _, err := authorize(ctx, auth.PermWebhooksWrite); err != nil {
+		return err
+	}
+
+	return s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		webhook, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, model.AuditActionDelete, "webhook", id, webhook, nil)
+	})
+}
+
+// ListDeliveries returns deliveries of events to webhooks. Filtering by status dead gives the dead-letter list.
+func (s *WebhookService) ListDeliveries(
+	ctx context.Context,
+	filter model.WebhookDeliveryFilter,
+) (*model.WebhookDeliveryPage, error) {
+	if _, err := authorize(ctx, auth.PermWebhooksWrite); err != nil {
+		return nil, err
+	}
+	return s.repo.ListDeliveries(ctx, filter)
+}
+
+// Retry queues a dead delivery again with a fresh set of attempts
+func (s *WebhookService) Retry(ctx context.Context, id uint64) error {
+	if _, err := authorize(ctx, auth.PermWebhooksWrite); err != nil {
+		return err
+	}
+	return s.repo.Retry(ctx, id)
+}
+
+// Run dispatches new events and sends due deliveries on every interval until the context is cancelled
+func (s *WebhookService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Deliver(ctx); err != nil {
+			logger.Error(ctx, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Deliver fans new outbox events out to the subscribed webhooks and sends one batch of due deliveries.
+// Deliveries are leased for longer than a request can take, so other instances do not send them twice.
+func (s *WebhookService) Deliver(ctx context.Context) error {
+	if _, err := s.outbox.Dispatch(ctx, webhookBatchSize); err != nil {
+		return fmt.Errorf("failed to dispatch outbox events: %w", err)
+	}
+
+	jobs, err := s.repo.ClaimDue(ctx, webhookBatchSize, 2*s.client.Timeout)
+	if err != nil {
+		return fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+
+	// Claimed deliveries are sent even when shutdown begins meanwhile; the client timeout bounds them
+	sendCtx := context.WithoutCancel(ctx)
+
+	var wg sync.WaitGroup
+	for _, job := range jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := s.send(sendCtx, job); err != nil {
+				logger.Error(ctx, err, slog.Uint64("delivery_id", job.DeliveryID))
+			}
+		}()
+	}
+	wg.Wait()
+
+	return nil
+}
+
+// send makes one attempt at a delivery and records its outcome
+func (s *WebhookService) send(ctx context.Context, job model.DeliveryJob) error {
+	statusCode, err := s.post(ctx, job)
+	if err == nil {
+		return s.repo.MarkDelivered(ctx, job.DeliveryID, statusCode)
+	}
+
+	var code *int
+	if statusCode != 0 {
+		code = &statusCode
+	}
+
+	var retryAt *time.Time
+	if job.Attempt < s.maxAttempts {
+		next := time.Now().Add(webhookBackoff(job.Attempt))
+		retryAt = &next
+	} else {
+		logger.Info(ctx, "Webhook delivery is dead",
+			slog.Uint64("delivery_id", job.DeliveryID),
+			slog.Uint64("webhook_id", uint64(job.Webhook.ID)),
+			slog.Int("attempts", job.Attempt),
+		)
+	}
+
+	reason := err.Error()
+	if len(reason) > maxDeliveryError {
+		reason = reason[:maxDeliveryError]
+	}
+	return s.repo.MarkFailed(ctx, job.DeliveryID, code, reason, retryAt)
+}
+
+// post sends the event to the webhook. Any response other than 2xx is an error; its status code is returned.
+func (s *WebhookService) post(ctx context.Context, job model.DeliveryJob) (int, error) {
+	body, err := json.Marshal(job.Event)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, job.Webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderWebhookEvent, string(job.Event.Type))
+	req.Header.Set(HeaderWebhookDelivery, strconv.FormatUint(job.DeliveryID, 10))
+	req.Header.Set(HeaderWebhookSignature, SignWebhook(job.Webhook.Secret, time.Now(), body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, errors.New(resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// SignWebhook returns the signature header of a delivery body sent at the given time
+func SignWebhook(secret string, at time.Time, body []byte) string {
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "t=" + timestamp + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookBackoff returns the delay before the attempt following the given one. Delays grow
+// exponentially with full jitter so endpoints coming back up are not hit by every retry at once.
+func webhookBackoff(attempt int) time.Duration {
+	ceiling := webhookRetryMaxDelay
+	if shift := attempt - 1; shift < 16 {
+		ceiling = min(webhookRetryBaseDelay<<shift, webhookRetryMaxDelay)
+	}
+	return mathrand.N(ceiling + 1)
+}
+
+func newWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return webhookSecretPrefix + base64.RawURLEncoding.EncodeToString(secret), nil
+}
@@ -0,0 +1,23 @@
+-- +goose Up
+ALTER TABLE missions ADD COLUMN status VARCHAR(32) NOT NULL DEFAULT 'draft';
+
+UPDATE missions SET status = 'completed' WHERE completed;
+UPDATE missions m SET status = 'in_progress'
+WHERE NOT m.completed
+  AND EXISTS (SELECT 1 FROM targets t WHERE t.mission_id = m.id AND t.completed);
+UPDATE missions SET status = 'assigned' WHERE status = 'draft';
+
+ALTER TABLE missions DROP COLUMN completed;
+ALTER TABLE missions ALTER COLUMN cat_id DROP NOT NULL;
+ALTER TABLE missions ADD CONSTRAINT missions_status_check
+    CHECK (status IN ('draft', 'assigned', 'in_progress', 'completed', 'aborted'));
+
+-- +goose Down
+ALTER TABLE missions DROP CONSTRAINT IF EXISTS missions_status_check;
+-- Drafts without a cat cannot exist in the old schema
+DELETE FROM targets WHERE mission_id IN (SELECT id FROM missions WHERE cat_id IS NULL);
+DELETE FROM missions WHERE cat_id IS NULL;
+ALTER TABLE missions ALTER COLUMN cat_id SET NOT NULL;
+ALTER TABLE missions ADD COLUMN completed BOOLEAN NOT NULL DEFAULT FALSE;
+UPDATE missions SET completed = TRUE WHERE status = 'completed';
+ALTER TABLE missions DROP COLUMN status;
@@ -0,0 +1,17 @@
+-- +goose Up
+-- Cats may already be on several active missions; keep the newest one and abort the others
+UPDATE missions m SET status = 'aborted', updated_at = CURRENT_TIMESTAMP
+WHERE m.status IN ('assigned', 'in_progress')
+  AND EXISTS (
+    SELECT 1 FROM missions newer
+    WHERE newer.cat_id = m.cat_id
+      AND newer.status IN ('assigned', 'in_progress')
+      AND (newer.created_at, newer.id) > (m.created_at, m.id)
+  );
+
+CREATE UNIQUE INDEX missions_one_active_per_cat
+    ON missions (cat_id)
+    WHERE status IN ('assigned', 'in_progress');
+
+-- +goose Down
+DROP INDEX IF EXISTS missions_one_active_per_cat;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE cats ADD COLUMN breed_verified BOOLEAN NOT NULL DEFAULT TRUE;
+
+-- +goose Down
+ALTER TABLE cats DROP COLUMN breed_verified;
@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE cats ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE missions ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE targets ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+-- +goose Down
+ALTER TABLE targets DROP COLUMN version;
+ALTER TABLE missions DROP COLUMN version;
+ALTER TABLE cats DROP COLUMN version;
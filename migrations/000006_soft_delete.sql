@@ -0,0 +1,32 @@
+-- +goose Up
+ALTER TABLE cats ADD COLUMN deleted_at TIMESTAMP;
+ALTER TABLE missions ADD COLUMN deleted_at TIMESTAMP;
+ALTER TABLE targets ADD COLUMN deleted_at TIMESTAMP;
+
+CREATE INDEX cats_deleted_at ON cats (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX missions_deleted_at ON missions (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX targets_deleted_at ON targets (deleted_at) WHERE deleted_at IS NOT NULL;
+
+DROP INDEX missions_one_active_per_cat;
+CREATE UNIQUE INDEX missions_one_active_per_cat
+    ON missions (cat_id)
+    WHERE status IN ('assigned', 'in_progress') AND deleted_at IS NULL;
+
+-- +goose Down
+DELETE FROM targets WHERE deleted_at IS NOT NULL;
+DELETE FROM targets WHERE mission_id IN (SELECT id FROM missions WHERE deleted_at IS NOT NULL);
+DELETE FROM missions WHERE deleted_at IS NOT NULL;
+DELETE FROM cats WHERE deleted_at IS NOT NULL AND NOT EXISTS (SELECT 1 FROM missions WHERE cat_id = cats.id);
+
+DROP INDEX missions_one_active_per_cat;
+CREATE UNIQUE INDEX missions_one_active_per_cat
+    ON missions (cat_id)
+    WHERE status IN ('assigned', 'in_progress');
+
+DROP INDEX IF EXISTS targets_deleted_at;
+DROP INDEX IF EXISTS missions_deleted_at;
+DROP INDEX IF EXISTS cats_deleted_at;
+
+ALTER TABLE targets DROP COLUMN deleted_at;
+ALTER TABLE missions DROP COLUMN deleted_at;
+ALTER TABLE cats DROP COLUMN deleted_at;
@@ -0,0 +1,20 @@
+-- +goose Up
+CREATE TABLE audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    request_id VARCHAR(26) NOT NULL DEFAULT '',
+    actor VARCHAR(255) NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    entity VARCHAR(20) NOT NULL,
+    entity_id INTEGER NOT NULL,
+    before JSONB,
+    after JSONB,
+    changes JSONB,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX audit_log_entity ON audit_log (entity, entity_id);
+CREATE INDEX audit_log_created_at ON audit_log (created_at);
+CREATE INDEX audit_log_request_id ON audit_log (request_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS audit_log;
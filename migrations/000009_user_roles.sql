@@ -0,0 +1,14 @@
+-- +goose Up
+-- Accounts created before roles existed had full access and become admins
+ALTER TABLE users ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'admin';
+ALTER TABLE users ALTER COLUMN role DROP DEFAULT;
+ALTER TABLE users ADD COLUMN cat_id INTEGER REFERENCES cats(id);
+
+ALTER TABLE users ADD CONSTRAINT users_role_check CHECK (role IN ('admin', 'handler', 'cat'));
+ALTER TABLE users ADD CONSTRAINT users_cat_id_check CHECK ((role = 'cat') = (cat_id IS NOT NULL));
+
+-- +goose Down
+ALTER TABLE users DROP CONSTRAINT users_cat_id_check;
+ALTER TABLE users DROP CONSTRAINT users_role_check;
+ALTER TABLE users DROP COLUMN cat_id;
+ALTER TABLE users DROP COLUMN role;
@@ -0,0 +1,18 @@
+-- +goose Up
+CREATE TABLE target_notes (
+    target_id INTEGER NOT NULL REFERENCES targets(id) ON DELETE CASCADE,
+    revision INTEGER NOT NULL,
+    content TEXT NOT NULL,
+    author VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (target_id, revision)
+);
+
+-- Notes written before the history existed become the first revision
+INSERT INTO target_notes (target_id, revision, content, author, created_at)
+SELECT id, 1, notes, 'unknown', updated_at
+FROM targets
+WHERE COALESCE(notes, '') <> '';
+
+-- +goose Down
+DROP TABLE IF EXISTS target_notes;
@@ -0,0 +1,25 @@
+-- +goose Up
+CREATE TABLE mission_assignments (
+    id SERIAL PRIMARY KEY,
+    mission_id INTEGER NOT NULL REFERENCES missions(id) ON DELETE CASCADE,
+    cat_id INTEGER NOT NULL REFERENCES cats(id) ON DELETE CASCADE,
+    assigned_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    released_at TIMESTAMP,
+    reason VARCHAR(255)
+);
+
+CREATE UNIQUE INDEX mission_assignments_one_open_per_mission
+    ON mission_assignments (mission_id)
+    WHERE released_at IS NULL;
+CREATE INDEX mission_assignments_cat_id ON mission_assignments (cat_id);
+
+-- Current assignments are all that is known about the past; finished missions released their cat when last updated
+INSERT INTO mission_assignments (mission_id, cat_id, assigned_at, released_at, reason)
+SELECT id, cat_id, created_at,
+       CASE WHEN status IN ('completed', 'aborted') THEN updated_at END,
+       CASE WHEN status IN ('completed', 'aborted') THEN status END
+FROM missions
+WHERE cat_id IS NOT NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS mission_assignments;
@@ -0,0 +1,41 @@
+-- +goose Up
+CREATE TABLE salary_changes (
+    id SERIAL PRIMARY KEY,
+    cat_id INTEGER NOT NULL REFERENCES cats(id) ON DELETE CASCADE,
+    salary DECIMAL(10,2) NOT NULL,
+    effective_from DATE NOT NULL,
+    changed_by VARCHAR(255) NOT NULL,
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX salary_changes_cat_id ON salary_changes (cat_id, effective_from);
+
+-- The current salary of every cat is taken to have applied since the cat was created
+INSERT INTO salary_changes (cat_id, salary, effective_from, changed_by, reason, created_at)
+SELECT id, salary, created_at::date, 'unknown', 'hired', created_at
+FROM cats;
+
+CREATE TABLE payroll_runs (
+    id SERIAL PRIMARY KEY,
+    period_start DATE NOT NULL UNIQUE,
+    period_end DATE NOT NULL,
+    total DECIMAL(12,2) NOT NULL,
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Lines keep the name of the cat so runs stay readable after the cat is purged
+CREATE TABLE payroll_lines (
+    run_id INTEGER NOT NULL REFERENCES payroll_runs(id) ON DELETE CASCADE,
+    cat_id INTEGER NOT NULL,
+    cat_name VARCHAR(255) NOT NULL,
+    days_paid INTEGER NOT NULL,
+    amount DECIMAL(12,2) NOT NULL,
+    PRIMARY KEY (run_id, cat_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS payroll_lines;
+DROP TABLE IF EXISTS payroll_runs;
+DROP TABLE IF EXISTS salary_changes;
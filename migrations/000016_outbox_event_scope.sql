@@ -0,0 +1,19 @@
+-- +goose Up
+ALTER TABLE outbox_events
+    ADD COLUMN mission_id INTEGER,
+    ADD COLUMN cat_id INTEGER;
+
+UPDATE outbox_events
+SET mission_id = CASE entity
+        WHEN 'mission' THEN entity_id
+        WHEN 'target' THEN (payload->>'mission_id')::INTEGER
+    END,
+    cat_id = CASE entity
+        WHEN 'cat' THEN entity_id
+        WHEN 'mission' THEN (payload->>'cat_id')::INTEGER
+    END;
+
+-- +goose Down
+ALTER TABLE outbox_events
+    DROP COLUMN cat_id,
+    DROP COLUMN mission_id;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- Retry times are absolute instants compared with NOW(); existing values were written in the session time zone
+ALTER TABLE webhook_deliveries ALTER COLUMN next_attempt_at TYPE TIMESTAMPTZ;
+
+-- +goose Down
+ALTER TABLE webhook_deliveries ALTER COLUMN next_attempt_at TYPE TIMESTAMP;
@@ -0,0 +1,82 @@
+package spycatclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// ListAuditEntries returns one page of the audit log; see AllAuditEntries to iterate over every page
+func (c *Client) ListAuditEntries(ctx context.Context, filter AuditFilter) (*AuditPage, error) {
+	var page AuditPage
+	if err := c.do(ctx, request{method: http.MethodGet, path: "/api/audit", query: queryOf(filter)}, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+func (c *Client) ListPayrollRuns(ctx context.Context) ([]PayrollRun, error) {
+	var runs []PayrollRun
+	if err := c.do(ctx, request{method: http.MethodGet, path: "/api/payroll/runs"}, &runs); err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
+
+// CreatePayrollRun pays every cat for a month. Months are paid in order and only once.
+func (c *Client) CreatePayrollRun(ctx context.Context, create PayrollRunCreate) (*PayrollRun, error) {
+	var run PayrollRun
+	if err := c.do(ctx, request{method: http.MethodPost, path: "/api/payroll/runs", body: create}, &run); err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+func (c *Client) GetPayrollRun(ctx context.Context, id uint) (*PayrollRun, error) {
+	var run PayrollRun
+	if err := c.do(ctx, request{method: http.MethodGet, path: fmt.Sprintf("/api/payroll/runs/%d", id)}, &run); err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// ExportPayrollRun returns the lines of a payroll run as CSV
+func (c *Client) ExportPayrollRun(ctx context.Context, id uint) ([]byte, error) {
+	return c.download(ctx, request{method: http.MethodGet, path: fmt.Sprintf("/api/payroll/runs/%d/export", id)})
+}
+
+// CreateWebhook registers a webhook. The secret deliveries are signed with is only returned here.
+func (c *Client) CreateWebhook(ctx context.Context, create WebhookCreate) (*WebhookSecret, error) {
+	var webhook WebhookSecret
+	if err := c.do(ctx, request{method: http.MethodPost, path: "/api/webhooks", body: create}, &webhook); err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (c *Client) ListWebhooks(ctx context.Context) ([]Webhook, error) {
+	var webhooks []Webhook
+	if err := c.do(ctx, request{method: http.MethodGet, path: "/api/webhooks"}, &webhooks); err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+func (c *Client) DeleteWebhook(ctx context.Context, id uint) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: fmt.Sprintf("/api/webhooks/%d", id)}, nil)
+}
+
+// ListWebhookDeliveries returns one page of deliveries; see AllWebhookDeliveries to iterate over every page
+func (c *Client) ListWebhookDeliveries(ctx context.Context, filter WebhookDeliveryFilter) (*WebhookDeliveryPage, error) {
+	var page WebhookDeliveryPage
+	r := request{method: http.MethodGet, path: "/api/webhooks/deliveries", query: queryOf(filter)}
+	if err := c.do(ctx, r, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// RetryWebhookDelivery sends a dead delivery again
+func (c *Client) RetryWebhookDelivery(ctx context.Context, id uint64) error {
+	return c.do(ctx, request{method: http.MethodPost, path: fmt.Sprintf("/api/webhooks/deliveries/%d/retry", id)}, nil)
+}
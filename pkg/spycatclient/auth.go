@@ -0,0 +1,58 @@
+package spycatclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Login exchanges a username and password for a JWT, which the client sends with later calls
+func (c *Client) Login(ctx context.Context, credentials Credentials) (*Token, error) {
+	var token Token
+	if err := c.do(ctx, request{method: http.MethodPost, path: "/api/auth/token", body: credentials}, &token); err != nil {
+		return nil, err
+	}
+
+	c.SetToken(token.AccessToken)
+	return &token, nil
+}
+
+// CreateUser creates an account; admins only
+func (c *Client) CreateUser(ctx context.Context, create UserCreate) (*User, error) {
+	var user User
+	if err := c.do(ctx, request{method: http.MethodPost, path: "/api/users", body: create}, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (c *Client) ListAPIKeys(ctx context.Context) ([]APIKey, error) {
+	var keys []APIKey
+	if err := c.do(ctx, request{method: http.MethodGet, path: "/api/apikeys"}, &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// CreateAPIKey creates an API key. The key is only returned here.
+func (c *Client) CreateAPIKey(ctx context.Context, create APIKeyCreate) (*APIKeySecret, error) {
+	var key APIKeySecret
+	if err := c.do(ctx, request{method: http.MethodPost, path: "/api/apikeys", body: create}, &key); err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// RotateAPIKey replaces the secret of an API key, keeping its name and scopes
+func (c *Client) RotateAPIKey(ctx context.Context, id uint) (*APIKeySecret, error) {
+	var key APIKeySecret
+	r := request{method: http.MethodPost, path: fmt.Sprintf("/api/apikeys/%d/rotate", id)}
+	if err := c.do(ctx, r, &key); err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (c *Client) RevokeAPIKey(ctx context.Context, id uint) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: fmt.Sprintf("/api/apikeys/%d", id)}, nil)
+}
@@ -0,0 +1,152 @@
+package spycatclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+const csvContentType = "text/csv"
+
+func (c *Client) CreateCat(ctx context.Context, create CatCreate) (*Cat, error) {
+	var cat Cat
+	if err := c.do(ctx, request{method: http.MethodPost, path: "/api/cats/create", body: create}, &cat); err != nil {
+		return nil, err
+	}
+	return &cat, nil
+}
+
+func (c *Client) GetCat(ctx context.Context, id uint) (*Cat, error) {
+	var cat Cat
+	r := request{method: http.MethodGet, path: fmt.Sprintf("/api/cats/%d", id), readsDeleted: true}
+	if err := c.do(ctx, r, &cat); err != nil {
+		return nil, err
+	}
+	return &cat, nil
+}
+
+// ListCats returns one page of cats; see AllCats to iterate over every page
+func (c *Client) ListCats(ctx context.Context, filter CatFilter) (*CatPage, error) {
+	var page CatPage
+	r := request{method: http.MethodGet, path: "/api/cats/list", query: queryOf(filter), readsDeleted: true}
+	if err := c.do(ctx, r, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// UpdateCatSalary changes the salary of a cat. A non-zero version must be the current version of the cat.
+func (c *Client) UpdateCatSalary(ctx context.Context, id uint, version int, update CatUpdate) (*Cat, error) {
+	var cat Cat
+	r := request{method: http.MethodPut, path: fmt.Sprintf("/api/cats/%d/salary", id), body: update, version: version}
+	if err := c.do(ctx, r, &cat); err != nil {
+		return nil, err
+	}
+	return &cat, nil
+}
+
+// DeleteCat soft-deletes a cat. A non-zero version must be the current version of the cat.
+func (c *Client) DeleteCat(ctx context.Context, id uint, version int) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: fmt.Sprintf("/api/cats/%d", id), version: version}, nil)
+}
+
+func (c *Client) RestoreCat(ctx context.Context, id uint) (*Cat, error) {
+	var cat Cat
+	if err := c.do(ctx, request{method: http.MethodPost, path: fmt.Sprintf("/api/cats/%d/restore", id)}, &cat); err != nil {
+		return nil, err
+	}
+	return &cat, nil
+}
+
+func (c *Client) CatSalaryHistory(ctx context.Context, id uint) ([]SalaryChange, error) {
+	var changes []SalaryChange
+	r := request{method: http.MethodGet, path: fmt.Sprintf("/api/cats/%d/salary-history", id), readsDeleted: true}
+	if err := c.do(ctx, r, &changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// CatAssignments returns the missions a cat was assigned to, oldest first
+func (c *Client) CatAssignments(ctx context.Context, id uint) ([]Assignment, error) {
+	var assignments []Assignment
+	r := request{method: http.MethodGet, path: fmt.Sprintf("/api/cats/%d/assignments", id), readsDeleted: true}
+	if err := c.do(ctx, r, &assignments); err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
+
+// ImportCats creates up to 1000 cats. With atomic, any invalid cat rejects the whole import.
+func (c *Client) ImportCats(ctx context.Context, cats []CatCreate, atomic bool) (*ImportReport, error) {
+	return c.importItems(ctx, request{method: http.MethodPost, path: "/api/cats/import", body: cats}, atomic)
+}
+
+// ImportCatsCSV creates cats from a CSV file with the columns name, years_experience, breed and salary
+func (c *Client) ImportCatsCSV(ctx context.Context, csv []byte, atomic bool) (*ImportReport, error) {
+	return c.importItems(ctx, request{method: http.MethodPost, path: "/api/cats/import", body: csv, contentType: csvContentType}, atomic)
+}
+
+// ExportCats returns every cat matching the filter; its pagination fields are ignored
+func (c *Client) ExportCats(ctx context.Context, filter CatFilter) ([]Cat, error) {
+	var cats []Cat
+	r := request{method: http.MethodGet, path: "/api/cats/export", query: exportQuery(filter, "json"), readsDeleted: true}
+	if err := c.do(ctx, r, &cats); err != nil {
+		return nil, err
+	}
+	return cats, nil
+}
+
+// ExportCatsCSV returns every cat matching the filter in the CSV format accepted by ImportCatsCSV
+func (c *Client) ExportCatsCSV(ctx context.Context, filter CatFilter) ([]byte, error) {
+	r := request{method: http.MethodGet, path: "/api/cats/export", query: exportQuery(filter, "csv"), readsDeleted: true}
+	return c.download(ctx, r)
+}
+
+// ListBreeds returns the breeds accepted when creating a cat
+func (c *Client) ListBreeds(ctx context.Context) ([]CatBreed, error) {
+	var breeds []CatBreed
+	if err := c.do(ctx, request{method: http.MethodGet, path: "/api/breeds"}, &breeds); err != nil {
+		return nil, err
+	}
+	return breeds, nil
+}
+
+// importItems sends r to an import route
+func (c *Client) importItems(ctx context.Context, r request, atomic bool) (*ImportReport, error) {
+	if atomic {
+		r.query = url.Values{"atomic": {"true"}}
+	}
+
+	var report ImportReport
+	if err := c.do(ctx, r, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// download returns the body of a response that is not JSON, e.g. a CSV export
+func (c *Client) download(ctx context.Context, r request) ([]byte, error) {
+	resp, err := c.send(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("spycatclient: failed to read response of %s %s: %w", r.method, r.path, err)
+	}
+	return body, nil
+}
+
+// exportQuery is the query of an export: the filters of the list without its pagination
+func exportQuery(filter any, format string) url.Values {
+	query := queryOf(filter)
+	query.Del("limit")
+	query.Del("cursor")
+	query.Set("format", format)
+	return query
+}
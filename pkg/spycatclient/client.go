@@ -0,0 +1,296 @@
+// Package spycatclient is the Go client of the SpyCat Agency HTTP API. It has a typed method for every route
+// documented in the Swagger spec (cmd/api/docs), decodes error responses into *Error, retries failed calls
+// and pages through lists.
+//
+//	client, err := spycatclient.New("https://spycat.example.com", spycatclient.WithAPIKey(key))
+//	for cat, err := range client.AllCats(ctx, spycatclient.CatFilter{Breed: "Siamese"}) {
+//		...
+//	}
+//
+// Run go generate after changing the API to check the client against the spec.
+package spycatclient
+
+//go:generate go run ./internal/specsync ../../cmd/api/docs/swagger.json .
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	mathrand "math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RetryPolicy controls how failed calls are retried. Calls are retried after network errors and 429 and 5xx
+// responses, with exponentially growing delays with full jitter, or after Retry-After when the API sends it.
+// POST calls are retried too: they carry an Idempotency-Key, so the API runs them at most once.
+type RetryPolicy struct {
+	MaxRetries int
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+}
+
+// DefaultRetryPolicy is used unless WithRetryPolicy is given
+var DefaultRetryPolicy = RetryPolicy{MaxRetries: 3, BaseDelay: 200 * time.Millisecond, MaxDelay: 5 * time.Second}
+
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	retry      RetryPolicy
+	userAgent  string
+	apiKey     string
+
+	mu    sync.RWMutex
+	token string
+}
+
+type Option func(*Client)
+
+// WithHTTPClient sends the calls with the given client instead of http.DefaultClient. Its Timeout also
+// bounds event streams, so leave it unset when using StreamEvents.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithToken authenticates calls with a JWT, e.g. one issued by Login
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithAPIKey authenticates calls with an API key. It takes precedence over a token.
+func WithAPIKey(key string) Option {
+	return func(c *Client) {
+		c.apiKey = key
+	}
+}
+
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// New returns a client of the API at baseURL, e.g. http://localhost:8080
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(strings.TrimRight(baseURL, "/"))
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("spycatclient: invalid base URL %q", baseURL)
+	}
+
+	c := &Client{
+		baseURL:    u,
+		httpClient: http.DefaultClient,
+		retry:      DefaultRetryPolicy,
+		userAgent:  "spycatclient-go",
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// SetToken replaces the JWT sent with later calls
+func (c *Client) SetToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = token
+}
+
+type idempotencyKey struct{}
+
+// WithIdempotencyKey sets the Idempotency-Key of the POST calls made with ctx. By default every POST gets a
+// random key, which makes its own retries safe; pass a key to also make retries of the whole call safe, e.g.
+// after a crash.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKey{}, key)
+}
+
+type withDeleted struct{}
+
+// WithDeleted makes the reads made with ctx also return soft-deleted cats, missions and targets
+func WithDeleted(ctx context.Context) context.Context {
+	return context.WithValue(ctx, withDeleted{}, true)
+}
+
+// request describes a call to the API
+type request struct {
+	method string
+	path   string
+	query  url.Values
+	// body is encoded as JSON unless contentType is set, in which case it must be a []byte
+	body        any
+	contentType string
+	// version is sent as If-Match unless it is 0
+	version int
+	// readsDeleted marks routes that take include_deleted
+	readsDeleted bool
+	header       http.Header
+}
+
+// do sends the call and decodes the JSON response into out, unless out is nil
+func (c *Client) do(ctx context.Context, r request, out any) error {
+	resp, err := c.send(ctx, r)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("spycatclient: failed to decode response of %s %s: %w", r.method, r.path, err)
+	}
+	return nil
+}
+
+// send sends the call, retrying it as the policy allows, and returns the successful response. The caller
+// must close its body. Error responses are returned as *Error.
+func (c *Client) send(ctx context.Context, r request) (*http.Response, error) {
+	var body []byte
+	contentType := r.contentType
+	if r.body != nil {
+		if contentType == "" {
+			encoded, err := json.Marshal(r.body)
+			if err != nil {
+				return nil, fmt.Errorf("spycatclient: failed to encode request body: %w", err)
+			}
+			body, contentType = encoded, "application/json"
+		} else {
+			body = r.body.([]byte)
+		}
+	}
+
+	header := r.header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	if contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+	if r.version != 0 {
+		header.Set("If-Match", strconv.Quote(strconv.Itoa(r.version)))
+	}
+	if r.method == http.MethodPost {
+		header.Set("Idempotency-Key", idempotencyKeyFrom(ctx))
+	}
+
+	query := r.query
+	if deleted, _ := ctx.Value(withDeleted{}).(bool); deleted && r.readsDeleted {
+		query = maps.Clone(query)
+		if query == nil {
+			query = url.Values{}
+		}
+		query.Set("include_deleted", "true")
+	}
+
+	target := c.url(r.path, query)
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, r.method, target, bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("spycatclient: failed to create request: %w", err)
+		}
+		req.Header = header.Clone()
+		c.authorize(req)
+
+		resp, err := c.httpClient.Do(req)
+		if attempt >= c.retry.MaxRetries || !retryable(ctx, resp, err) {
+			if err != nil {
+				return nil, fmt.Errorf("spycatclient: %s %s: %w", r.method, r.path, err)
+			}
+			if resp.StatusCode >= http.StatusBadRequest {
+				defer resp.Body.Close()
+				return nil, decodeError(resp)
+			}
+			return resp, nil
+		}
+
+		delay := c.retry.backoff(attempt, resp)
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+func (c *Client) url(path string, query url.Values) string {
+	u := *c.baseURL
+	u.Path += path
+	if len(query) > 0 {
+		u.RawQuery = query.Encode()
+	}
+	return u.String()
+}
+
+func (c *Client) authorize(req *http.Request) {
+	req.Header.Set("User-Agent", c.userAgent)
+	if c.apiKey != "" {
+		req.Header.Set("X-API-Key", c.apiKey)
+		return
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+}
+
+func retryable(ctx context.Context, resp *http.Response, err error) bool {
+	if err != nil {
+		return ctx.Err() == nil && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError &&
+		resp.StatusCode != http.StatusNotImplemented
+}
+
+// backoff returns the delay before the next attempt
+func (p RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			return min(time.Duration(seconds)*time.Second, p.MaxDelay)
+		}
+	}
+
+	ceiling := min(p.BaseDelay<<attempt, p.MaxDelay)
+	if ceiling <= 0 {
+		return 0
+	}
+	return mathrand.N(ceiling + 1)
+}
+
+func idempotencyKeyFrom(ctx context.Context) string {
+	if key, ok := ctx.Value(idempotencyKey{}).(string); ok && key != "" {
+		return key
+	}
+
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
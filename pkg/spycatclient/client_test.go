@@ -0,0 +1,208 @@
+package spycatclient_test
+
+import (
+	"SpyCatAgency/internal/apperror"
+	"SpyCatAgency/internal/auth"
+	"SpyCatAgency/internal/config"
+	"SpyCatAgency/internal/handler"
+	"SpyCatAgency/internal/model"
+	"SpyCatAgency/internal/repository"
+	"SpyCatAgency/internal/server"
+	"SpyCatAgency/internal/service"
+	"SpyCatAgency/pkg/spycatclient"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Fakes serving mission 10 of cat 1 with a single assignment; other methods are not used by the tests
+
+type stubMissions struct {
+	repository.MissionRepository
+}
+
+func (stubMissions) GetByID(_ context.Context, id uint) (*model.Mission, error) {
+	if id != 10 {
+		return nil, apperror.NotFound("mission %d not found", id)
+	}
+	catID := uint(1)
+	return &model.Mission{ID: 10, Name: "Op", CatID: &catID, Status: model.MissionStatusAssigned, Version: 2}, nil
+}
+
+type stubCats struct {
+	repository.CatRepository
+}
+
+func (stubCats) GetByID(_ context.Context, id uint) (*model.Cat, error) {
+	if id != 1 {
+		return nil, apperror.NotFound("cat %d not found", id)
+	}
+	return &model.Cat{ID: 1, Name: "Tom"}, nil
+}
+
+type stubAssignments struct {
+	repository.AssignmentRepository
+}
+
+func (stubAssignments) ListByMissionID(_ context.Context, missionID uint) ([]model.Assignment, error) {
+	return []model.Assignment{{ID: 5, MissionID: missionID, CatID: 1, AssignedAt: time.Now()}}, nil
+}
+
+func (stubAssignments) ListByCatID(_ context.Context, catID uint) ([]model.Assignment, error) {
+	return []model.Assignment{{ID: 5, MissionID: 10, CatID: catID, AssignedAt: time.Now()}}, nil
+}
+
+// newTestClient returns a client of the API router served as in production, without authentication: every
+// request acts as an admin. Only the mission service is backed by fakes; the handlers of other services fail
+// with 500 once they reach their service, which is enough to tell that their routes exist.
+func newTestClient(t *testing.T) *spycatclient.Client {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	gin.DefaultErrorWriter = io.Discard
+
+	missions := service.NewMissionService(nil, stubMissions{}, nil, nil, stubAssignments{}, stubCats{}, nil, nil)
+	handlers := &handler.Handlers{
+		Auth:    handler.NewAuthHandler(nil),
+		Cat:     handler.NewCatHandler(nil),
+		Mission: handler.NewMissionHandler(missions),
+		Audit:   handler.NewAuditHandler(nil),
+		User:    handler.NewUserHandler(nil),
+		APIKey:  handler.NewAPIKeyHandler(nil),
+		Payroll: handler.NewPayrollHandler(nil),
+		Webhook: handler.NewWebhookHandler(nil),
+		Event:   handler.NewEventHandler(nil, time.Second),
+		GraphQL: handler.NewGraphQLHandler(nil),
+	}
+
+	srv := server.NewServer(&config.Config{})
+	admin := &auth.Principal{Subject: "1", Name: "admin", Role: auth.RoleAdmin}
+	api := srv.Router.Group("/", func(ctx *gin.Context) {
+		ctx.Request = ctx.Request.WithContext(auth.WithPrincipal(ctx.Request.Context(), admin))
+	})
+	handlers.RegisterRoutes(srv.Router, api)
+
+	ts := httptest.NewServer(srv.Router)
+	t.Cleanup(ts.Close)
+
+	client, err := spycatclient.New(ts.URL, spycatclient.WithRetryPolicy(spycatclient.RetryPolicy{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestAssignments(t *testing.T) {
+	client := newTestClient(t)
+	ctx := context.Background()
+
+	missionHistory, err := client.MissionAssignments(ctx, 10)
+	if err != nil {
+		t.Fatalf("MissionAssignments: %v", err)
+	}
+	if len(missionHistory) != 1 || missionHistory[0].MissionID != 10 || missionHistory[0].CatID != 1 {
+		t.Fatalf("unexpected mission assignments %+v", missionHistory)
+	}
+
+	catHistory, err := client.CatAssignments(ctx, 1)
+	if err != nil {
+		t.Fatalf("CatAssignments: %v", err)
+	}
+	if len(catHistory) != 1 || catHistory[0].MissionID != 10 || catHistory[0].CatID != 1 {
+		t.Fatalf("unexpected cat assignments %+v", catHistory)
+	}
+
+	_, err = client.MissionAssignments(ctx, 11)
+	var apiErr *spycatclient.Error
+	if !errors.Is(err, spycatclient.ErrNotFound) || !errors.As(err, &apiErr) || apiErr.Message != "mission 11 not found" {
+		t.Fatalf("got %v, want the not found error of the API", err)
+	}
+}
+
+// TestRoutesServed calls every method of the client and fails if the router does not serve its route
+func TestRoutesServed(t *testing.T) {
+	client := newTestClient(t)
+	ctx := context.Background()
+
+	calls := map[string]func() error{
+		"Login":              func() error { _, err := client.Login(ctx, spycatclient.Credentials{}); return err },
+		"CreateUser":         func() error { _, err := client.CreateUser(ctx, spycatclient.UserCreate{}); return err },
+		"ListAPIKeys":        func() error { _, err := client.ListAPIKeys(ctx); return err },
+		"CreateAPIKey":       func() error { _, err := client.CreateAPIKey(ctx, spycatclient.APIKeyCreate{}); return err },
+		"RotateAPIKey":       func() error { _, err := client.RotateAPIKey(ctx, 1); return err },
+		"RevokeAPIKey":       func() error { return client.RevokeAPIKey(ctx, 1) },
+		"CreateCat":          func() error { _, err := client.CreateCat(ctx, spycatclient.CatCreate{}); return err },
+		"GetCat":             func() error { _, err := client.GetCat(ctx, 1); return err },
+		"ListCats":           func() error { _, err := client.ListCats(ctx, spycatclient.CatFilter{}); return err },
+		"UpdateCatSalary":    func() error { _, err := client.UpdateCatSalary(ctx, 1, 1, spycatclient.CatUpdate{}); return err },
+		"DeleteCat":          func() error { return client.DeleteCat(ctx, 1, 1) },
+		"RestoreCat":         func() error { _, err := client.RestoreCat(ctx, 1); return err },
+		"CatSalaryHistory":   func() error { _, err := client.CatSalaryHistory(ctx, 1); return err },
+		"CatAssignments":     func() error { _, err := client.CatAssignments(ctx, 1); return err },
+		"ImportCats":         func() error { _, err := client.ImportCats(ctx, nil, true); return err },
+		"ImportCatsCSV":      func() error { _, err := client.ImportCatsCSV(ctx, []byte("name\n"), true); return err },
+		"ExportCats":         func() error { _, err := client.ExportCats(ctx, spycatclient.CatFilter{}); return err },
+		"ExportCatsCSV":      func() error { _, err := client.ExportCatsCSV(ctx, spycatclient.CatFilter{}); return err },
+		"ListBreeds":         func() error { _, err := client.ListBreeds(ctx); return err },
+		"CreateMission":      func() error { _, err := client.CreateMission(ctx, spycatclient.MissionCreate{}); return err },
+		"GetMission":         func() error { _, err := client.GetMission(ctx, 10, &spycatclient.MissionInclude{}); return err },
+		"ListMissions":       func() error { _, err := client.ListMissions(ctx, spycatclient.MissionFilter{}); return err },
+		"UpdateMission":      func() error { _, err := client.UpdateMission(ctx, 10, 2, spycatclient.MissionUpdate{}); return err },
+		"DeleteMission":      func() error { return client.DeleteMission(ctx, 10, 2) },
+		"RestoreMission":     func() error { _, err := client.RestoreMission(ctx, 10); return err },
+		"AssignCat":          func() error { return client.AssignCat(ctx, 10, spycatclient.CatAssign{}) },
+		"MissionAssignments": func() error { _, err := client.MissionAssignments(ctx, 10); return err },
+		"AddTarget":          func() error { _, err := client.AddTarget(ctx, 10, spycatclient.TargetCreate{}); return err },
+		"UpdateTarget":       func() error { _, err := client.UpdateTarget(ctx, 1, 1, spycatclient.TargetUpdate{}); return err },
+		"DeleteTarget":       func() error { return client.DeleteTarget(ctx, 1, 1) },
+		"RestoreTarget":      func() error { _, err := client.RestoreTarget(ctx, 1); return err },
+		"TargetNotes":        func() error { _, err := client.TargetNotes(ctx, 1); return err },
+		"DiffTargetNotes": func() error {
+			_, err := client.DiffTargetNotes(ctx, 1, spycatclient.TargetNotesDiffQuery{})
+			return err
+		},
+		"ImportMissions":    func() error { _, err := client.ImportMissions(ctx, nil, true); return err },
+		"ImportMissionsCSV": func() error { _, err := client.ImportMissionsCSV(ctx, []byte("name\n"), true); return err },
+		"ExportMissions":    func() error { _, err := client.ExportMissions(ctx, spycatclient.MissionFilter{}); return err },
+		"ExportMissionsCSV": func() error { _, err := client.ExportMissionsCSV(ctx, spycatclient.MissionFilter{}); return err },
+		"ListAuditEntries":  func() error { _, err := client.ListAuditEntries(ctx, spycatclient.AuditFilter{}); return err },
+		"ListPayrollRuns":   func() error { _, err := client.ListPayrollRuns(ctx); return err },
+		"CreatePayrollRun":  func() error { _, err := client.CreatePayrollRun(ctx, spycatclient.PayrollRunCreate{}); return err },
+		"GetPayrollRun":     func() error { _, err := client.GetPayrollRun(ctx, 1); return err },
+		"ExportPayrollRun":  func() error { _, err := client.ExportPayrollRun(ctx, 1); return err },
+		"CreateWebhook":     func() error { _, err := client.CreateWebhook(ctx, spycatclient.WebhookCreate{}); return err },
+		"ListWebhooks":      func() error { _, err := client.ListWebhooks(ctx); return err },
+		"DeleteWebhook":     func() error { return client.DeleteWebhook(ctx, 1) },
+		"ListWebhookDeliveries": func() error {
+			_, err := client.ListWebhookDeliveries(ctx, spycatclient.WebhookDeliveryFilter{})
+			return err
+		},
+		"RetryWebhookDelivery": func() error { return client.RetryWebhookDelivery(ctx, 1) },
+		"GraphQL": func() error {
+			_, err := client.GraphQL(ctx, spycatclient.GraphQLRequest{Query: "{ __typename }"})
+			return err
+		},
+		"StreamEvents": func() error {
+			stream, err := client.StreamEvents(ctx, spycatclient.EventStreamFilter{})
+			if err == nil {
+				stream.Close()
+			}
+			return err
+		},
+	}
+
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			// The router answers unknown routes with a plain-text 404; the API's own errors are JSON
+			var apiErr *spycatclient.Error
+			if err := call(); errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound && apiErr.Message == http.StatusText(http.StatusNotFound) {
+				t.Fatalf("route of %s is not served: %v", name, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package spycatclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Error kinds, matched with errors.Is against the *Error of a failed call
+var (
+	ErrBadRequest         = &kindError{"bad request", http.StatusBadRequest}
+	ErrUnauthorized       = &kindError{"unauthorized", http.StatusUnauthorized}
+	ErrForbidden          = &kindError{"forbidden", http.StatusForbidden}
+	ErrNotFound           = &kindError{"not found", http.StatusNotFound}
+	ErrConflict           = &kindError{"conflict", http.StatusConflict}
+	ErrPreconditionFailed = &kindError{"precondition failed", http.StatusPreconditionFailed}
+	ErrValidation         = &kindError{"validation failed", http.StatusUnprocessableEntity}
+	ErrRateLimited        = &kindError{"rate limited", http.StatusTooManyRequests}
+	ErrUpstream           = &kindError{"upstream failure", http.StatusBadGateway}
+)
+
+type kindError struct {
+	name   string
+	status int
+}
+
+func (e *kindError) Error() string {
+	return e.name
+}
+
+// Error is an error response of the API
+type Error struct {
+	StatusCode int
+	// Message is the error field of the response
+	Message string
+	// Details are the other fields of the response, e.g. errors for validation failures or mission_id
+	Details map[string]any
+	// RequestID identifies the call in the logs and the audit log of the API
+	RequestID string
+	// RetryAfter is the wait asked for by a 429 response
+	RetryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("spycat API: %d %s", e.StatusCode, e.Message)
+}
+
+// Is reports whether the error is of the kind of target, e.g. errors.Is(err, spycatclient.ErrNotFound)
+func (e *Error) Is(target error) bool {
+	kind, ok := target.(*kindError)
+	return ok && kind.status == e.StatusCode
+}
+
+// decodeError reads an error response. Bodies that are not the usual JSON object keep the status text as message.
+func decodeError(resp *http.Response) error {
+	apiErr := &Error{
+		StatusCode: resp.StatusCode,
+		Message:    http.StatusText(resp.StatusCode),
+		RequestID:  resp.Header.Get("X-Request-ID"),
+	}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		apiErr.RetryAfter = time.Duration(seconds) * time.Second
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return apiErr
+	}
+	var fields map[string]any
+	if json.Unmarshal(body, &fields) != nil {
+		return apiErr
+	}
+	if message, ok := fields["error"].(string); ok {
+		apiErr.Message = message
+		delete(fields, "error")
+	}
+	if len(fields) > 0 {
+		apiErr.Details = fields
+	}
+	return apiErr
+}
@@ -0,0 +1,95 @@
+package spycatclient
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// EventStream is a live stream of mission and target events, read with Next
+type EventStream struct {
+	body   io.ReadCloser
+	reader *bufio.Reader
+	lastID uint64
+}
+
+// StreamEvents opens a live stream of events. The stream ends with io.EOF when the server closes it, e.g.
+// because the client fell behind; reconnect with LastEventID set to the stream's LastEventID to resume
+// without missing events.
+func (c *Client) StreamEvents(ctx context.Context, filter EventStreamFilter) (*EventStream, error) {
+	r := request{
+		method: http.MethodGet,
+		path:   "/api/events",
+		query:  queryOf(filter),
+		header: http.Header{"Accept": {"text/event-stream"}},
+	}
+	resp, err := c.send(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	stream := &EventStream{body: resp.Body, reader: bufio.NewReader(resp.Body)}
+	if filter.LastEventID != nil {
+		stream.lastID = *filter.LastEventID
+	}
+	return stream, nil
+}
+
+// Next blocks until the next event arrives. It returns io.EOF once the stream has ended.
+func (s *EventStream) Next() (*Event, error) {
+	var id, data string
+	for {
+		line, err := s.reader.ReadString('\n')
+		if err != nil {
+			if err == io.EOF && line == "" {
+				return nil, io.EOF
+			}
+			if err != io.EOF {
+				return nil, fmt.Errorf("spycatclient: failed to read event stream: %w", err)
+			}
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		if line == "" {
+			if data == "" {
+				continue
+			}
+
+			var event Event
+			if err := json.Unmarshal([]byte(data), &event); err != nil {
+				return nil, fmt.Errorf("spycatclient: failed to decode event %s: %w", id, err)
+			}
+			if n, err := strconv.ParseUint(id, 10, 64); err == nil {
+				s.lastID = n
+			}
+			return &event, nil
+		}
+
+		// comments, such as heartbeats, start with a colon
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "id":
+			id = value
+		case "data":
+			if data != "" {
+				data += "\n"
+			}
+			data += value
+		}
+	}
+}
+
+// LastEventID is the id of the last event returned by Next, or the one the stream resumed after
+func (s *EventStream) LastEventID() uint64 {
+	return s.lastID
+}
+
+func (s *EventStream) Close() error {
+	return s.body.Close()
+}
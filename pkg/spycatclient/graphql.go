@@ -0,0 +1,41 @@
+package spycatclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// GraphQL runs a GraphQL request. Errors of the request itself are in the Errors of the response; the
+// returned error is only set when the call failed.
+func (c *Client) GraphQL(ctx context.Context, req GraphQLRequest) (*GraphQLResponse, error) {
+	var resp GraphQLResponse
+	if err := c.do(ctx, request{method: http.MethodPost, path: "/api/graphql", body: req}, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Decode decodes the data of the response into out
+func (r *GraphQLResponse) Decode(out any) error {
+	if len(r.Data) == 0 {
+		return fmt.Errorf("spycatclient: GraphQL response has no data")
+	}
+	return json.Unmarshal(r.Data, out)
+}
+
+// Err returns the first error of the response, or nil
+func (r *GraphQLResponse) Err() error {
+	if len(r.Errors) == 0 {
+		return nil
+	}
+	return &r.Errors[0]
+}
+
+func (e *GraphQLError) Error() string {
+	if code, ok := e.Extensions["code"].(string); ok {
+		return fmt.Sprintf("spycat GraphQL: %s: %s", code, e.Message)
+	}
+	return "spycat GraphQL: " + e.Message
+}
@@ -0,0 +1,273 @@
+// Command specsync checks that the client covers every route of the Swagger spec and that its types have the
+// fields of the spec's definitions. It prints every difference and exits with status 1 when there are any.
+//
+// Usage: specsync <swagger.json> <client package dir>
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type spec struct {
+	Paths       map[string]map[string]json.RawMessage `json:"paths"`
+	Definitions map[string]definition                 `json:"definitions"`
+}
+
+type definition struct {
+	Properties map[string]json.RawMessage `json:"properties"`
+	Enum       []string                   `json:"enum"`
+}
+
+// typeNames maps definitions whose Go type has a different name in the client
+var typeNames = map[string]string{
+	"graph.Request": "GraphQLRequest",
+}
+
+var (
+	pathParam = regexp.MustCompile(`\{[^}]+\}`)
+	verb      = regexp.MustCompile(`%[a-z]`)
+)
+
+func main() {
+	if len(os.Args) != 3 {
+		log.Fatal("usage: specsync <swagger.json> <client package dir>")
+	}
+
+	data, err := os.ReadFile(os.Args[1])
+	if err != nil {
+		log.Fatalf("failed to read spec: %v", err)
+	}
+	var s spec
+	if err := json.Unmarshal(data, &s); err != nil {
+		log.Fatalf("failed to parse spec: %v", err)
+	}
+
+	pkg, err := loadPackage(os.Args[2])
+	if err != nil {
+		log.Fatalf("failed to load client: %v", err)
+	}
+
+	problems := append(checkRoutes(s, pkg), checkDefinitions(s, pkg)...)
+	for _, problem := range problems {
+		fmt.Println(problem)
+	}
+	if len(problems) > 0 {
+		os.Exit(1)
+	}
+}
+
+// clientPackage is what the check needs from the client: the routes it calls, its structs and its enums
+type clientPackage struct {
+	routes  map[string]token.Position
+	structs map[string]*ast.StructType
+	enums   map[string][]string
+	errs    []string
+}
+
+func loadPackage(dir string) (*clientPackage, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg := &clientPackage{
+		routes:  map[string]token.Position{},
+		structs: map[string]*ast.StructType{},
+		enums:   map[string][]string{},
+	}
+	for _, p := range pkgs {
+		for _, file := range p.Files {
+			pkg.collect(fset, file)
+		}
+	}
+	return pkg, nil
+}
+
+func (p *clientPackage) collect(fset *token.FileSet, file *ast.File) {
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.TypeSpec:
+			if st, ok := n.Type.(*ast.StructType); ok {
+				p.structs[n.Name.Name] = st
+			}
+		case *ast.ValueSpec:
+			typ, ok := n.Type.(*ast.Ident)
+			if !ok {
+				break
+			}
+			for _, value := range n.Values {
+				if lit, ok := value.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+					v, _ := strconv.Unquote(lit.Value)
+					p.enums[typ.Name] = append(p.enums[typ.Name], v)
+				}
+			}
+		case *ast.CompositeLit:
+			if typ, ok := n.Type.(*ast.Ident); !ok || typ.Name != "request" {
+				break
+			}
+			route, ok := routeOf(n)
+			if !ok {
+				p.errs = append(p.errs, fmt.Sprintf("%s: request without a constant method and path", fset.Position(n.Pos())))
+				break
+			}
+			p.routes[route] = fset.Position(n.Pos())
+		}
+		return true
+	})
+}
+
+// routeOf returns the route of a request literal as "METHOD /path/{}", with every path parameter as {}
+func routeOf(lit *ast.CompositeLit) (string, bool) {
+	var method, path string
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key, _ := kv.Key.(*ast.Ident)
+		if key == nil {
+			continue
+		}
+		switch key.Name {
+		case "method":
+			if sel, ok := kv.Value.(*ast.SelectorExpr); ok && strings.HasPrefix(sel.Sel.Name, "Method") {
+				method = strings.ToUpper(strings.TrimPrefix(sel.Sel.Name, "Method"))
+			}
+		case "path":
+			path = pathOf(kv.Value)
+		}
+	}
+	if method == "" || path == "" {
+		return "", false
+	}
+	return method + " " + verb.ReplaceAllString(path, "{}"), true
+}
+
+// pathOf returns a string literal, or the format of a fmt.Sprintf call
+func pathOf(expr ast.Expr) string {
+	if call, ok := expr.(*ast.CallExpr); ok && len(call.Args) > 0 {
+		if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Sprintf" {
+			expr = call.Args[0]
+		}
+	}
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return ""
+	}
+	path, _ := strconv.Unquote(lit.Value)
+	return path
+}
+
+func checkRoutes(s spec, pkg *clientPackage) []string {
+	problems := slices.Clone(pkg.errs)
+
+	routes := map[string]bool{}
+	for path, operations := range s.Paths {
+		for method := range operations {
+			routes[strings.ToUpper(method)+" "+pathParam.ReplaceAllString(path, "{}")] = true
+		}
+	}
+
+	for route := range routes {
+		if _, ok := pkg.routes[route]; !ok {
+			problems = append(problems, fmt.Sprintf("route %s has no client method", route))
+		}
+	}
+	for route, pos := range pkg.routes {
+		if !routes[route] {
+			problems = append(problems, fmt.Sprintf("%s: route %s is not in the spec", pos, route))
+		}
+	}
+	slices.Sort(problems)
+	return problems
+}
+
+func checkDefinitions(s spec, pkg *clientPackage) []string {
+	var problems []string
+	for name, def := range s.Definitions {
+		typeName, ok := typeNames[name]
+		if !ok {
+			_, typeName, _ = strings.Cut(name, ".")
+		}
+
+		if len(def.Enum) > 0 {
+			values := pkg.enums[typeName]
+			for _, value := range def.Enum {
+				if !slices.Contains(values, value) {
+					problems = append(problems, fmt.Sprintf("%s: value %q of %s has no constant", typeName, value, name))
+				}
+			}
+			continue
+		}
+
+		st, ok := pkg.structs[typeName]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("definition %s has no type %s", name, typeName))
+			continue
+		}
+		fields := pkg.jsonFields(st)
+		for property := range def.Properties {
+			if !fields[property] {
+				problems = append(problems, fmt.Sprintf("%s: field %q of %s is missing", typeName, property, name))
+			}
+		}
+		for field := range fields {
+			if _, ok := def.Properties[field]; !ok {
+				problems = append(problems, fmt.Sprintf("%s: field %q is not in %s", typeName, field, name))
+			}
+		}
+	}
+	slices.Sort(problems)
+	return problems
+}
+
+// jsonFields returns the JSON names of the fields of st, including those of embedded structs
+func (p *clientPackage) jsonFields(st *ast.StructType) map[string]bool {
+	fields := map[string]bool{}
+	for _, field := range st.Fields.List {
+		var tag string
+		if field.Tag != nil {
+			unquoted, _ := strconv.Unquote(field.Tag.Value)
+			tag, _, _ = strings.Cut(reflect.StructTag(unquoted).Get("json"), ",")
+		}
+		if tag == "-" {
+			continue
+		}
+
+		if len(field.Names) == 0 {
+			if ident, ok := field.Type.(*ast.Ident); ok && tag == "" {
+				if embedded, ok := p.structs[ident.Name]; ok {
+					for name := range p.jsonFields(embedded) {
+						fields[name] = true
+					}
+				}
+				continue
+			}
+		}
+		for _, name := range field.Names {
+			if !name.IsExported() {
+				continue
+			}
+			if tag != "" {
+				fields[tag] = true
+			} else {
+				fields[name.Name] = true
+			}
+		}
+	}
+	return fields
+}
@@ -0,0 +1,173 @@
+package spycatclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// CreateMission creates a mission with 1 to 3 targets, optionally assigned to a cat
+func (c *Client) CreateMission(ctx context.Context, create MissionCreate) (*Mission, error) {
+	var mission Mission
+	if err := c.do(ctx, request{method: http.MethodPost, path: "/api/missions", body: create}, &mission); err != nil {
+		return nil, err
+	}
+	return &mission, nil
+}
+
+// GetMission returns a mission with the relations selected by include, or the default ones if it is nil
+func (c *Client) GetMission(ctx context.Context, id uint, include *MissionInclude) (*Mission, error) {
+	var mission Mission
+	r := request{
+		method:       http.MethodGet,
+		path:         fmt.Sprintf("/api/missions/%d", id),
+		query:        includeQuery(url.Values{}, include),
+		readsDeleted: true,
+	}
+	if err := c.do(ctx, r, &mission); err != nil {
+		return nil, err
+	}
+	return &mission, nil
+}
+
+// ListMissions returns one page of missions; see AllMissions to iterate over every page
+func (c *Client) ListMissions(ctx context.Context, filter MissionFilter) (*MissionPage, error) {
+	var page MissionPage
+	r := request{
+		method:       http.MethodGet,
+		path:         "/api/missions",
+		query:        includeQuery(queryOf(filter), filter.Include),
+		readsDeleted: true,
+	}
+	if err := c.do(ctx, r, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// UpdateMission changes the status of a mission. A non-zero version must be the current version of the mission.
+func (c *Client) UpdateMission(ctx context.Context, id uint, version int, update MissionUpdate) (*Mission, error) {
+	var mission Mission
+	r := request{method: http.MethodPut, path: fmt.Sprintf("/api/missions/%d", id), body: update, version: version}
+	if err := c.do(ctx, r, &mission); err != nil {
+		return nil, err
+	}
+	return &mission, nil
+}
+
+// DeleteMission soft-deletes a mission with its targets. A non-zero version must be the current version.
+func (c *Client) DeleteMission(ctx context.Context, id uint, version int) error {
+	r := request{method: http.MethodDelete, path: fmt.Sprintf("/api/missions/%d", id), version: version}
+	return c.do(ctx, r, nil)
+}
+
+func (c *Client) RestoreMission(ctx context.Context, id uint) (*Mission, error) {
+	var mission Mission
+	if err := c.do(ctx, request{method: http.MethodPost, path: fmt.Sprintf("/api/missions/%d/restore", id)}, &mission); err != nil {
+		return nil, err
+	}
+	return &mission, nil
+}
+
+// AssignCat assigns a cat to a mission, releasing the cat assigned before
+func (c *Client) AssignCat(ctx context.Context, missionID uint, assign CatAssign) error {
+	r := request{method: http.MethodPost, path: fmt.Sprintf("/api/missions/%d/assign", missionID), body: assign}
+	return c.do(ctx, r, nil)
+}
+
+// MissionAssignments returns the cats that worked on a mission, earliest first
+func (c *Client) MissionAssignments(ctx context.Context, missionID uint) ([]Assignment, error) {
+	var assignments []Assignment
+	r := request{method: http.MethodGet, path: fmt.Sprintf("/api/missions/%d/assignments", missionID), readsDeleted: true}
+	if err := c.do(ctx, r, &assignments); err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
+
+func (c *Client) AddTarget(ctx context.Context, missionID uint, create TargetCreate) (*Target, error) {
+	var target Target
+	r := request{method: http.MethodPost, path: fmt.Sprintf("/api/missions/%d/targets", missionID), body: create}
+	if err := c.do(ctx, r, &target); err != nil {
+		return nil, err
+	}
+	return &target, nil
+}
+
+// UpdateTarget changes the notes of a target or completes it. A non-zero version must be the current version.
+func (c *Client) UpdateTarget(ctx context.Context, id uint, version int, update TargetUpdate) (*Target, error) {
+	var target Target
+	r := request{method: http.MethodPut, path: fmt.Sprintf("/api/missions/targets/%d", id), body: update, version: version}
+	if err := c.do(ctx, r, &target); err != nil {
+		return nil, err
+	}
+	return &target, nil
+}
+
+// DeleteTarget soft-deletes a target. A non-zero version must be the current version of the target.
+func (c *Client) DeleteTarget(ctx context.Context, id uint, version int) error {
+	r := request{method: http.MethodDelete, path: fmt.Sprintf("/api/missions/targets/%d", id), version: version}
+	return c.do(ctx, r, nil)
+}
+
+func (c *Client) RestoreTarget(ctx context.Context, id uint) (*Target, error) {
+	var target Target
+	r := request{method: http.MethodPost, path: fmt.Sprintf("/api/missions/targets/%d/restore", id)}
+	if err := c.do(ctx, r, &target); err != nil {
+		return nil, err
+	}
+	return &target, nil
+}
+
+// TargetNotes returns every revision of the notes of a target, oldest first
+func (c *Client) TargetNotes(ctx context.Context, id uint) ([]TargetNote, error) {
+	var notes []TargetNote
+	r := request{method: http.MethodGet, path: fmt.Sprintf("/api/missions/targets/%d/notes", id), readsDeleted: true}
+	if err := c.do(ctx, r, &notes); err != nil {
+		return nil, err
+	}
+	return notes, nil
+}
+
+// DiffTargetNotes compares two revisions of the notes of a target line by line
+func (c *Client) DiffTargetNotes(ctx context.Context, id uint, query TargetNotesDiffQuery) (*TargetNotesDiff, error) {
+	var diff TargetNotesDiff
+	r := request{
+		method:       http.MethodGet,
+		path:         fmt.Sprintf("/api/missions/targets/%d/notes/diff", id),
+		query:        queryOf(query),
+		readsDeleted: true,
+	}
+	if err := c.do(ctx, r, &diff); err != nil {
+		return nil, err
+	}
+	return &diff, nil
+}
+
+// ImportMissions creates up to 1000 missions. With atomic, any invalid mission rejects the whole import.
+func (c *Client) ImportMissions(ctx context.Context, missions []MissionCreate, atomic bool) (*ImportReport, error) {
+	return c.importItems(ctx, request{method: http.MethodPost, path: "/api/missions/import", body: missions}, atomic)
+}
+
+// ImportMissionsCSV creates missions from a CSV file with the columns mission_ref, name, cat_id, target_name,
+// target_country and target_notes, one line per target
+func (c *Client) ImportMissionsCSV(ctx context.Context, csv []byte, atomic bool) (*ImportReport, error) {
+	return c.importItems(ctx, request{method: http.MethodPost, path: "/api/missions/import", body: csv, contentType: csvContentType}, atomic)
+}
+
+// ExportMissions returns every mission matching the filter with its targets; its pagination fields are ignored
+func (c *Client) ExportMissions(ctx context.Context, filter MissionFilter) ([]Mission, error) {
+	var missions []Mission
+	r := request{method: http.MethodGet, path: "/api/missions/export", query: exportQuery(filter, "json"), readsDeleted: true}
+	if err := c.do(ctx, r, &missions); err != nil {
+		return nil, err
+	}
+	return missions, nil
+}
+
+// ExportMissionsCSV returns every mission matching the filter in the CSV format accepted by ImportMissionsCSV
+func (c *Client) ExportMissionsCSV(ctx context.Context, filter MissionFilter) ([]byte, error) {
+	r := request{method: http.MethodGet, path: "/api/missions/export", query: exportQuery(filter, "csv"), readsDeleted: true}
+	return c.download(ctx, r)
+}
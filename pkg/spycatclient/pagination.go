@@ -0,0 +1,84 @@
+package spycatclient
+
+import (
+	"cmp"
+	"context"
+	"iter"
+)
+
+// paginate yields the items of every page, fetching the next page once the previous one was consumed.
+// It stops at the first error, which it yields with a zero item.
+func paginate[T any](ctx context.Context, fetch func(ctx context.Context, cursor string) ([]T, PageInfo, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		cursor := ""
+		for {
+			items, page, err := fetch(ctx, cursor)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if page.NextCursor == "" {
+				return
+			}
+			cursor = page.NextCursor
+		}
+	}
+}
+
+// AllCats iterates over every cat matching the filter, page by page, starting at its cursor
+func (c *Client) AllCats(ctx context.Context, filter CatFilter) iter.Seq2[Cat, error] {
+	first := filter.Cursor
+	return paginate(ctx, func(ctx context.Context, cursor string) ([]Cat, PageInfo, error) {
+		filter.Cursor = cmp.Or(cursor, first)
+		page, err := c.ListCats(ctx, filter)
+		if err != nil {
+			return nil, PageInfo{}, err
+		}
+		return page.Items, page.PageInfo, nil
+	})
+}
+
+// AllMissions iterates over every mission matching the filter, page by page, starting at its cursor
+func (c *Client) AllMissions(ctx context.Context, filter MissionFilter) iter.Seq2[Mission, error] {
+	first := filter.Cursor
+	return paginate(ctx, func(ctx context.Context, cursor string) ([]Mission, PageInfo, error) {
+		filter.Cursor = cmp.Or(cursor, first)
+		page, err := c.ListMissions(ctx, filter)
+		if err != nil {
+			return nil, PageInfo{}, err
+		}
+		return page.Items, page.PageInfo, nil
+	})
+}
+
+// AllAuditEntries iterates over every audit entry matching the filter, page by page, starting at its cursor
+func (c *Client) AllAuditEntries(ctx context.Context, filter AuditFilter) iter.Seq2[AuditEntry, error] {
+	first := filter.Cursor
+	return paginate(ctx, func(ctx context.Context, cursor string) ([]AuditEntry, PageInfo, error) {
+		filter.Cursor = cmp.Or(cursor, first)
+		page, err := c.ListAuditEntries(ctx, filter)
+		if err != nil {
+			return nil, PageInfo{}, err
+		}
+		return page.Items, page.PageInfo, nil
+	})
+}
+
+// AllWebhookDeliveries iterates over every delivery matching the filter, page by page, starting at its cursor
+func (c *Client) AllWebhookDeliveries(ctx context.Context, filter WebhookDeliveryFilter) iter.Seq2[WebhookDelivery, error] {
+	first := filter.Cursor
+	return paginate(ctx, func(ctx context.Context, cursor string) ([]WebhookDelivery, PageInfo, error) {
+		filter.Cursor = cmp.Or(cursor, first)
+		page, err := c.ListWebhookDeliveries(ctx, filter)
+		if err != nil {
+			return nil, PageInfo{}, err
+		}
+		return page.Items, page.PageInfo, nil
+	})
+}
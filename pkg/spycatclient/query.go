@@ -0,0 +1,64 @@
+package spycatclient
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// queryOf encodes the fields of a filter tagged with query into query parameters. Zero values and nil
+// pointers are left out; embedded structs are flattened.
+func queryOf(filter any) url.Values {
+	query := url.Values{}
+	addQuery(query, reflect.ValueOf(filter))
+	return query
+}
+
+func addQuery(query url.Values, v reflect.Value) {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+
+	for i := 0; i < v.NumField(); i++ {
+		field, value := v.Type().Field(i), v.Field(i)
+		if field.Anonymous {
+			addQuery(query, value)
+			continue
+		}
+
+		name := field.Tag.Get("query")
+		if name == "" || name == "-" || value.IsZero() {
+			continue
+		}
+		if value.Kind() == reflect.Pointer {
+			value = value.Elem()
+		}
+		if t, ok := value.Interface().(time.Time); ok {
+			query.Set(name, t.Format(time.RFC3339Nano))
+			continue
+		}
+		query.Set(name, fmt.Sprint(value.Interface()))
+	}
+}
+
+// includeQuery adds the include parameter of mission reads, unless include is nil
+func includeQuery(query url.Values, include *MissionInclude) url.Values {
+	if include == nil {
+		return query
+	}
+
+	var names []string
+	if include.Cat {
+		names = append(names, "cat")
+	}
+	if include.Targets {
+		names = append(names, "targets")
+	}
+	query.Set("include", strings.Join(names, ","))
+	return query
+}
@@ -0,0 +1,453 @@
+package spycatclient
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// The types below mirror the definitions of the Swagger spec; go generate checks that they match.
+
+type MissionStatus string
+
+const (
+	MissionStatusDraft      MissionStatus = "draft"
+	MissionStatusAssigned   MissionStatus = "assigned"
+	MissionStatusInProgress MissionStatus = "in_progress"
+	MissionStatusCompleted  MissionStatus = "completed"
+	MissionStatusAborted    MissionStatus = "aborted"
+)
+
+type AuditAction string
+
+const (
+	AuditActionCreate  AuditAction = "create"
+	AuditActionUpdate  AuditAction = "update"
+	AuditActionDelete  AuditAction = "delete"
+	AuditActionRestore AuditAction = "restore"
+	AuditActionAssign  AuditAction = "assign"
+)
+
+type DiffOp string
+
+const (
+	DiffOpEqual  DiffOp = "equal"
+	DiffOpDelete DiffOp = "delete"
+	DiffOpInsert DiffOp = "insert"
+)
+
+type EventType string
+
+const (
+	EventMissionCreated   EventType = "mission.created"
+	EventMissionAssigned  EventType = "mission.assigned"
+	EventMissionStarted   EventType = "mission.started"
+	EventMissionCompleted EventType = "mission.completed"
+	EventMissionAborted   EventType = "mission.aborted"
+	EventMissionDeleted   EventType = "mission.deleted"
+	EventTargetCreated    EventType = "target.created"
+	EventTargetUpdated    EventType = "target.updated"
+	EventTargetDeleted    EventType = "target.deleted"
+	EventCatCreated       EventType = "cat.created"
+	EventCatSalaryChanged EventType = "cat.salary_changed"
+	EventCatDeleted       EventType = "cat.deleted"
+)
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	DeliveryDead      DeliveryStatus = "dead"
+)
+
+// ListParams are the pagination and sorting parameters of list calls. Sort names a column, prefixed with
+// "-" for descending order.
+type ListParams struct {
+	Limit  int    `query:"limit"`
+	Cursor string `query:"cursor"`
+	Sort   string `query:"sort"`
+}
+
+// PageInfo is part of every page of a list. NextCursor is empty on the last page.
+type PageInfo struct {
+	Total      int    `json:"total"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	Next       string `json:"next,omitempty"`
+}
+
+type Credentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type Token struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+type User struct {
+	ID        uint      `json:"id"`
+	Username  string    `json:"username"`
+	Role      string    `json:"role"`
+	CatID     *uint     `json:"cat_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// UserCreate creates an account. Role is admin, handler or cat; cat accounts act as the cat given by CatID.
+type UserCreate struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Role     string `json:"role"`
+	CatID    *uint  `json:"cat_id,omitempty"`
+}
+
+type APIKey struct {
+	ID         uint       `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	CreatedBy  string     `json:"created_by"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+type APIKeyCreate struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// APIKeySecret is returned when a key is created or rotated; the key itself is never shown again
+type APIKeySecret struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+type Cat struct {
+	ID              uint       `json:"id"`
+	Name            string     `json:"name"`
+	YearsExperience int        `json:"years_experience"`
+	Breed           string     `json:"breed"`
+	Salary          float64    `json:"salary"`
+	BreedVerified   bool       `json:"breed_verified"`
+	Version         int        `json:"version"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	DeletedAt       *time.Time `json:"deleted_at,omitempty"`
+}
+
+type CatCreate struct {
+	Name            string  `json:"name"`
+	YearsExperience int     `json:"years_experience"`
+	Breed           string  `json:"breed"`
+	Salary          float64 `json:"salary"`
+}
+
+type CatUpdate struct {
+	Salary float64 `json:"salary"`
+	// EffectiveFrom is the first day of the new salary as YYYY-MM-DD, today by default
+	EffectiveFrom string `json:"effective_from,omitempty"`
+	Reason        string `json:"reason,omitempty"`
+}
+
+type CatFilter struct {
+	ListParams
+	Breed         string   `query:"breed"`
+	MinExperience *int     `query:"min_experience"`
+	MaxExperience *int     `query:"max_experience"`
+	MinSalary     *float64 `query:"min_salary"`
+	MaxSalary     *float64 `query:"max_salary"`
+}
+
+type CatPage struct {
+	Items []Cat `json:"items"`
+	PageInfo
+}
+
+type CatBreed struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type SalaryChange struct {
+	ID            uint      `json:"id"`
+	CatID         uint      `json:"cat_id"`
+	Salary        float64   `json:"salary"`
+	EffectiveFrom string    `json:"effective_from"`
+	ChangedBy     string    `json:"changed_by"`
+	Reason        string    `json:"reason,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type Mission struct {
+	ID        uint          `json:"id"`
+	Name      string        `json:"name"`
+	CatID     *uint         `json:"cat_id"`
+	Cat       *Cat          `json:"cat,omitempty"`
+	Targets   []Target      `json:"targets,omitempty"`
+	Status    MissionStatus `json:"status"`
+	Version   int           `json:"version"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+	DeletedAt *time.Time    `json:"deleted_at,omitempty"`
+}
+
+// MissionInclude selects the relations returned with missions. Without it the API returns the targets, and
+// the cat to callers allowed to see it.
+type MissionInclude struct {
+	Cat     bool
+	Targets bool
+}
+
+type MissionCreate struct {
+	Name    string         `json:"name"`
+	CatID   *uint          `json:"cat_id,omitempty"`
+	Targets []TargetCreate `json:"targets"`
+}
+
+type MissionUpdate struct {
+	Status MissionStatus `json:"status"`
+}
+
+type MissionFilter struct {
+	ListParams
+	Status    MissionStatus `query:"status"`
+	Completed *bool         `query:"completed"`
+	CatID     *uint         `query:"cat_id"`
+	Country   string        `query:"country"`
+	// Include selects the relations returned with the missions
+	Include *MissionInclude `query:"-"`
+}
+
+type MissionPage struct {
+	Items []Mission `json:"items"`
+	PageInfo
+}
+
+type CatAssign struct {
+	CatID uint `json:"cat_id"`
+	// Reason is recorded on the assignment of the cat being replaced
+	Reason string `json:"reason,omitempty"`
+}
+
+type Assignment struct {
+	ID         uint       `json:"id"`
+	MissionID  uint       `json:"mission_id"`
+	CatID      uint       `json:"cat_id"`
+	AssignedAt time.Time  `json:"assigned_at"`
+	ReleasedAt *time.Time `json:"released_at,omitempty"`
+	Reason     string     `json:"reason,omitempty"`
+}
+
+type Target struct {
+	ID        uint       `json:"id"`
+	MissionID uint       `json:"mission_id"`
+	Name      string     `json:"name"`
+	Country   string     `json:"country"`
+	Notes     string     `json:"notes"`
+	Completed bool       `json:"completed"`
+	Version   int        `json:"version"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+type TargetCreate struct {
+	Name    string `json:"name"`
+	Country string `json:"country"`
+	Notes   string `json:"notes,omitempty"`
+}
+
+type TargetUpdate struct {
+	Notes     string `json:"notes"`
+	Completed bool   `json:"completed"`
+}
+
+type TargetNote struct {
+	TargetID  uint      `json:"target_id"`
+	Revision  int       `json:"revision"`
+	Content   string    `json:"content"`
+	Author    string    `json:"author"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type DiffLine struct {
+	Op   DiffOp `json:"op"`
+	Text string `json:"text"`
+}
+
+type TargetNotesDiff struct {
+	TargetID uint       `json:"target_id"`
+	From     int        `json:"from"`
+	To       int        `json:"to"`
+	Lines    []DiffLine `json:"lines"`
+}
+
+// TargetNotesDiffQuery selects the revisions to compare: by default the latest one against the one before
+type TargetNotesDiffQuery struct {
+	From *int `query:"from"`
+	To   *int `query:"to"`
+}
+
+// ImportReport tells which records of a bulk import were created and why the others were not
+type ImportReport struct {
+	Created int                `json:"created"`
+	Failed  int                `json:"failed"`
+	Items   []ImportItemResult `json:"items"`
+}
+
+// ImportItemResult is the outcome of one record; Item is its 1-based position in the input
+type ImportItemResult struct {
+	Item   int      `json:"item"`
+	ID     uint     `json:"id,omitempty"`
+	Errors []string `json:"errors,omitempty"`
+}
+
+type AuditEntry struct {
+	ID        uint                   `json:"id"`
+	RequestID string                 `json:"request_id"`
+	Actor     string                 `json:"actor"`
+	Action    AuditAction            `json:"action"`
+	Entity    string                 `json:"entity"`
+	EntityID  uint                   `json:"entity_id"`
+	Before    json.RawMessage        `json:"before,omitempty"`
+	After     json.RawMessage        `json:"after,omitempty"`
+	Changes   map[string]AuditChange `json:"changes,omitempty"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+type AuditChange struct {
+	From any `json:"from"`
+	To   any `json:"to"`
+}
+
+type AuditFilter struct {
+	ListParams
+	Entity    string      `query:"entity"`
+	EntityID  *uint       `query:"entity_id"`
+	Action    AuditAction `query:"action"`
+	Actor     string      `query:"actor"`
+	RequestID string      `query:"request_id"`
+	From      *time.Time  `query:"from"`
+	To        *time.Time  `query:"to"`
+}
+
+type AuditPage struct {
+	Items []AuditEntry `json:"items"`
+	PageInfo
+}
+
+// PayrollRun is the pay of every cat for one month
+type PayrollRun struct {
+	ID          uint          `json:"id"`
+	Period      string        `json:"period"`
+	PeriodStart string        `json:"period_start"`
+	PeriodEnd   string        `json:"period_end"`
+	Total       float64       `json:"total"`
+	CreatedBy   string        `json:"created_by"`
+	CreatedAt   time.Time     `json:"created_at"`
+	Lines       []PayrollLine `json:"lines,omitempty"`
+}
+
+type PayrollLine struct {
+	CatID    uint    `json:"cat_id"`
+	CatName  string  `json:"cat_name"`
+	DaysPaid int     `json:"days_paid"`
+	Amount   float64 `json:"amount"`
+}
+
+type PayrollRunCreate struct {
+	// Period is the month to pay, as YYYY-MM
+	Period string `json:"period"`
+}
+
+// Event is a change to a cat, mission or target, as delivered to webhooks and event streams
+type Event struct {
+	ID        uint64          `json:"id"`
+	Type      EventType       `json:"type"`
+	Entity    string          `json:"entity"`
+	EntityID  uint            `json:"entity_id"`
+	MissionID *uint           `json:"mission_id,omitempty"`
+	CatID     *uint           `json:"cat_id,omitempty"`
+	Data      json.RawMessage `json:"data"`
+	RequestID string          `json:"request_id,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// EventStreamFilter limits an event stream to a mission or cat. LastEventID resumes the stream after that event.
+type EventStreamFilter struct {
+	MissionID   *uint   `query:"mission_id"`
+	CatID       *uint   `query:"cat_id"`
+	LastEventID *uint64 `query:"last_event_id"`
+}
+
+// Webhook receives the events it subscribed to, or every event when Events is empty
+type Webhook struct {
+	ID        uint       `json:"id"`
+	URL       string     `json:"url"`
+	Events    []string   `json:"events"`
+	CreatedBy string     `json:"created_by"`
+	CreatedAt time.Time  `json:"created_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+type WebhookCreate struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events,omitempty"`
+}
+
+// WebhookSecret is returned once, when the webhook is registered. Deliveries are signed with Secret.
+type WebhookSecret struct {
+	Webhook
+	Secret string `json:"secret"`
+}
+
+type WebhookDelivery struct {
+	ID             uint64         `json:"id"`
+	WebhookID      uint           `json:"webhook_id"`
+	EventID        uint64         `json:"event_id"`
+	EventType      EventType      `json:"event_type"`
+	Status         DeliveryStatus `json:"status"`
+	Attempts       int            `json:"attempts"`
+	NextAttemptAt  time.Time      `json:"next_attempt_at"`
+	LastStatusCode *int           `json:"last_status_code,omitempty"`
+	LastError      string         `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time     `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time      `json:"created_at"`
+}
+
+type WebhookDeliveryFilter struct {
+	ListParams
+	Status    DeliveryStatus `query:"status"`
+	WebhookID *uint          `query:"webhook_id"`
+}
+
+type WebhookDeliveryPage struct {
+	Items []WebhookDelivery `json:"items"`
+	PageInfo
+}
+
+// GraphQLRequest is a query or mutation for the GraphQL endpoint
+type GraphQLRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// GraphQLResponse is the result of a GraphQL request. Data is decoded with Decode.
+type GraphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []GraphQLError  `json:"errors,omitempty"`
+}
+
+// GraphQLError is an error of a GraphQL request. Extensions["code"] tells its kind, e.g. NOT_FOUND.
+type GraphQLError struct {
+	Message    string         `json:"message"`
+	Path       []any          `json:"path,omitempty"`
+	Extensions map[string]any `json:"extensions,omitempty"`
+}